@@ -1,3 +1,17 @@
 data "polytomic_bulk_source" "source" {
   connection_id = "aab123aa-27f3-abc1-9999-abcde123a4aa"
 }
+
+# Tables in the public schema, with the fields which can be used to track
+# incremental changes.
+data "polytomic_bulk_source" "public" {
+  connection_id = "aab123aa-27f3-abc1-9999-abcde123a4aa"
+  schema_filter = "public.*"
+}
+
+locals {
+  tracking_fields = {
+    for s in data.polytomic_bulk_source.public.schemas :
+    s.id => [for f in s.fields : f.id if f.supports_tracking]
+  }
+}
@@ -0,0 +1,3 @@
+data "polytomic_connection" "warehouse" {
+  id = "aab123aa-27f3-abc1-9999-abcde123a4aa"
+}
@@ -0,0 +1,18 @@
+data "polytomic_model_preview" "users" {
+  connection_id = "bbd321bb-abc1-27f3-1111-abcde123a1bb"
+
+  configuration = jsonencode({
+    "database"   = "acme"
+    "collection" = "users"
+  })
+}
+
+resource "polytomic_model" "users" {
+  name          = "Users"
+  connection_id = data.polytomic_model_preview.users.connection_id
+  configuration = data.polytomic_model_preview.users.configuration
+
+  fields           = [for f in data.polytomic_model_preview.users.fields : f.name]
+  identifier       = data.polytomic_model_preview.users.identifier
+  tracking_columns = slice(data.polytomic_model_preview.users.tracking_columns, 0, 1)
+}
@@ -0,0 +1,16 @@
+data "polytomic_sync_dependency_graph" "graph" {}
+
+# Fail the plan if any run-after chain loops back on itself.
+check "no_run_after_cycles" {
+  assert {
+    condition     = length(data.polytomic_sync_dependency_graph.graph.cycles) == 0
+    error_message = "Run-after cycles: ${jsonencode(data.polytomic_sync_dependency_graph.graph.cycles)}"
+  }
+}
+
+output "run_after_roots" {
+  value = [
+    for node in data.polytomic_sync_dependency_graph.graph.nodes :
+    node.name if contains(data.polytomic_sync_dependency_graph.graph.roots, node.id)
+  ]
+}
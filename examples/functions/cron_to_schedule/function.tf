@@ -0,0 +1,6 @@
+resource "polytomic_sync" "contacts" {
+  name     = "Contacts"
+  mode     = "updateOrCreate"
+  schedule = provider::polytomic::cron_to_schedule("30 6 * * 1")
+  # ...
+}
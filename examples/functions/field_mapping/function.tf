@@ -0,0 +1,10 @@
+resource "polytomic_sync" "contacts" {
+  name = "Contacts"
+  mode = "updateOrCreate"
+  fields = provider::polytomic::field_mapping(polytomic_model.contacts.id, {
+    Email     = "email"
+    FirstName = "first_name"
+    LastName  = "last_name"
+  })
+  # ...
+}
@@ -0,0 +1,7 @@
+locals {
+  accounts = provider::polytomic::parse_schema_id(polytomic_connection_schema_primary_keys.accounts.id)
+}
+
+output "accounts_connection_id" {
+  value = local.accounts.connection_id
+}
@@ -0,0 +1,4 @@
+import {
+  to = polytomic_connection_schema_primary_keys.accounts
+  id = provider::polytomic::schema_id(var.organization_id, polytomic_salesforce_connection.example.id, "Account")
+}
@@ -1,4 +1,53 @@
-resource "polytomic_bulk_sync_schema" "schema" {
-  sync_id = "516a4fbe-464a-4678-8153-ec53d2e4bdd5"
-  id      = "project"
+# Example: Configure the schemas of a bulk sync individually
+#
+# With manage_schemas = false, the bulk sync is created with all of its
+# schemas disabled, and each polytomic_bulk_sync_schema enables and configures
+# one of them. The schema resources can live in different modules.
+
+resource "polytomic_bulk_sync" "warehouse" {
+  name           = "Warehouse"
+  active         = true
+  mode           = "replicate"
+  manage_schemas = false
+
+  schedule = {
+    frequency = "daily"
+    hour      = "2"
+    minute    = "0"
+  }
+
+  source = {
+    connection_id = "aab123aa-27f3-abc1-9999-abcde123a4aa"
+  }
+
+  destination = {
+    connection_id = "bbb123bb-27f3-abc1-9999-abcde123a4bb"
+    configuration = jsonencode({
+      schema = "replicated"
+    })
+  }
+}
+
+resource "polytomic_bulk_sync_schema" "users" {
+  bulk_sync_id   = polytomic_bulk_sync.warehouse.id
+  schema_id      = "public.users"
+  tracking_field = "updated_at"
+
+  fields = [{
+    id        = "email"
+    obfuscate = true
+  }]
+}
+
+resource "polytomic_bulk_sync_schema" "orders" {
+  bulk_sync_id     = polytomic_bulk_sync.warehouse.id
+  schema_id        = "public.orders"
+  partition_key    = "created_at"
+  user_output_name = "orders_raw"
+
+  filters = [{
+    field_id = "created_at"
+    function = "RelativeOnOrAfter"
+    value    = jsonencode("90 days ago")
+  }]
 }
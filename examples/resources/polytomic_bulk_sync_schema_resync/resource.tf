@@ -0,0 +1,10 @@
+# Resync the users table whenever its destination migration changes.
+resource "polytomic_bulk_sync_schema_resync" "users" {
+  bulk_sync_id        = polytomic_bulk_sync.warehouse.id
+  schemas             = ["public.users"]
+  wait_for_completion = true
+
+  triggers = {
+    migration = "2026-10-01-add-users-region"
+  }
+}
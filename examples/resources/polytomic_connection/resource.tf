@@ -0,0 +1,21 @@
+resource "polytomic_connection" "warehouse" {
+  name = "Warehouse"
+  type = "postgresql"
+  configuration = jsonencode({
+    hostname = "db.example.com"
+    port     = 5432
+    database = "analytics"
+    username = "polytomic"
+  })
+  sensitive_configuration = jsonencode({
+    password = var.warehouse_password
+  })
+}
+
+# Once the provider has a typed resource for the connection type, move the
+# connection to it without recreating it:
+#
+# moved {
+#   from = polytomic_connection.warehouse
+#   to   = polytomic_postgresql_connection.warehouse
+# }
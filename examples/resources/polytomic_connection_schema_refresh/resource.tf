@@ -0,0 +1,28 @@
+# Refresh the source's schemas when the expected tables change, so bulk syncs
+# are configured with the new tables.
+resource "polytomic_connection_schema_refresh" "warehouse" {
+  connection_id = "aab123aa-27f3-abc1-9999-abcde123a4aa"
+
+  triggers = {
+    tables = join(",", var.expected_tables)
+  }
+}
+
+resource "polytomic_bulk_sync" "warehouse" {
+  name   = "Warehouse"
+  active = true
+  mode   = "replicate"
+
+  schedule = {
+    frequency = "manual"
+  }
+  source = {
+    connection_id = polytomic_connection_schema_refresh.warehouse.connection_id
+  }
+  destination = {
+    connection_id = "bbd321bb-abc1-27f3-1111-abcde123a1bb"
+  }
+  schema_selection = {
+    include = ["public.*"]
+  }
+}
@@ -0,0 +1,33 @@
+# Example: Declare a model's relations apart from the model
+#
+# With manage_relations = false, the model's relations are configured by
+# polytomic_model_relation resources, which can live in a different module
+# from the models they relate, or relate models which relate to each other.
+
+resource "polytomic_model" "users" {
+  name             = "Users"
+  connection_id    = "bbd321bb-abc1-27f3-1111-abcde123a1bb"
+  configuration    = jsonencode({ table = "public.users" })
+  manage_relations = false
+}
+
+resource "polytomic_model" "accounts" {
+  name             = "Accounts"
+  connection_id    = "bbd321bb-abc1-27f3-1111-abcde123a1bb"
+  configuration    = jsonencode({ table = "public.accounts" })
+  manage_relations = false
+}
+
+resource "polytomic_model_relation" "users_accounts" {
+  model_id    = polytomic_model.users.id
+  from        = "account_id"
+  to_model_id = polytomic_model.accounts.id
+  to_field    = "id"
+}
+
+resource "polytomic_model_relation" "accounts_owner" {
+  model_id    = polytomic_model.accounts.id
+  from        = "owner_id"
+  to_model_id = polytomic_model.users.id
+  to_field    = "id"
+}
@@ -0,0 +1,10 @@
+resource "polytomic_oauth_connection" "airtable" {
+  name         = "Airtable"
+  type         = "airtable"
+  redirect_url = "https://example.com/connected"
+}
+
+output "airtable_authorization_url" {
+  value     = polytomic_oauth_connection.airtable.authorization_url
+  sensitive = true
+}
@@ -26,14 +26,16 @@ var (
 )
 
 type BulkSyncs struct {
-	c *ptclient.Client
+	c     *ptclient.Client
+	namer *Namer
 
 	Resources map[string]*polytomic.BulkSyncResponse
 }
 
-func NewBulkSyncs(c *ptclient.Client) *BulkSyncs {
+func NewBulkSyncs(c *ptclient.Client, namer *Namer) *BulkSyncs {
 	return &BulkSyncs{
 		c:         c,
+		namer:     namer,
 		Resources: make(map[string]*polytomic.BulkSyncResponse),
 	}
 }
@@ -43,10 +45,15 @@ func (b *BulkSyncs) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	sortByID(bulkSyncs.Data, func(i int) *string { return bulkSyncs.Data[i].Id })
 	for _, bulk := range bulkSyncs.Data {
 		// Bulk sync names are not unique, so we need to a slug to the name
 		// to make it unique.
-		name := provider.ValidName(provider.ToSnakeCase(pointer.GetString(bulk.Name)) + "_" + pointer.GetString(bulk.Id)[:8])
+		name := b.namer.Name(BulkSyncResource, NameData{
+			Type: BulkSyncResource,
+			Name: provider.ToSnakeCase(pointer.GetString(bulk.Name)) + "_" + pointer.GetString(bulk.Id)[:8],
+			ID:   pointer.GetString(bulk.Id),
+		})
 		b.Resources[name] = bulk
 	}
 
@@ -254,16 +261,18 @@ func (b *BulkSyncs) buildFieldMapping(bulkSync *polytomic.BulkSyncResponse, sche
 	return mapping
 }
 
-func (b *BulkSyncs) GenerateImports(ctx context.Context, writer io.Writer) error {
+func (b *BulkSyncs) Imports() []Import {
+	imports := make([]Import, 0, len(b.Resources))
 	for _, name := range sortedKeys(b.Resources) {
 		bulkSync := b.Resources[name]
-		writer.Write([]byte(fmt.Sprintf("terraform import %s.%s %s",
-			BulkSyncResource,
-			name,
-			pointer.GetString(bulkSync.Id))))
-		writer.Write([]byte(fmt.Sprintf(" # %s\n", pointer.GetString(bulkSync.Name))))
+		imports = append(imports, Import{
+			Resource: BulkSyncResource,
+			Name:     name,
+			ID:       pointer.GetString(bulkSync.Id),
+			Comment:  pointer.GetString(bulkSync.Name),
+		})
 	}
-	return nil
+	return imports
 }
 
 func (b *BulkSyncs) Filename() string {
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+
+	"github.com/polytomic/terraform-provider-polytomic/importer"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// driftExitCode is the exit status when drift is detected, distinguishing it
+// from errors (which exit with 1).
+const driftExitCode = 2
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Report drift between Polytomic and Terraform",
+	Long: `Compare the live configuration of Polytomic organizations with a directory of Terraform configuration (--config) or a Terraform state file (--state), and report objects which were added, removed or changed.
+
+Exits with status 2 if drift is detected.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		clientProvider := newClientProvider()
+		drift, err := importer.Diff(ctx, clientProvider, importer.DiffOptions{
+			Organizations:      viper.GetString("organizations"),
+			ConfigPath:         viper.GetString("config"),
+			StatePath:          viper.GetString("state"),
+			IncludePermissions: viper.GetBool("include-permissions"),
+			NameTemplate:       viper.GetString("name-template"),
+			Format:             viper.GetString("report-format"),
+		}, os.Stdout)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to compare organizations")
+		}
+		if drift {
+			os.Exit(driftExitCode)
+		}
+	},
+}
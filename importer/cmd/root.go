@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/polytomic/terraform-provider-polytomic/importer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -26,19 +27,39 @@ func newRootCmd(version string) *cobra.Command {
 	rootCmd.PersistentFlags().StringVar(&url, "url", "", "Polytomic API URL")
 	viper.BindPFlag("url", rootCmd.PersistentFlags().Lookup("url"))
 
+	// Flags shared by run and diff
+	var organizations string
+	rootCmd.PersistentFlags().StringVar(&organizations, "organizations", "", "Comma-separated list of organization IDs to import (partner-key or deployment-key only)")
+	rootCmd.PersistentFlags().Bool("include-permissions", false, "Include permission resources")
+	rootCmd.PersistentFlags().String("name-template", importer.DefaultNameTemplate, "Go template for generated resource names, e.g. \"{{.Type}}_{{.Name}}\"; fields are .Type, .Name and .ID")
+	viper.BindPFlag("organizations", rootCmd.PersistentFlags().Lookup("organizations"))
+	viper.BindPFlag("include-permissions", rootCmd.PersistentFlags().Lookup("include-permissions"))
+	viper.BindPFlag("name-template", rootCmd.PersistentFlags().Lookup("name-template"))
+
 	// Run flags
-	var output, organizations string
+	var output string
 	runCmd.PersistentFlags().StringVar(&output, "output", ".", "Output directory for generated files (defaults to current directory)")
-	runCmd.PersistentFlags().StringVar(&organizations, "organizations", "", "Comma-separated list of organization IDs to import (partner-key or deployment-key only)")
 	runCmd.PersistentFlags().Bool("replace", false, "Replace existing files")
-	runCmd.PersistentFlags().Bool("include-permissions", false, "Include permission resources")
+	runCmd.PersistentFlags().Bool("update", false, "Update previously exported files in place, preserving hand edits")
+	runCmd.PersistentFlags().String("import-mode", importer.ImportModeScript, "How to import existing resources: \"script\" writes import.sh, \"blocks\" writes Terraform import blocks to imports.tf")
+	runCmd.PersistentFlags().String("format", importer.FormatHCL, "Output format for generated Terraform: \"hcl\" (*.tf) or \"json\" (*.tf.json)")
 	viper.BindPFlag("output", runCmd.PersistentFlags().Lookup("output"))
-	viper.BindPFlag("organizations", runCmd.PersistentFlags().Lookup("organizations"))
 	viper.BindPFlag("replace", runCmd.PersistentFlags().Lookup("replace"))
-	viper.BindPFlag("include-permissions", runCmd.PersistentFlags().Lookup("include-permissions"))
+	viper.BindPFlag("update", runCmd.PersistentFlags().Lookup("update"))
+	viper.BindPFlag("import-mode", runCmd.PersistentFlags().Lookup("import-mode"))
+	viper.BindPFlag("format", runCmd.PersistentFlags().Lookup("format"))
+
+	// Diff flags
+	diffCmd.PersistentFlags().String("config", "", "Directory of Terraform configuration to compare against")
+	diffCmd.PersistentFlags().String("state", "", "Terraform state file to compare against")
+	diffCmd.PersistentFlags().String("format", importer.ReportFormatText, "Report format: \"text\" or \"json\"")
+	viper.BindPFlag("config", diffCmd.PersistentFlags().Lookup("config"))
+	viper.BindPFlag("state", diffCmd.PersistentFlags().Lookup("state"))
+	viper.BindPFlag("report-format", diffCmd.PersistentFlags().Lookup("format"))
 
 	// Register commands
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(diffCmd)
 
 	// Hide completions
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
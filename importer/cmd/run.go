@@ -11,41 +11,62 @@ import (
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Run the Polytomic importer",
-	Long:  `Export existing Polytomic resources into Terraform by creating the necessary *.tf files and an associated import.sh script.`,
+	Long:  `Export existing Polytomic resources into Terraform by creating the necessary *.tf files and either an import.sh script or Terraform import blocks.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 
-		url := viper.GetString("url")
-		apiKey := viper.GetString("api-key")
-		partnerKey := viper.GetString("partner-key")
-		deploymentKey := viper.GetString("deployment-key")
 		organizations := viper.GetString("organizations")
 		path := viper.GetString("output")
 		replace := viper.GetBool("replace")
 		includePermissions := viper.GetBool("include-permissions")
+		update := viper.GetBool("update")
+		importMode := viper.GetString("import-mode")
+		format := viper.GetString("format")
+		nameTemplate := viper.GetString("name-template")
 
-		if apiKey == "" && partnerKey == "" && deploymentKey == "" {
-			log.Fatal().Msg("either --api-key, --partner-key, or --deployment-key must be provided")
-		}
-
-		clientOpts := providerclient.OptionsFromEnv()
-		if url != "" {
-			clientOpts.DeploymentURL = url
-		}
-		// Note: Default URL is handled by NewClientProvider if empty
-		if apiKey != "" {
-			clientOpts.APIKey = apiKey
-		}
-		if partnerKey != "" {
-			clientOpts.PartnerKey = partnerKey
-		}
-		if deploymentKey != "" {
-			clientOpts.DeploymentKey = deploymentKey
-		}
-		clientProvider, err := providerclient.NewClientProvider(clientOpts)
-		if err != nil {
-			log.Fatal().Err(err).Msg("failed to create client provider")
-		}
-		importer.Init(ctx, clientProvider, organizations, path, replace, includePermissions)
+		clientProvider := newClientProvider()
+		importer.Init(ctx, clientProvider, importer.Options{
+			Organizations:      organizations,
+			OutputPath:         path,
+			Replace:            replace,
+			IncludePermissions: includePermissions,
+			Update:             update,
+			ImportMode:         importMode,
+			Format:             format,
+			NameTemplate:       nameTemplate,
+		})
 	},
 }
+
+// newClientProvider returns a client provider configured from the
+// authentication flags and environment.
+func newClientProvider() *providerclient.Provider {
+	url := viper.GetString("url")
+	apiKey := viper.GetString("api-key")
+	partnerKey := viper.GetString("partner-key")
+	deploymentKey := viper.GetString("deployment-key")
+
+	if apiKey == "" && partnerKey == "" && deploymentKey == "" {
+		log.Fatal().Msg("either --api-key, --partner-key, or --deployment-key must be provided")
+	}
+
+	clientOpts := providerclient.OptionsFromEnv()
+	if url != "" {
+		clientOpts.DeploymentURL = url
+	}
+	// Note: Default URL is handled by NewClientProvider if empty
+	if apiKey != "" {
+		clientOpts.APIKey = apiKey
+	}
+	if partnerKey != "" {
+		clientOpts.PartnerKey = partnerKey
+	}
+	if deploymentKey != "" {
+		clientOpts.DeploymentKey = deploymentKey
+	}
+	clientProvider, err := providerclient.NewClientProvider(clientOpts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create client provider")
+	}
+	return clientProvider
+}
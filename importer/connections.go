@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +24,12 @@ import (
 
 const (
 	ConnectionsResourceFileName = "connections.tf"
+	GenericConnectionResource   = "polytomic_connection"
+
+	// connectionResourceScope and connectionDatasourceScope are the naming
+	// scopes for connection resources and data sources.
+	connectionResourceScope   = "connections"
+	connectionDatasourceScope = "data.connections"
 )
 
 // varSentinelRe matches the placeholder strings we plant for required
@@ -45,7 +54,8 @@ var (
 )
 
 type Connections struct {
-	c *ptclient.Client
+	c     *ptclient.Client
+	namer *Namer
 
 	Resources   map[string]Connection
 	Datasources map[string]Connection
@@ -62,11 +72,17 @@ type Connection struct {
 	Name          *string
 	Organization  *string
 	Configuration interface{}
+
+	// Generic is true for connections whose type has no typed resource;
+	// they are generated as polytomic_connection with a JSON
+	// configuration.
+	Generic bool
 }
 
-func NewConnections(c *ptclient.Client) *Connections {
+func NewConnections(c *ptclient.Client, namer *Namer) *Connections {
 	return &Connections{
 		c:           c,
+		namer:       namer,
 		Resources:   make(map[string]Connection),
 		Datasources: make(map[string]Connection),
 	}
@@ -77,13 +93,20 @@ func (c *Connections) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	sortByID(conns.Data, func(i int) *string { return conns.Data[i].Id })
 	for _, conn := range conns.Data {
-		name := provider.ValidName(provider.ToSnakeCase(pointer.GetString(conn.Name)))
 		if r, ok := provider.ConnectionsMap[pointer.GetString(conn.Type.Id)]; ok {
 			resp := &resource.MetadataResponse{}
 			r.Metadata(ctx, resource.MetadataRequest{
 				ProviderTypeName: provider.Name,
 			}, resp)
+			// Connections of every type share the Resources map, so names
+			// must be unique across connection types.
+			name := c.namer.Name(connectionResourceScope, NameData{
+				Type: resp.TypeName,
+				Name: provider.ToSnakeCase(pointer.GetString(conn.Name)),
+				ID:   pointer.GetString(conn.Id),
+			})
 
 			schemaResp := &resource.SchemaResponse{}
 			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
@@ -200,6 +223,11 @@ func (c *Connections) Init(ctx context.Context) error {
 			d.Metadata(ctx, datasource.MetadataRequest{
 				ProviderTypeName: provider.Name,
 			}, resp)
+			name := c.namer.Name(connectionDatasourceScope, NameData{
+				Type: resp.TypeName,
+				Name: provider.ToSnakeCase(pointer.GetString(conn.Name)),
+				ID:   pointer.GetString(conn.Id),
+			})
 
 			// Get datasource schema for validation
 			schemaReq := datasource.SchemaRequest{}
@@ -228,7 +256,32 @@ func (c *Connections) Init(ctx context.Context) error {
 			}
 
 		} else {
-			log.Warn().Msgf("connection type %s not supported", pointer.GetString(conn.Type.Id))
+			// Fall back to the generic connection resource for types the
+			// provider doesn't know about yet.
+			name := c.namer.Name(connectionResourceScope, NameData{
+				Type: GenericConnectionResource,
+				Name: provider.ToSnakeCase(pointer.GetString(conn.Name)),
+				ID:   pointer.GetString(conn.Id),
+			})
+			var config map[string]interface{}
+			err := mapstructure.Decode(conn.Configuration, &config)
+			if err != nil {
+				return err
+			}
+			log.Info().
+				Str("connection", pointer.GetString(conn.Name)).
+				Str("type", pointer.GetString(conn.Type.Id)).
+				Msg("using generic connection resource for unsupported connection type")
+
+			c.Resources[name] = Connection{
+				ID:            conn.Id,
+				Type:          conn.Type.Id,
+				Resource:      GenericConnectionResource,
+				Name:          conn.Name,
+				Organization:  conn.OrganizationId,
+				Configuration: config,
+				Generic:       true,
+			}
 		}
 	}
 
@@ -281,7 +334,23 @@ func (c *Connections) GenerateTerraformFiles(ctx context.Context, writer io.Writ
 			},
 		)
 
-		resourceBlock.Body().SetAttributeValue("configuration", config)
+		if conn.Generic {
+			// Sensitive values are masked by the API, so they can't be
+			// exported; the user must supply them.
+			conf, masked := splitMaskedValues(conn.Configuration)
+			comment := "# Move secrets to sensitive_configuration; their values are not exported.\n"
+			if len(masked) > 0 {
+				comment = fmt.Sprintf("# TODO: set %s in sensitive_configuration; their values are masked by Polytomic and are not exported.\n",
+					strings.Join(masked, ", "))
+			}
+			resourceBlock.Body().SetAttributeValue("type", cty.StringVal(pointer.GetString(conn.Type)))
+			resourceBlock.Body().AppendUnstructuredTokens(hclwrite.Tokens{
+				{Type: hclsyntax.TokenComment, Bytes: []byte(comment)},
+			})
+			resourceBlock.Body().SetAttributeRaw("configuration", wrapJSONEncode(conf))
+		} else {
+			resourceBlock.Body().SetAttributeValue("configuration", config)
+		}
 		body.AppendNewline()
 
 		writer.Write(substituteVarRefs(hclFile.Bytes()))
@@ -290,17 +359,35 @@ func (c *Connections) GenerateTerraformFiles(ctx context.Context, writer io.Writ
 
 }
 
-func (c *Connections) GenerateImports(ctx context.Context, writer io.Writer) error {
+// splitMaskedValues returns a generic connection's configuration without the
+// sensitive values masked by the API, and the sorted keys of those values.
+func splitMaskedValues(configuration any) (map[string]any, []string) {
+	conf, _ := configuration.(map[string]any)
+	exported := make(map[string]any, len(conf))
+	var masked []string
+	for k, v := range conf {
+		if s, ok := v.(string); ok && s != "" && strings.Trim(s, "*") == "" {
+			masked = append(masked, k)
+			continue
+		}
+		exported[k] = v
+	}
+	slices.Sort(masked)
+	return exported, masked
+}
+
+func (c *Connections) Imports() []Import {
+	imports := make([]Import, 0, len(c.Resources))
 	for _, name := range sortedKeys(c.Resources) {
 		conn := c.Resources[name]
-		fmt.Fprintf(writer, "terraform import %s.%s %s # %s\n",
-			conn.Resource,
-			name,
-			pointer.Get(conn.ID),
-			pointer.Get(conn.Name),
-		)
+		imports = append(imports, Import{
+			Resource: conn.Resource,
+			Name:     name,
+			ID:       pointer.Get(conn.ID),
+			Comment:  pointer.Get(conn.Name),
+		})
 	}
-	return nil
+	return imports
 }
 
 func (c *Connections) Filename() string {
@@ -79,3 +79,50 @@ func TestRenderConnectionWithVarRef(t *testing.T) {
 		t.Errorf("placeholder leaked in output:\n%s", out)
 	}
 }
+
+// TestRenderGenericConnection verifies that connections of unsupported types
+// are rendered as polytomic_connection with a type and JSON configuration,
+// without the values masked by the API.
+func TestRenderGenericConnection(t *testing.T) {
+	c := &Connections{
+		Resources: map[string]Connection{
+			"warehouse": {
+				ID:           pointer.ToString("conn-id"),
+				Type:         pointer.ToString("newdb"),
+				Resource:     GenericConnectionResource,
+				Name:         pointer.ToString("Warehouse"),
+				Organization: pointer.ToString("org-id"),
+				Configuration: map[string]interface{}{
+					"hostname": "db.example.com",
+					"password": "********",
+					"api_key":  "****",
+				},
+				Generic: true,
+			},
+		},
+		Datasources: map[string]Connection{},
+	}
+
+	var buf bytes.Buffer
+	if err := c.GenerateTerraformFiles(t.Context(), &buf, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	out := hclwrite.Format(buf.Bytes())
+
+	want := []string{
+		`resource "polytomic_connection" "warehouse" {`,
+		`type         = "newdb"`,
+		"configuration = jsonencode({",
+		`hostname = "db.example.com"`,
+		"# TODO: set api_key, password in sensitive_configuration",
+	}
+	for _, w := range want {
+		if !bytes.Contains(out, []byte(w)) {
+			t.Errorf("missing %q in output:\n%s", w, out)
+		}
+	}
+	// masked values aren't exported
+	if bytes.Contains(out, []byte("****")) {
+		t.Errorf("masked value in output:\n%s", out)
+	}
+}
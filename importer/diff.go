@@ -0,0 +1,791 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const (
+	// ReportFormatText writes a human-readable drift report.
+	ReportFormatText = "text"
+	// ReportFormatJSON writes a drift report as JSON.
+	ReportFormatJSON = "json"
+)
+
+// DiffOptions configure a drift report.
+type DiffOptions struct {
+	// Organizations is a comma-separated list of organization IDs to compare;
+	// if empty all accessible organizations are compared.
+	Organizations string
+	// ConfigPath is a directory of Terraform configuration to compare
+	// against. When comparing multiple organizations, each organization's
+	// configuration is read from a subdirectory named after it, matching the
+	// layout written by the importer.
+	ConfigPath string
+	// StatePath is a Terraform state file to compare against. Exactly one of
+	// ConfigPath and StatePath must be set.
+	StatePath          string
+	IncludePermissions bool
+	// NameTemplate must match the template used to export ConfigPath.
+	NameTemplate string
+	// Format is one of ReportFormatText (the default) or ReportFormatJSON.
+	Format string
+}
+
+// Validate checks the options, filling in defaults for empty values.
+func (o *DiffOptions) Validate() error {
+	if o.Format == "" {
+		o.Format = ReportFormatText
+	}
+	if o.NameTemplate == "" {
+		o.NameTemplate = DefaultNameTemplate
+	}
+	if (o.ConfigPath == "") == (o.StatePath == "") {
+		return fmt.Errorf("exactly one of a configuration directory or a state file must be provided")
+	}
+	if o.Format != ReportFormatText && o.Format != ReportFormatJSON {
+		return fmt.Errorf("invalid format %q: must be %q or %q", o.Format, ReportFormatText, ReportFormatJSON)
+	}
+	_, err := NewNamer(o.NameTemplate)
+	return err
+}
+
+// DriftReport describes the differences between Polytomic and Terraform for
+// each organization compared.
+type DriftReport struct {
+	Organizations []OrganizationDrift `json:"organizations"`
+}
+
+// OrganizationDrift describes the differences between Polytomic and
+// Terraform for a single organization.
+type OrganizationDrift struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Added objects exist in Polytomic but not in Terraform.
+	Added []DriftObject `json:"added"`
+	// Removed objects exist in Terraform but not in Polytomic.
+	Removed []DriftObject `json:"removed"`
+	// Changed objects exist in both, with different attribute values.
+	Changed []DriftObject `json:"changed"`
+}
+
+// DriftObject identifies an object which has drifted.
+type DriftObject struct {
+	Address    string          `json:"address"`
+	ID         string          `json:"id,omitempty"`
+	Attributes []AttributeDiff `json:"attributes,omitempty"`
+}
+
+// AttributeDiff is an attribute whose value differs between Terraform and
+// Polytomic. Values are HCL expressions when comparing configuration, and
+// JSON when comparing state.
+type AttributeDiff struct {
+	Name      string `json:"name"`
+	Terraform string `json:"terraform"`
+	Polytomic string `json:"polytomic"`
+}
+
+// HasDrift reports whether any organization has drifted.
+func (r DriftReport) HasDrift() bool {
+	for _, org := range r.Organizations {
+		if org.HasDrift() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDrift reports whether the organization has drifted.
+func (o OrganizationDrift) HasDrift() bool {
+	return len(o.Added)+len(o.Removed)+len(o.Changed) > 0
+}
+
+// Diff compares the live configuration of each organization with Terraform
+// configuration or state, and writes a drift report to w. It returns true if
+// drift was detected.
+func Diff(ctx context.Context, clientProvider *providerclient.Provider, opts DiffOptions, w io.Writer) (bool, error) {
+	err := opts.Validate()
+	if err != nil {
+		return false, err
+	}
+
+	targetOrgs, err := targetOrganizations(ctx, clientProvider, opts.Organizations)
+	if err != nil {
+		return false, err
+	}
+	if opts.StatePath != "" && len(targetOrgs) > 1 {
+		return false, fmt.Errorf("comparing against a state file requires a single organization; use --organizations to select one")
+	}
+
+	var state *tfState
+	if opts.StatePath != "" {
+		state, err = readState(opts.StatePath)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	multiOrg := len(targetOrgs) > 1
+	report := DriftReport{}
+	for _, org := range targetOrgs {
+		orgClient, err := clientProvider.Client(ctx, pointer.Get(org.Id))
+		if err != nil {
+			return false, fmt.Errorf("creating client for organization %s: %w", pointer.Get(org.Id), err)
+		}
+		dir := opts.ConfigPath
+		if multiOrg {
+			dir = filepath.Join(dir, pointer.Get(org.Name))
+		}
+		// Objects are named as they were when the configuration was last
+		// exported or updated.
+		var names map[string]map[string]string
+		if state == nil {
+			names, err = readNamesSnapshot(dir)
+			if err != nil {
+				return false, err
+			}
+		}
+		exp, err := generate(ctx, org, orgClient, Options{
+			IncludePermissions: opts.IncludePermissions,
+			NameTemplate:       opts.NameTemplate,
+		}, multiOrg, names)
+		if err != nil {
+			return false, err
+		}
+		live, err := liveResources(exp)
+		if err != nil {
+			return false, err
+		}
+
+		drift := OrganizationDrift{
+			ID:   pointer.Get(org.Id),
+			Name: pointer.Get(org.Name),
+		}
+		scope := func(typ string) bool {
+			return exportedResourceType(typ, opts.IncludePermissions, multiOrg)
+		}
+		if state != nil {
+			drift.Added, drift.Removed, drift.Changed = diffState(live, state, scope)
+		} else {
+			config, err := readConfigDir(dir)
+			if err != nil {
+				return false, err
+			}
+			drift.Added, drift.Removed, drift.Changed = diffConfig(live, config, scope)
+		}
+		report.Organizations = append(report.Organizations, drift)
+	}
+
+	switch opts.Format {
+	case ReportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	default:
+		err = writeTextReport(w, report)
+	}
+	return report.HasDrift(), err
+}
+
+// liveResource is a resource block generated from the live organization.
+type liveResource struct {
+	Address string
+	Type    string
+	ID      string
+	Body    *hclsyntax.Body
+	Src     []byte
+}
+
+// liveResources returns the resource blocks of an export, in order.
+func liveResources(exp *export) ([]liveResource, error) {
+	ids := map[string]string{}
+	for _, i := range exp.Imports {
+		ids[i.Address()] = i.ID
+	}
+
+	var resources []liveResource
+	for _, f := range exp.Files {
+		file, diags := hclsyntax.ParseConfig(f.Content, f.Filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("parsing generated %s: %w", f.Filename, diags)
+		}
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "resource" || len(block.Labels) != 2 {
+				continue
+			}
+			addr := strings.Join(block.Labels, ".")
+			resources = append(resources, liveResource{
+				Address: addr,
+				Type:    block.Labels[0],
+				ID:      ids[addr],
+				Body:    block.Body,
+				Src:     f.Content,
+			})
+		}
+	}
+	return resources, nil
+}
+
+// exportedResourceType reports whether resources of type typ are exported by
+// the importer. Only these are reported as removed when they don't match a
+// live object.
+func exportedResourceType(typ string, includePermissions, orgResource bool) bool {
+	switch typ {
+	case SyncResource, BulkSyncResource, ModelResource, GlobalErrorSubscribersResourceType:
+		return true
+	case RoleResource, PolicyResource:
+		return includePermissions
+	case "polytomic_organization":
+		return orgResource
+	}
+	return strings.HasPrefix(typ, "polytomic_") && strings.HasSuffix(typ, "_connection")
+}
+
+// configResource is a resource block read from existing configuration.
+type configResource struct {
+	Address    string
+	Type       string
+	Attributes map[string]configAttribute
+}
+
+// configAttribute is an attribute of a resource block read from existing
+// configuration.
+type configAttribute struct {
+	// Src is the attribute's normalized expression.
+	Src string
+	// Value is the attribute's value if it was read from JSON configuration
+	// and could be evaluated, and cty.NilVal otherwise. JSON configuration is
+	// compared by value, since its expressions aren't written the way the
+	// generated configuration is.
+	Value cty.Value
+}
+
+// configFunctions are the functions available when evaluating configuration.
+var configFunctions = map[string]function.Function{
+	"jsonencode": stdlib.JSONEncodeFunc,
+}
+
+// readConfigDir reads the resource blocks of the Terraform files, in native
+// or JSON syntax, in dir.
+func readConfigDir(dir string) ([]configResource, error) {
+	var files []string
+	for _, pattern := range []string{"*.tf", "*.tf.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Terraform files found in %s", dir)
+	}
+
+	var resources []configResource
+	for _, filename := range files {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		read := readConfig
+		if strings.HasSuffix(filename, ".json") {
+			read = readJSONConfig
+		}
+		r, err := read(src, filename)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r...)
+	}
+	return resources, nil
+}
+
+// readConfig reads the resource blocks of a Terraform file.
+func readConfig(src []byte, filename string) ([]configResource, error) {
+	file, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %w", filename, diags)
+	}
+	var resources []configResource
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != "resource" || len(block.Labels()) != 2 {
+			continue
+		}
+		attrs := map[string]configAttribute{}
+		for name, attr := range block.Body().Attributes() {
+			attrs[name] = configAttribute{Src: string(exprBytes(attr))}
+		}
+		resources = append(resources, configResource{
+			Address:    blockAddress(block),
+			Type:       block.Labels()[0],
+			Attributes: attrs,
+		})
+	}
+	return resources, nil
+}
+
+// readJSONConfig reads the resource blocks of a Terraform file in JSON syntax.
+func readJSONConfig(src []byte, filename string) ([]configResource, error) {
+	file, diags := hcljson.Parse(src, filename)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %w", filename, diags)
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %w", filename, diags)
+	}
+
+	var resources []configResource
+	for _, block := range content.Blocks {
+		// Skip the nested blocks, which are attributes in JSON syntax.
+		_, body, diags := block.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{Type: "lifecycle"},
+				{Type: "connection"},
+				{Type: "provisioner", LabelNames: []string{"type"}},
+			},
+		})
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("parsing %s: %w", filename, diags)
+		}
+		jsonAttrs, diags := body.JustAttributes()
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("parsing %s: %w", filename, diags)
+		}
+
+		attrs := map[string]configAttribute{}
+		for name, attr := range jsonAttrs {
+			value, diags := attr.Expr.Value(&hcl.EvalContext{Functions: configFunctions})
+			if diags.HasErrors() || !value.IsWhollyKnown() {
+				attrs[name] = configAttribute{Src: jsonExprSrc(attr.Expr.Range().SliceBytes(src))}
+				continue
+			}
+			attrs[name] = configAttribute{
+				Src:   string(normalizeExpr(hclwrite.TokensForValue(value).Bytes())),
+				Value: value,
+			}
+		}
+		resources = append(resources, configResource{
+			Address:    strings.Join(block.Labels, "."),
+			Type:       block.Labels[0],
+			Attributes: attrs,
+		})
+	}
+	return resources, nil
+}
+
+// jsonExprSrc returns the normalized source of a JSON expression. A string
+// which only interpolates an expression, such as "${var.name}", returns the
+// interpolated expression, which is how it's written in native syntax.
+func jsonExprSrc(src []byte) string {
+	var str string
+	if json.Unmarshal(src, &str) != nil {
+		return string(src)
+	}
+	expr, diags := hclsyntax.ParseTemplate([]byte(str), "", hcl.InitialPos)
+	if wrap, ok := expr.(*hclsyntax.TemplateWrapExpr); ok && !diags.HasErrors() {
+		return string(normalizeExpr(wrap.Wrapped.Range().SliceBytes([]byte(str))))
+	}
+	return string(src)
+}
+
+// valueEqual reports whether expr evaluates to value without referring to
+// other objects.
+func valueEqual(expr hcl.Expression, value cty.Value) bool {
+	got, diags := expr.Value(&hcl.EvalContext{Functions: configFunctions})
+	if diags.HasErrors() || !got.IsWhollyKnown() {
+		return false
+	}
+	gotJSON, err := ctyjson.Marshal(got, got.Type())
+	if err != nil {
+		return false
+	}
+	wantJSON, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(gotJSON, wantJSON)
+}
+
+// diffConfig compares live resources with configuration by address. Attribute
+// values are compared as normalized HCL expressions, so an attribute which
+// has been replaced by a reference (for example, to a variable) is reported
+// as changed.
+func diffConfig(live []liveResource, config []configResource, scope func(string) bool) (added, removed, changed []DriftObject) {
+	configByAddr := map[string]configResource{}
+	for _, r := range config {
+		configByAddr[r.Address] = r
+	}
+	liveByAddr := map[string]bool{}
+
+	for _, l := range live {
+		liveByAddr[l.Address] = true
+		c, ok := configByAddr[l.Address]
+		if !ok {
+			added = append(added, DriftObject{Address: l.Address, ID: l.ID})
+			continue
+		}
+
+		var attrs []AttributeDiff
+		for _, name := range sortedKeys(l.Body.Attributes) {
+			want := string(normalizeExpr(l.Body.Attributes[name].Expr.Range().SliceBytes(l.Src)))
+			got := ""
+			if attr, ok := c.Attributes[name]; ok {
+				if attr.Value != cty.NilVal && valueEqual(l.Body.Attributes[name].Expr, attr.Value) {
+					continue
+				}
+				got = attr.Src
+			}
+			if got != want {
+				attrs = append(attrs, AttributeDiff{Name: name, Terraform: got, Polytomic: want})
+			}
+		}
+		for _, name := range sortedKeys(c.Attributes) {
+			if _, ok := l.Body.Attributes[name]; !ok {
+				attrs = append(attrs, AttributeDiff{Name: name, Terraform: c.Attributes[name].Src})
+			}
+		}
+		if len(attrs) > 0 {
+			changed = append(changed, DriftObject{Address: l.Address, ID: l.ID, Attributes: attrs})
+		}
+	}
+
+	for _, c := range config {
+		if !liveByAddr[c.Address] && scope(c.Type) {
+			removed = append(removed, DriftObject{Address: c.Address})
+		}
+	}
+	return added, removed, changed
+}
+
+// normalizeExpr formats the source of an expression for comparison.
+func normalizeExpr(src []byte) []byte {
+	return bytes.TrimSpace(hclwrite.Format(src))
+}
+
+// tfState is the subset of the Terraform state file format (version 4) used
+// to detect drift.
+type tfState struct {
+	Version   int               `json:"version"`
+	Resources []tfStateResource `json:"resources"`
+}
+
+type tfStateResource struct {
+	Module    string            `json:"module,omitempty"`
+	Mode      string            `json:"mode"`
+	Type      string            `json:"type"`
+	Name      string            `json:"name"`
+	Instances []tfStateInstance `json:"instances"`
+}
+
+type tfStateInstance struct {
+	IndexKey   any                        `json:"index_key,omitempty"`
+	Attributes map[string]json.RawMessage `json:"attributes"`
+}
+
+// stateObject is a resource instance in state.
+type stateObject struct {
+	Address    string
+	Type       string
+	ID         string
+	Attributes map[string]json.RawMessage
+}
+
+func readState(path string) (*tfState, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state tfState
+	err = json.Unmarshal(src, &state)
+	if err != nil {
+		return nil, fmt.Errorf("parsing state %s: %w", path, err)
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported state version %d", state.Version)
+	}
+	return &state, nil
+}
+
+// objects returns the managed resource instances in state.
+func (s *tfState) objects() []stateObject {
+	var objects []stateObject
+	for _, r := range s.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+		prefix := ""
+		if r.Module != "" {
+			prefix = r.Module + "."
+		}
+		for _, inst := range r.Instances {
+			addr := prefix + r.Type + "." + r.Name
+			switch key := inst.IndexKey.(type) {
+			case string:
+				addr += fmt.Sprintf("[%q]", key)
+			case float64:
+				addr += fmt.Sprintf("[%d]", int(key))
+			}
+			var id string
+			_ = json.Unmarshal(inst.Attributes["id"], &id)
+			objects = append(objects, stateObject{
+				Address:    addr,
+				Type:       r.Type,
+				ID:         id,
+				Attributes: inst.Attributes,
+			})
+		}
+	}
+	return objects
+}
+
+// evalContext returns an evaluation context in which references to root
+// module resources and data sources resolve to their values in state.
+// Variables and locals are unknown.
+func (s *tfState) evalContext() *hcl.EvalContext {
+	resources := map[string]map[string]cty.Value{}
+	data := map[string]map[string]cty.Value{}
+	for _, r := range s.Resources {
+		if r.Module != "" || len(r.Instances) != 1 || r.Instances[0].IndexKey != nil {
+			continue
+		}
+		value, err := attributesValue(r.Instances[0].Attributes)
+		if err != nil {
+			continue
+		}
+		target := resources
+		if r.Mode == "data" {
+			target = data
+		}
+		if target[r.Type] == nil {
+			target[r.Type] = map[string]cty.Value{}
+		}
+		target[r.Type][r.Name] = value
+	}
+
+	vars := map[string]cty.Value{
+		"var":   cty.DynamicVal,
+		"local": cty.DynamicVal,
+	}
+	for typ, names := range resources {
+		vars[typ] = cty.ObjectVal(names)
+	}
+	dataVals := map[string]cty.Value{}
+	for typ, names := range data {
+		dataVals[typ] = cty.ObjectVal(names)
+	}
+	vars["data"] = cty.ObjectVal(dataVals)
+
+	return &hcl.EvalContext{
+		Variables: vars,
+		Functions: configFunctions,
+	}
+}
+
+// attributesValue converts state attributes to an object value.
+func attributesValue(attrs map[string]json.RawMessage) (cty.Value, error) {
+	src, err := json.Marshal(attrs)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	ty, err := ctyjson.ImpliedType(src)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(src, ty)
+}
+
+// diffState compares live resources with state, matching objects by ID and
+// falling back to the resource address. Attributes set in the generated
+// configuration are evaluated against state and compared by value;
+// attributes which can't be evaluated (for example, those referring to
+// variables) are skipped.
+func diffState(live []liveResource, state *tfState, scope func(string) bool) (added, removed, changed []DriftObject) {
+	objects := state.objects()
+	byID := map[string]int{}
+	byAddr := map[string]int{}
+	for i, o := range objects {
+		if o.ID != "" {
+			byID[o.Type+"/"+o.ID] = i
+		}
+		byAddr[o.Address] = i
+	}
+	ctx := state.evalContext()
+
+	matched := map[int]bool{}
+	for _, l := range live {
+		i, ok := byID[l.Type+"/"+l.ID]
+		if !ok || l.ID == "" {
+			i, ok = byAddr[l.Address]
+		}
+		if !ok {
+			added = append(added, DriftObject{Address: l.Address, ID: l.ID})
+			continue
+		}
+		matched[i] = true
+		o := objects[i]
+
+		var attrs []AttributeDiff
+		for _, name := range sortedKeys(l.Body.Attributes) {
+			value, diags := l.Body.Attributes[name].Expr.Value(ctx)
+			if diags.HasErrors() || !value.IsWhollyKnown() {
+				continue
+			}
+			want, err := ctyjson.Marshal(value, value.Type())
+			if err != nil {
+				continue
+			}
+			got := o.Attributes[name]
+			if !jsonSubsetEqual(want, got) {
+				if len(got) == 0 {
+					got = json.RawMessage("null")
+				}
+				attrs = append(attrs, AttributeDiff{Name: name, Terraform: string(got), Polytomic: string(want)})
+			}
+		}
+		if len(attrs) > 0 {
+			addr := l.Address
+			if o.Address != l.Address {
+				addr = o.Address
+			}
+			changed = append(changed, DriftObject{Address: addr, ID: l.ID, Attributes: attrs})
+		}
+	}
+
+	for i, o := range objects {
+		if !matched[i] && scope(o.Type) {
+			removed = append(removed, DriftObject{Address: o.Address, ID: o.ID})
+		}
+	}
+	return added, removed, changed
+}
+
+// jsonSubsetEqual reports whether the JSON value want is equal to got,
+// ignoring object properties which are null or absent in want (state records
+// every attribute of a resource, including those which are computed).
+// Strings containing JSON documents, such as jsonencode results, are compared
+// by value.
+func jsonSubsetEqual(want, got []byte) bool {
+	var w, g any
+	if json.Unmarshal(want, &w) != nil {
+		return false
+	}
+	if len(got) == 0 {
+		return w == nil
+	}
+	if json.Unmarshal(got, &g) != nil {
+		return false
+	}
+	return subsetEqual(w, g)
+}
+
+func subsetEqual(want, got any) bool {
+	switch w := want.(type) {
+	case nil:
+		return got == nil
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			if v == nil {
+				continue
+			}
+			if !subsetEqual(v, g[k]) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !subsetEqual(w[i], g[i]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		g, ok := got.(string)
+		if !ok {
+			return false
+		}
+		if w == g {
+			return true
+		}
+		var wj, gj any
+		if json.Unmarshal([]byte(w), &wj) == nil && json.Unmarshal([]byte(g), &gj) == nil {
+			return reflect.DeepEqual(wj, gj)
+		}
+		return false
+	}
+	return reflect.DeepEqual(want, got)
+}
+
+// writeTextReport writes a human-readable drift report.
+func writeTextReport(w io.Writer, report DriftReport) error {
+	var buf bytes.Buffer
+	for i, org := range report.Organizations {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "Organization %s (%s)\n", org.Name, org.ID)
+		if !org.HasDrift() {
+			buf.WriteString("  No drift detected.\n")
+			continue
+		}
+
+		for _, o := range org.Added {
+			fmt.Fprintf(&buf, "  + %s%s: exists in Polytomic but not in Terraform\n", o.Address, idSuffix(o.ID))
+		}
+		for _, o := range org.Removed {
+			fmt.Fprintf(&buf, "  - %s%s: exists in Terraform but not in Polytomic\n", o.Address, idSuffix(o.ID))
+		}
+		for _, o := range org.Changed {
+			fmt.Fprintf(&buf, "  ~ %s%s\n", o.Address, idSuffix(o.ID))
+			for _, a := range o.Attributes {
+				fmt.Fprintf(&buf, "      %s: %s => %s\n", a.Name, displayValue(a.Terraform), displayValue(a.Polytomic))
+			}
+		}
+		fmt.Fprintf(&buf, "  %d added, %d removed, %d changed.\n", len(org.Added), len(org.Removed), len(org.Changed))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func idSuffix(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " (" + id + ")"
+}
+
+// displayValue formats a value on a single line for the text report.
+func displayValue(v string) string {
+	if v == "" {
+		return "(not set)"
+	}
+	return singleLine(v)
+}
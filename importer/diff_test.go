@@ -0,0 +1,250 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testExport() *export {
+	return &export{
+		Files: []exportFile{
+			{Filename: "syncs.tf", Content: []byte(`resource "polytomic_sync" "contacts" {
+  name          = "All Contacts"
+  active        = true
+  organization  = local.organization_id
+  connection_id = polytomic_postgresql_connection.pg.id
+  schedule      = { frequency = "daily" }
+  configuration = jsonencode({
+    "schema" = "public"
+  })
+}
+
+resource "polytomic_sync" "opportunities" {
+  name = "Opportunities"
+}
+`)},
+		},
+		Imports: []Import{
+			{Resource: SyncResource, Name: "contacts", ID: "sync-1"},
+			{Resource: SyncResource, Name: "opportunities", ID: "sync-3"},
+		},
+	}
+}
+
+func isSync(typ string) bool {
+	return typ == SyncResource
+}
+
+func TestDiffConfig(t *testing.T) {
+	live, err := liveResources(testExport())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "syncs.tf"), []byte(`resource "polytomic_sync" "contacts" {
+  name          = "Contacts"
+  active        = true
+  organization  = local.organization_id
+  connection_id = polytomic_postgresql_connection.pg.id
+  schedule = {
+    frequency = "daily"
+  }
+  configuration = jsonencode({
+    "schema" = "public"
+  })
+}
+
+resource "polytomic_sync" "accounts" {
+  name = "Accounts"
+}
+
+resource "polytomic_user" "admin" {
+  email = "admin@example.com"
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := readConfigDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	added, removed, changed := diffConfig(live, config, isSync)
+	if !reflect.DeepEqual([]DriftObject{{Address: "polytomic_sync.opportunities", ID: "sync-3"}}, added) {
+		t.Errorf("unexpected added: %+v", added)
+	}
+	if !reflect.DeepEqual([]DriftObject{{Address: "polytomic_sync.accounts"}}, removed) {
+		t.Errorf("unexpected removed: %+v", removed)
+	}
+	expectedChanged := []DriftObject{{
+		Address: "polytomic_sync.contacts",
+		ID:      "sync-1",
+		Attributes: []AttributeDiff{
+			{Name: "name", Terraform: `"Contacts"`, Polytomic: `"All Contacts"`},
+			{Name: "schedule", Terraform: "{\n  frequency = \"daily\"\n}", Polytomic: `{ frequency = "daily" }`},
+		},
+	}}
+	if !reflect.DeepEqual(expectedChanged, changed) {
+		t.Errorf("unexpected changed: %+v", changed)
+	}
+}
+
+func TestDiffConfigJSON(t *testing.T) {
+	live, err := liveResources(testExport())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "syncs.tf.json"), []byte(`{
+  "resource": {
+    "polytomic_sync": {
+      "contacts": {
+        "//": "Contacts synced from the warehouse.",
+        "name": "Contacts",
+        "active": true,
+        "organization": "${local.organization_id}",
+        "connection_id": "${polytomic_postgresql_connection.pg.id}",
+        "schedule": { "frequency": "daily" },
+        "configuration": "${jsonencode({ schema = \"public\" })}",
+        "lifecycle": { "prevent_destroy": true }
+      },
+      "accounts": {
+        "name": "Accounts"
+      }
+    }
+  }
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := readConfigDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	added, removed, changed := diffConfig(live, config, isSync)
+	if !reflect.DeepEqual([]DriftObject{{Address: "polytomic_sync.opportunities", ID: "sync-3"}}, added) {
+		t.Errorf("unexpected added: %+v", added)
+	}
+	if !reflect.DeepEqual([]DriftObject{{Address: "polytomic_sync.accounts"}}, removed) {
+		t.Errorf("unexpected removed: %+v", removed)
+	}
+	expectedChanged := []DriftObject{{
+		Address: "polytomic_sync.contacts",
+		ID:      "sync-1",
+		Attributes: []AttributeDiff{
+			{Name: "name", Terraform: `"Contacts"`, Polytomic: `"All Contacts"`},
+		},
+	}}
+	if !reflect.DeepEqual(expectedChanged, changed) {
+		t.Errorf("unexpected changed: %+v", changed)
+	}
+}
+
+func TestDiffState(t *testing.T) {
+	live, err := liveResources(testExport())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state tfState
+	err = json.Unmarshal([]byte(`{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "polytomic_postgresql_connection",
+      "name": "pg",
+      "instances": [{"attributes": {"id": "conn-1"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "polytomic_sync",
+      "name": "renamed",
+      "instances": [{
+        "attributes": {
+          "id": "sync-1",
+          "name": "Contacts",
+          "active": true,
+          "organization": "org-1",
+          "connection_id": "conn-1",
+          "schedule": {"frequency": "daily", "hour": null, "day_of_week": "monday"},
+          "configuration": "{\"schema\":\"public\"}"
+        }
+      }]
+    },
+    {
+      "mode": "managed",
+      "type": "polytomic_sync",
+      "name": "accounts",
+      "instances": [{"attributes": {"id": "sync-2", "name": "Accounts"}}]
+    }
+  ]
+}`), &state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added, removed, changed := diffState(live, &state, isSync)
+	if !reflect.DeepEqual([]DriftObject{{Address: "polytomic_sync.opportunities", ID: "sync-3"}}, added) {
+		t.Errorf("unexpected added: %+v", added)
+	}
+	if !reflect.DeepEqual([]DriftObject{{Address: "polytomic_sync.accounts", ID: "sync-2"}}, removed) {
+		t.Errorf("unexpected removed: %+v", removed)
+	}
+	// Matched by ID; only the name differs.
+	expectedChanged := []DriftObject{{
+		Address:    "polytomic_sync.renamed",
+		ID:         "sync-1",
+		Attributes: []AttributeDiff{{Name: "name", Terraform: `"Contacts"`, Polytomic: `"All Contacts"`}},
+	}}
+	if !reflect.DeepEqual(expectedChanged, changed) {
+		t.Errorf("unexpected changed: %+v", changed)
+	}
+}
+
+func TestWriteTextReport(t *testing.T) {
+	report := DriftReport{Organizations: []OrganizationDrift{
+		{ID: "org-1", Name: "Acme"},
+		{
+			ID:      "org-2",
+			Name:    "Globex",
+			Added:   []DriftObject{{Address: "polytomic_sync.opportunities", ID: "sync-3"}},
+			Removed: []DriftObject{{Address: "polytomic_sync.accounts"}},
+			Changed: []DriftObject{{
+				Address:    "polytomic_sync.contacts",
+				ID:         "sync-1",
+				Attributes: []AttributeDiff{{Name: "name", Terraform: `"Contacts"`, Polytomic: `"All Contacts"`}},
+			}},
+		},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeTextReport(&buf, report); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `Organization Acme (org-1)
+  No drift detected.
+
+Organization Globex (org-2)
+  + polytomic_sync.opportunities (sync-3): exists in Polytomic but not in Terraform
+  - polytomic_sync.accounts: exists in Terraform but not in Polytomic
+  ~ polytomic_sync.contacts (sync-1)
+      name: "Contacts" => "All Contacts"
+  1 added, 1 removed, 1 changed.
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+	if !report.HasDrift() {
+		t.Error("expected drift")
+	}
+}
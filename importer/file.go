@@ -1,14 +1,18 @@
 package importer
 
 import (
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/rs/zerolog/log"
 )
 
+const generatedHeader = "Code generated by github.com/polytomic/terraform-provider-polytomic/importer"
+
 func createFile(recreate bool, mode fs.FileMode, pathElems ...string) (*os.File, error) {
 	if recreate {
 		err := os.Remove(filepath.Join(pathElems...))
@@ -26,10 +30,61 @@ func createFile(recreate bool, mode fs.FileMode, pathElems ...string) (*os.File,
 	if err != nil {
 		return nil, err
 	}
-	f.Write([]byte("# Code generated by github.com/polytomic/terraform-provider-polytomic/importer\n"))
+	if !strings.HasSuffix(f.Name(), ".json") {
+		// JSON files carry the header as a "//" comment property instead.
+		f.Write([]byte("# " + generatedHeader + "\n"))
+	}
 	return f, nil
 }
 
+// writeFile writes generated content to a file in dir. Terraform (*.tf)
+// content is converted to JSON syntax, with a .json suffix appended to the
+// filename, when opts.Format is FormatJSON.
+func writeFile(opts Options, mode fs.FileMode, content []byte, dir, filename string) error {
+	if opts.Format == FormatJSON && strings.HasSuffix(filename, ".tf") {
+		var err error
+		content, err = hclToJSON(content, filename)
+		if err != nil {
+			return fmt.Errorf("converting %s to JSON: %w", filename, err)
+		}
+		filename += ".json"
+	}
+
+	f, err := createFile(opts.Replace, mode, dir, filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(content)
+	return err
+}
+
+// writeTerraformFile writes a generated Terraform file, merging it into the
+// existing file when opts.Update is set, and records a snapshot of the
+// generated content for later updates. The addresses of blocks added by an
+// update are recorded in added.
+func writeTerraformFile(opts Options, content []byte, dir, filename string, added map[string]bool) error {
+	if opts.Update {
+		addrs, err := updateFile(0644, content, dir, filename)
+		if err != nil {
+			return err
+		}
+		for _, addr := range addrs {
+			added[addr] = true
+		}
+	} else {
+		err := writeFile(opts, 0644, content, dir, filename)
+		if err != nil {
+			return err
+		}
+	}
+	if opts.Format != FormatHCL {
+		return nil
+	}
+	return writeSnapshot(dir, filename, content)
+}
+
 func createDirectory(path string) error {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		log.Info().Msgf("Creating directory %s", path)
@@ -67,16 +67,15 @@ func (g *GlobalErrorSubscribers) GenerateTerraformFiles(ctx context.Context, wri
 	return err
 }
 
-func (g *GlobalErrorSubscribers) GenerateImports(ctx context.Context, writer io.Writer) error {
+func (g *GlobalErrorSubscribers) Imports() []Import {
 	if len(g.emails) == 0 {
 		return nil
 	}
-	_, err := fmt.Fprintf(writer, "terraform import %s.%s %s\n",
-		GlobalErrorSubscribersResourceType,
-		"global",
-		globalErrorSubscribersImportID,
-	)
-	return err
+	return []Import{{
+		Resource: GlobalErrorSubscribersResourceType,
+		Name:     "global",
+		ID:       globalErrorSubscribersImportID,
+	}}
 }
 
 func (g *GlobalErrorSubscribers) Filename() string {
@@ -1,9 +1,12 @@
 package importer
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/AlekSi/pointer"
@@ -16,6 +19,23 @@ import (
 const (
 	UserAgent      = "polytomic-terraform-provider/importer"
 	ImportFileName = "import.sh"
+	// VariablesFileName is the file containing generated input variables.
+	VariablesFileName = "variables.tf"
+	// ImportBlocksFileName is the file containing Terraform import blocks,
+	// written when using ImportModeBlocks.
+	ImportBlocksFileName = "imports.tf"
+
+	// ImportModeScript writes a shell script running `terraform import` for
+	// each resource.
+	ImportModeScript = "script"
+	// ImportModeBlocks writes Terraform (1.5+) import blocks, which are
+	// applied by `terraform plan` and `terraform apply`.
+	ImportModeBlocks = "blocks"
+
+	// FormatHCL writes Terraform native syntax (*.tf).
+	FormatHCL = "hcl"
+	// FormatJSON writes Terraform JSON syntax (*.tf.json).
+	FormatJSON = "json"
 )
 
 type Importable interface {
@@ -23,44 +43,92 @@ type Importable interface {
 	ResourceRefs() map[string]string
 	DatasourceRefs() map[string]string
 	GenerateTerraformFiles(ctx context.Context, writer io.Writer, refs map[string]string) error
-	GenerateImports(ctx context.Context, writer io.Writer) error
+	Imports() []Import
 	Filename() string
 	Variables() []Variable
 }
 
-func Init(ctx context.Context, clientProvider *providerclient.Provider, organizations, outputPath string, recreate, includePermissions bool) {
-	err := createDirectory(outputPath)
-	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("failed to create directory")
-	}
+// Import identifies an existing Polytomic object and the Terraform resource it
+// should be imported into.
+type Import struct {
+	// Resource is the Terraform resource type, e.g. polytomic_sync.
+	Resource string
+	// Name is the Terraform resource name.
+	Name string
+	// ID is the import ID passed to the resource's ImportState.
+	ID string
+	// Comment is a human-readable description of the object, typically its
+	// name in Polytomic.
+	Comment string
+}
 
-	// // Handle organization discovery and filtering
-	orgFilter := make(map[string]bool)
-	for _, id := range strings.Split(organizations, ",") {
-		if strings.TrimSpace(id) != "" {
-			orgFilter[strings.TrimSpace(id)] = true
-		}
+// Address returns the Terraform address of the resource being imported.
+func (i Import) Address() string {
+	return i.Resource + "." + i.Name
+}
+
+// Options configure an import run.
+type Options struct {
+	// Organizations is a comma-separated list of organization IDs to import;
+	// if empty all accessible organizations are imported.
+	Organizations      string
+	OutputPath         string
+	Replace            bool
+	IncludePermissions bool
+	// ImportMode is one of ImportModeScript (the default) or ImportModeBlocks.
+	ImportMode string
+	// Format is one of FormatHCL (the default) or FormatJSON.
+	Format string
+	// NameTemplate is a text/template used to name generated resources; see
+	// NameData for the available fields. Defaults to DefaultNameTemplate.
+	NameTemplate string
+	// Update merges changes into previously exported files, preserving hand
+	// edits, instead of failing (or, with Replace, overwriting them).
+	Update bool
+}
+
+// Validate checks the options, filling in defaults for empty values.
+func (o *Options) Validate() error {
+	if o.ImportMode == "" {
+		o.ImportMode = ImportModeScript
+	}
+	if o.Format == "" {
+		o.Format = FormatHCL
+	}
+	if o.NameTemplate == "" {
+		o.NameTemplate = DefaultNameTemplate
+	}
+	if o.ImportMode != ImportModeScript && o.ImportMode != ImportModeBlocks {
+		return fmt.Errorf("invalid import mode %q: must be %q or %q", o.ImportMode, ImportModeScript, ImportModeBlocks)
+	}
+	if o.Format != FormatHCL && o.Format != FormatJSON {
+		return fmt.Errorf("invalid format %q: must be %q or %q", o.Format, FormatHCL, FormatJSON)
+	}
+	if o.Update && o.Replace {
+		return fmt.Errorf("update and replace are mutually exclusive")
 	}
+	if o.Update && o.Format != FormatHCL {
+		return fmt.Errorf("update is only supported with the %q format", FormatHCL)
+	}
+	_, err := NewNamer(o.NameTemplate)
+	return err
+}
 
-	// Discover all accessible organizations
-	orgs, err := clientProvider.ListOrganizations(ctx)
+func Init(ctx context.Context, clientProvider *providerclient.Provider, opts Options) {
+	err := opts.Validate()
 	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("failed to list organizations")
+		log.Fatal().AnErr("error", err).Msg("invalid options")
 	}
-	targetOrgs := make([]*polytomic.Organization, 0, len(orgs))
-
-	// Filter organizations if specified, otherwise use all discovered
-	// organizations
-	for _, org := range orgs {
-		if len(orgFilter) == 0 || orgFilter[pointer.Get(org.Id)] {
-			targetOrgs = append(targetOrgs, org)
-		}
+	err = createDirectory(opts.OutputPath)
+	if err != nil {
+		log.Fatal().AnErr("error", err).Msg("failed to create directory")
 	}
 
-	if len(targetOrgs) == 0 {
-		log.Fatal().Msg("no matching organizations found")
+	targetOrgs, err := targetOrganizations(ctx, clientProvider, opts.Organizations)
+	if err != nil {
+		log.Fatal().AnErr("error", err).Msg("failed to list organizations")
 	}
-	if len(orgFilter) == 0 {
+	if opts.Organizations == "" {
 		// Log discovered organizations for user awareness
 		log.Info().Msgf("Discovered %d organization(s) for export:", len(targetOrgs))
 		for _, org := range targetOrgs {
@@ -72,14 +140,14 @@ func Init(ctx context.Context, clientProvider *providerclient.Provider, organiza
 	if len(targetOrgs) > 1 {
 		// Multi-org mode: create separate directories
 		for _, org := range targetOrgs {
-			orgPath := filepath.Join(outputPath, pointer.Get(org.Name))
+			orgPath := filepath.Join(opts.OutputPath, pointer.Get(org.Name))
 
 			// Import resources for this organization
 			orgClient, err := clientProvider.Client(ctx, pointer.Get(org.Id))
 			if err != nil {
 				log.Fatal().AnErr("error", err).Msg("failed to create organization client")
 			}
-			importOrganization(ctx, org, orgClient, orgPath, recreate, includePermissions, true)
+			importOrganization(ctx, org, orgClient, orgPath, opts, true)
 		}
 	} else {
 		// Single organization - use it directly
@@ -87,12 +155,44 @@ func Init(ctx context.Context, clientProvider *providerclient.Provider, organiza
 		if err != nil {
 			log.Fatal().AnErr("error", err).Msg("failed to create organization client")
 		}
-		importOrganization(ctx, targetOrgs[0], orgClient, outputPath, recreate, includePermissions, false)
+		importOrganization(ctx, targetOrgs[0], orgClient, opts.OutputPath, opts, false)
+	}
+}
+
+// targetOrganizations returns the organizations matching organizations, a
+// comma-separated list of IDs, or all accessible organizations if it is
+// empty.
+func targetOrganizations(ctx context.Context, clientProvider *providerclient.Provider, organizations string) ([]*polytomic.Organization, error) {
+	orgFilter := make(map[string]bool)
+	for _, id := range strings.Split(organizations, ",") {
+		if strings.TrimSpace(id) != "" {
+			orgFilter[strings.TrimSpace(id)] = true
+		}
+	}
+
+	// Discover all accessible organizations
+	orgs, err := clientProvider.ListOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targetOrgs := make([]*polytomic.Organization, 0, len(orgs))
+
+	// Filter organizations if specified, otherwise use all discovered
+	// organizations
+	for _, org := range orgs {
+		if len(orgFilter) == 0 || orgFilter[pointer.Get(org.Id)] {
+			targetOrgs = append(targetOrgs, org)
+		}
+	}
+
+	if len(targetOrgs) == 0 {
+		return nil, fmt.Errorf("no matching organizations found")
 	}
+	return targetOrgs, nil
 }
 
 // importOrganization imports resources for a single organization
-func importOrganization(ctx context.Context, org *polytomic.Organization, c *ptclient.Client, path string, recreate, includePermissions, orgResource bool) {
+func importOrganization(ctx context.Context, org *polytomic.Organization, c *ptclient.Client, path string, opts Options, orgResource bool) {
 	log.Info().
 		Str("org_id", pointer.Get(org.Id)).
 		Str("org_name", pointer.Get(org.Name)).
@@ -104,27 +204,105 @@ func importOrganization(ctx context.Context, org *polytomic.Organization, c *ptc
 		return
 	}
 
-	importables := []Importable{
-		NewMain(org, orgResource),
-		NewGlobalErrorSubscribers(c),
-		NewConnections(c),
-		NewModels(c),
-		NewBulkSyncs(c),
-		NewSyncs(c),
+	var names map[string]map[string]string
+	if opts.Update {
+		names, err = readNamesSnapshot(path)
+		if err != nil {
+			log.Fatal().AnErr("error", err).Msg("failed to read the names of the last export")
+		}
+	}
+	exp, err := generate(ctx, org, c, opts, orgResource, names)
+	if err != nil {
+		log.Fatal().AnErr("error", err).
+			Str("organization_id", pointer.Get(org.Id)).
+			Msg("failed to generate terraform files")
+	}
+
+	// added holds the addresses of blocks new since the last export, when
+	// updating.
+	added := map[string]bool{}
+	for _, f := range exp.Files {
+		err = writeTerraformFile(opts, f.Content, path, f.Filename, added)
+		if err != nil {
+			log.Fatal().AnErr("error", err).Str("filename", f.Filename).Msg("failed to write file")
+		}
+	}
+	if opts.Format == FormatHCL {
+		err = writeNamesSnapshot(path, exp.Names)
+		if err != nil {
+			log.Fatal().AnErr("error", err).Msg("failed to write the names of the export")
+		}
 	}
 
-	if includePermissions {
-		importables = append(importables, NewRoles(c))
-		importables = append(importables, NewPolicies(c))
+	imports := exp.Imports
+	importOpts := opts
+	if opts.Update {
+		// Only resources new since the last export need to be imported; the
+		// import file is regenerated on each run.
+		imports = slices.DeleteFunc(imports, func(i Import) bool {
+			return !added[i.Address()]
+		})
+		importOpts.Replace = true
 	}
 
-	// Create import.sh
-	importFile, err := createFile(recreate, 0755, path, ImportFileName)
+	// Create the import script or import blocks
+	switch opts.ImportMode {
+	case ImportModeBlocks:
+		err = writeFile(importOpts, 0644, importBlocks(imports), path, ImportBlocksFileName)
+	default:
+		var buf bytes.Buffer
+		err = writeImportScript(&buf, imports)
+		if err == nil {
+			err = writeFile(importOpts, 0755, buf.Bytes(), path, ImportFileName)
+		}
+	}
 	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("failed to create import.sh")
+		log.Fatal().AnErr("error", err).Msg("failed to generate imports")
 	}
-	defer importFile.Close()
+}
+
+// export is the Terraform configuration generated for an organization.
+type export struct {
+	// Files holds the generated Terraform files, in the order they were
+	// generated.
+	Files   []exportFile
+	Imports []Import
+	// Names holds the resource names assigned, as returned by
+	// Namer.Assigned.
+	Names map[string]map[string]string
+}
+
+type exportFile struct {
+	Filename string
+	Content  []byte
+}
 
+// generate exports the resources of a single organization in memory. names
+// are the resource names assigned by a previous export, which are kept; it
+// may be nil.
+func generate(ctx context.Context, org *polytomic.Organization, c *ptclient.Client, opts Options, orgResource bool, names map[string]map[string]string) (*export, error) {
+	// Names are unique per organization.
+	namer, err := NewNamer(opts.NameTemplate)
+	if err != nil {
+		return nil, err
+	}
+	namer.Keep(names)
+
+	importables := []Importable{
+		NewMain(org, orgResource),
+		NewGlobalErrorSubscribers(c),
+		NewConnections(c, namer),
+		NewModels(c, namer),
+		NewBulkSyncs(c, namer),
+		NewSyncs(c, namer),
+	}
+
+	if opts.IncludePermissions {
+		importables = append(importables, NewRoles(c, namer))
+		importables = append(importables, NewPolicies(c, namer))
+	}
+
+	exp := &export{}
 	vars := []Variable{}
 	refs := make(map[string]string)
 
@@ -132,10 +310,7 @@ func importOrganization(ctx context.Context, org *polytomic.Organization, c *ptc
 		log.Info().Str("filename", i.Filename()).Msg("importing")
 		err := i.Init(ctx)
 		if err != nil {
-			log.Fatal().AnErr("error", err).
-				Str("organization_id", *org.Id).
-				Str("importable", i.Filename()).
-				Msg("failed to initialize")
+			return nil, fmt.Errorf("initializing %s: %w", i.Filename(), err)
 		}
 
 		// Add resource refs
@@ -149,33 +324,23 @@ func importOrganization(ctx context.Context, org *polytomic.Organization, c *ptc
 		// Add variables
 		vars = append(vars, i.Variables()...)
 
-		file := i.Filename()
-		f, err := createFile(recreate, 0644, path, file)
-		if err != nil {
-			log.Fatal().AnErr("error", err).Msg("failed to create file")
-		}
-		defer f.Close()
-
-		err = i.GenerateTerraformFiles(ctx, f, refs)
-		if err != nil {
-			log.Fatal().AnErr("error", err).Msg("failed to generate terraform files")
-		}
-		err = i.GenerateImports(ctx, importFile)
+		var buf bytes.Buffer
+		err = i.GenerateTerraformFiles(ctx, &buf, refs)
 		if err != nil {
-			log.Fatal().AnErr("error", err).Msg("failed to generate imports")
+			return nil, fmt.Errorf("generating %s: %w", i.Filename(), err)
 		}
+		exp.Files = append(exp.Files, exportFile{Filename: i.Filename(), Content: buf.Bytes()})
+		exp.Imports = append(exp.Imports, i.Imports()...)
 	}
 
 	// Create variables.tf
-	variablesFile, err := createFile(recreate, 0644, path, "variables.tf")
-	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("failed to create variables.tf")
-	}
-	defer variablesFile.Close()
-
-	err = generateVariables(variablesFile, vars)
+	var buf bytes.Buffer
+	err = generateVariables(&buf, vars)
 	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("failed to generate variables")
+		return nil, fmt.Errorf("generating variables: %w", err)
 	}
+	exp.Files = append(exp.Files, exportFile{Filename: VariablesFileName, Content: buf.Bytes()})
+	exp.Names = namer.Assigned()
 
+	return exp, nil
 }
@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// writeImportScript writes a `terraform import` command for each import. This
+// is the legacy import.sh format.
+func writeImportScript(writer io.Writer, imports []Import) error {
+	for _, i := range imports {
+		line := fmt.Sprintf("terraform import %s %s", i.Address(), i.ID)
+		if i.Comment != "" {
+			line += " # " + singleLine(i.Comment)
+		}
+		_, err := fmt.Fprintln(writer, line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importBlocks returns an HCL document with an import block for each import.
+//
+// The importer generates a separately named resource for each object, and an
+// import block's `to` address may only vary by instance key, so imports can't
+// be collapsed into for_each groups; one block is written per resource.
+func importBlocks(imports []Import) []byte {
+	hclFile := hclwrite.NewEmptyFile()
+	body := hclFile.Body()
+	for idx, i := range imports {
+		if idx > 0 {
+			body.AppendNewline()
+		}
+		if i.Comment != "" {
+			body.AppendUnstructuredTokens(hclwrite.Tokens{
+				{Type: hclsyntax.TokenComment, Bytes: []byte("# " + singleLine(i.Comment) + "\n")},
+			})
+		}
+		block := body.AppendNewBlock("import", nil)
+		block.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: i.Resource},
+			hcl.TraverseAttr{Name: i.Name},
+		})
+		block.Body().SetAttributeValue("id", cty.StringVal(i.ID))
+	}
+	return hclFile.Bytes()
+}
+
+// singleLine collapses s onto a single line for use in a comment.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
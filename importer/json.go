@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// templateEscaper escapes literal strings so that Terraform does not
+// interpret them as templates when reading JSON syntax.
+var templateEscaper = strings.NewReplacer("${", "$${", "%{", "%%{")
+
+// hclToJSON converts a generated HCL document to the equivalent Terraform
+// JSON configuration syntax.
+//
+// Literal values are written as JSON values. Any other expression (resource
+// references, function calls such as jsonencode) is written as a "${...}"
+// template wrapping the original expression, which Terraform evaluates to the
+// expression's value.
+func hclToJSON(src []byte, filename string) ([]byte, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	root := bodyToJSON(file.Body.(*hclsyntax.Body), src, "")
+	root["//"] = generatedHeader
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bodyToJSON converts the attributes and nested blocks of body. Labeled
+// blocks are nested by label; unlabeled blocks are written as an object when
+// there is a single block of the type and as an array otherwise.
+func bodyToJSON(body *hclsyntax.Body, src []byte, blockType string) map[string]any {
+	result := map[string]any{}
+	for name, attr := range body.Attributes {
+		if rawExpressionAttribute(blockType, name) {
+			result[name] = string(attr.Expr.Range().SliceBytes(src))
+			continue
+		}
+		result[name] = exprToJSON(attr.Expr, src)
+	}
+
+	unlabeled := map[string][]any{}
+	for _, block := range body.Blocks {
+		content := bodyToJSON(block.Body, src, block.Type)
+		if len(block.Labels) == 0 {
+			unlabeled[block.Type] = append(unlabeled[block.Type], content)
+			continue
+		}
+
+		parent, ok := result[block.Type].(map[string]any)
+		if !ok {
+			parent = map[string]any{}
+			result[block.Type] = parent
+		}
+		for _, label := range block.Labels[:len(block.Labels)-1] {
+			next, ok := parent[label].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				parent[label] = next
+			}
+			parent = next
+		}
+		parent[block.Labels[len(block.Labels)-1]] = content
+	}
+	for blockType, blocks := range unlabeled {
+		if len(blocks) == 1 {
+			result[blockType] = blocks[0]
+		} else {
+			result[blockType] = blocks
+		}
+	}
+
+	return result
+}
+
+// rawExpressionAttribute reports whether an attribute is written in JSON
+// syntax as a bare expression string rather than a value or template.
+func rawExpressionAttribute(blockType, name string) bool {
+	switch {
+	case blockType == "variable" && name == "type":
+		return true
+	case blockType == "import" && name == "to":
+		return true
+	}
+	return false
+}
+
+// exprToJSON converts expr to a JSON-encodable value.
+func exprToJSON(expr hclsyntax.Expression, src []byte) any {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		result := map[string]any{}
+		for _, item := range e.Items {
+			key, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || key.IsNull() || !key.IsKnown() || key.Type() != cty.String {
+				return interpolate(expr, src)
+			}
+			result[key.AsString()] = exprToJSON(item.ValueExpr, src)
+		}
+		return result
+	case *hclsyntax.TupleConsExpr:
+		result := make([]any, 0, len(e.Exprs))
+		for _, elem := range e.Exprs {
+			result = append(result, exprToJSON(elem, src))
+		}
+		return result
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() {
+		return interpolate(expr, src)
+	}
+	return ctyToJSON(value)
+}
+
+// interpolate wraps the source of expr in a template interpolation.
+func interpolate(expr hclsyntax.Expression, src []byte) string {
+	return "${" + string(expr.Range().SliceBytes(src)) + "}"
+}
+
+// ctyToJSON converts a known cty value to a JSON-encodable value.
+func ctyToJSON(value cty.Value) any {
+	if value.IsNull() {
+		return nil
+	}
+
+	ty := value.Type()
+	switch {
+	case ty == cty.String:
+		return templateEscaper.Replace(value.AsString())
+	case ty == cty.Number:
+		return json.Number(value.AsBigFloat().Text('f', -1))
+	case ty == cty.Bool:
+		return value.True()
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		result := make([]any, 0, value.LengthInt())
+		for it := value.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			result = append(result, ctyToJSON(elem))
+		}
+		return result
+	case ty.IsMapType() || ty.IsObjectType():
+		result := map[string]any{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			result[key.AsString()] = ctyToJSON(elem)
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHCLToJSON(t *testing.T) {
+	in := []byte(`terraform {
+  required_providers {
+    polytomic = {
+      source = "polytomic/polytomic"
+    }
+  }
+}
+
+resource "polytomic_sync" "contacts" {
+  name   = "Contacts ${literal}"
+  active = true
+  organization = local.organization_id
+  schedule = {
+    frequency = "daily"
+    hour      = 3
+  }
+  target = {
+    connection_id = polytomic_postgresql_connection.pg.id
+    configuration = jsonencode({
+      "schema" = "public"
+    })
+  }
+  fields = [{ target = "email" }]
+}
+
+resource "polytomic_sync" "accounts" {
+  name   = "Accounts"
+  active = false
+}
+
+variable "api_key" {
+  type      = string
+  sensitive = true
+}
+
+import {
+  to = polytomic_sync.contacts
+  id = "abc"
+}
+
+import {
+  to = polytomic_sync.accounts
+  id = "def"
+}
+`)
+	in = bytes.Replace(in, []byte("${literal}"), []byte("$${literal}"), 1)
+
+	out, err := hclToJSON(in, "test.tf")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("output is not valid JSON: %s\n%s", err, out)
+	}
+
+	expected := map[string]any{
+		"//": generatedHeader,
+		"terraform": map[string]any{
+			"required_providers": map[string]any{
+				"polytomic": map[string]any{"source": "polytomic/polytomic"},
+			},
+		},
+		"resource": map[string]any{
+			"polytomic_sync": map[string]any{
+				"contacts": map[string]any{
+					"name":         "Contacts $${literal}",
+					"active":       true,
+					"organization": "${local.organization_id}",
+					"schedule": map[string]any{
+						"frequency": "daily",
+						"hour":      float64(3),
+					},
+					"target": map[string]any{
+						"connection_id": "${polytomic_postgresql_connection.pg.id}",
+						"configuration": "${jsonencode({\n      \"schema\" = \"public\"\n    })}",
+					},
+					"fields": []any{
+						map[string]any{"target": "email"},
+					},
+				},
+				"accounts": map[string]any{
+					"name":   "Accounts",
+					"active": false,
+				},
+			},
+		},
+		"variable": map[string]any{
+			"api_key": map[string]any{
+				"type":      "string",
+				"sensitive": true,
+			},
+		},
+		"import": []any{
+			map[string]any{"to": "polytomic_sync.contacts", "id": "abc"},
+			map[string]any{"to": "polytomic_sync.accounts", "id": "def"},
+		},
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("unexpected JSON:\n%s", out)
+	}
+}
+
+func TestImportOutputs(t *testing.T) {
+	imports := []Import{
+		{Resource: SyncResource, Name: "contacts", ID: "abc", Comment: "Salesforce\nContacts"},
+		{Resource: GlobalErrorSubscribersResourceType, Name: "global", ID: globalErrorSubscribersImportID},
+	}
+
+	var script bytes.Buffer
+	if err := writeImportScript(&script, imports); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedScript := "terraform import polytomic_sync.contacts abc # Salesforce Contacts\n" +
+		"terraform import polytomic_global_error_subscribers.global global-error-subscribers\n"
+	if script.String() != expectedScript {
+		t.Errorf("unexpected import script:\n%s", script.String())
+	}
+
+	blocks := string(importBlocks(imports))
+	expectedBlocks := `# Salesforce Contacts
+import {
+  to = polytomic_sync.contacts
+  id = "abc"
+}
+
+import {
+  to = polytomic_global_error_subscribers.global
+  id = "global-error-subscribers"
+}
+`
+	if blocks != expectedBlocks {
+		t.Errorf("unexpected import blocks:\n%s", blocks)
+	}
+	if !strings.Contains(blocks, "to = polytomic_sync.contacts\n") {
+		t.Errorf("to should be a bare address: %s", blocks)
+	}
+}
@@ -91,12 +91,16 @@ func (m *Main) GenerateTerraformFiles(ctx context.Context, writer io.Writer, ref
 	return err
 }
 
-func (m *Main) GenerateImports(ctx context.Context, writer io.Writer) error {
+func (m *Main) Imports() []Import {
 	if m.ID == "" {
 		return nil
 	}
-	_, err := fmt.Fprintf(writer, "terraform import polytomic_organization.%s %s # %s\n", m.Slug, m.ID, m.Name)
-	return err
+	return []Import{{
+		Resource: "polytomic_organization",
+		Name:     m.Slug,
+		ID:       m.ID,
+		Comment:  m.Name,
+	}}
 }
 
 func (m *Main) Filename() string {
@@ -23,20 +23,20 @@ var (
 )
 
 type Models struct {
-	c *ptclient.Client
+	c     *ptclient.Client
+	namer *Namer
 	// modelNames is a map of model id's to their disambiguated names
-	modelNames  map[string]string
-	uniqueNames map[string]bool
+	modelNames map[string]string
 
 	Resources map[string]*polytomic.ModelResponse
 }
 
-func NewModels(c *ptclient.Client) *Models {
+func NewModels(c *ptclient.Client, namer *Namer) *Models {
 	return &Models{
-		c:           c,
-		modelNames:  map[string]string{},
-		uniqueNames: map[string]bool{},
-		Resources:   make(map[string]*polytomic.ModelResponse),
+		c:          c,
+		namer:      namer,
+		modelNames: map[string]string{},
+		Resources:  make(map[string]*polytomic.ModelResponse),
 	}
 }
 
@@ -46,19 +46,18 @@ func (m *Models) Init(ctx context.Context) error {
 		return err
 	}
 
+	sortByID(models.Data, func(i int) *string { return models.Data[i].Id })
 	for _, model := range models.Data {
 		hydratedModel, err := m.c.Models.Get(ctx, pointer.GetString(model.Id), &polytomic.ModelsGetRequest{})
 		if err != nil {
 			return err
 		}
 
-		name := provider.ValidName(
-			provider.ToSnakeCase(pointer.GetString(model.Name)),
-		)
-		if _, exists := m.uniqueNames[name]; exists {
-			name = fmt.Sprintf("%s_%s", name, pointer.GetString(model.Type))
-		}
-		m.uniqueNames[name] = true
+		name := m.namer.Name(ModelResource, NameData{
+			Type: ModelResource,
+			Name: provider.ToSnakeCase(pointer.GetString(model.Name)),
+			ID:   pointer.GetString(model.Id),
+		})
 		m.modelNames[pointer.GetString(model.Id)] = name
 		m.Resources[name] = hydratedModel.Data
 	}
@@ -125,16 +124,18 @@ func (m *Models) GenerateTerraformFiles(ctx context.Context, writer io.Writer, r
 	return nil
 }
 
-func (m *Models) GenerateImports(ctx context.Context, writer io.Writer) error {
+func (m *Models) Imports() []Import {
+	imports := make([]Import, 0, len(m.Resources))
 	for _, name := range sortedKeys(m.Resources) {
 		model := m.Resources[name]
-		writer.Write([]byte(fmt.Sprintf("terraform import %s.%s %s",
-			ModelResource,
-			m.modelNames[pointer.GetString(model.Id)],
-			pointer.GetString(model.Id))))
-		writer.Write([]byte(fmt.Sprintf(" # %s\n", pointer.GetString(model.Name))))
+		imports = append(imports, Import{
+			Resource: ModelResource,
+			Name:     m.modelNames[pointer.GetString(model.Id)],
+			ID:       pointer.GetString(model.Id),
+			Comment:  pointer.GetString(model.Name),
+		})
 	}
-	return nil
+	return imports
 }
 
 func (m *Models) Filename() string {
@@ -0,0 +1,167 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/AlekSi/pointer"
+	"github.com/polytomic/terraform-provider-polytomic/provider"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultNameTemplate names resources after the object's name.
+const DefaultNameTemplate = "{{ .Name }}"
+
+// NameData is the data available to resource name templates.
+type NameData struct {
+	// Type is the Terraform resource type without the provider prefix, e.g.
+	// sync or postgresql_connection.
+	Type string
+	// Name is the object's name in snake_case.
+	Name string
+	// ID is the object's ID.
+	ID string
+}
+
+// Namer assigns Terraform resource names to exported objects.
+//
+// Names are rendered from a template and must be unique within a scope
+// (typically the resource type). When two objects would receive the same
+// name, the one named second is suffixed with a short hash of its ID, and a
+// warning is logged. The suffix depends only on the object's ID, so it
+// doesn't change when other colliding objects are added or removed.
+// Importables name objects in ID order so that the resulting addresses are
+// the same on every export. When updating a previous export, the names it
+// assigned are kept (see Keep), so an object which now sorts first doesn't
+// take the name of one exported before it.
+type Namer struct {
+	tmpl *template.Template
+
+	// assigned maps a scope to the names assigned within it, and the ID of
+	// the object each name was assigned to.
+	assigned map[string]map[string]string
+	// previous holds the names assigned by a previous export, in the same
+	// form as assigned.
+	previous map[string]map[string]string
+}
+
+func NewNamer(nameTemplate string) (*Namer, error) {
+	if nameTemplate == "" {
+		nameTemplate = DefaultNameTemplate
+	}
+	tmpl, err := template.New("name").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+	// Catch references to unknown fields before anything is exported.
+	err = tmpl.Execute(io.Discard, NameData{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+
+	return &Namer{
+		tmpl:     tmpl,
+		assigned: map[string]map[string]string{},
+	}, nil
+}
+
+// Keep reserves the names assigned by a previous export, as returned by
+// Assigned, for the objects they were assigned to. Other objects rendering
+// the same name are suffixed, even if they're named first.
+func (n *Namer) Keep(previous map[string]map[string]string) {
+	n.previous = previous
+}
+
+// Assigned returns the names assigned within each scope, and the ID of the
+// object each name was assigned to.
+func (n *Namer) Assigned() map[string]map[string]string {
+	return n.assigned
+}
+
+// Name returns a valid, unique resource name within scope for the object
+// described by data. Naming the same object twice returns the same name.
+func (n *Namer) Name(scope string, data NameData) string {
+	data.Type = strings.TrimPrefix(data.Type, provider.Name+"_")
+
+	var buf strings.Builder
+	err := n.tmpl.Execute(&buf, data)
+	if err != nil {
+		// The template was validated by NewNamer; fall back to the name.
+		buf.Reset()
+		buf.WriteString(data.Name)
+	}
+	name := provider.ValidName(buf.String())
+
+	assigned, ok := n.assigned[scope]
+	if !ok {
+		assigned = map[string]string{}
+		n.assigned[scope] = assigned
+	}
+	// Objects without an ID can't be told apart, so each gets its own name.
+	if data.ID != "" {
+		for existing, id := range assigned {
+			if id == data.ID {
+				return existing
+			}
+		}
+	}
+
+	taken := func(name string) (string, bool) {
+		if id, ok := assigned[name]; ok {
+			return id, true
+		}
+		id, ok := n.previous[scope][name]
+		return id, ok && id != data.ID
+	}
+	owner, collides := taken(name)
+	if !collides {
+		assigned[name] = data.ID
+		return name
+	}
+
+	unique := uniqueName(name, data.ID, func(name string) bool {
+		_, ok := taken(name)
+		return ok
+	})
+	log.Warn().
+		Str("scope", scope).
+		Str("name", name).
+		Str("id", data.ID).
+		Str("conflicts_with", owner).
+		Str("renamed_to", unique).
+		Msg("resource name collision")
+	assigned[unique] = data.ID
+	return unique
+}
+
+// uniqueName suffixes name with a short hash of id, falling back to a
+// counter if that name is already taken or id is empty.
+func uniqueName(name, id string, taken func(string) bool) string {
+	if id != "" {
+		sum := sha256.Sum256([]byte(id))
+		candidate := fmt.Sprintf("%s_%s", name, hex.EncodeToString(sum[:])[:8])
+		if !taken(candidate) {
+			return candidate
+		}
+		name = candidate
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s_%d", name, i)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// sortByID sorts a slice of API objects by ID, so that they are named in a
+// stable order. id returns the ID of the object at index i.
+func sortByID(objects any, id func(i int) *string) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		return pointer.Get(id(i)) < pointer.Get(id(j))
+	})
+}
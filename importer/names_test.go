@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"testing"
+)
+
+func TestNamer(t *testing.T) {
+	n, err := NewNamer("")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		scope    string
+		data     NameData
+		expected string
+	}{
+		{SyncResource, NameData{Type: SyncResource, Name: "contacts", ID: "0b6e6d1e-58a4-4f4b-9d4c-8a1e0e8c2f11"}, "contacts"},
+		// Same name, different object: suffixed with a hash of the ID.
+		{SyncResource, NameData{Type: SyncResource, Name: "contacts", ID: "5c1f0f0a-7d1e-4b8e-9c6a-3e2d1c0b9a88"}, "contacts_1f63ad5a"},
+		{SyncResource, NameData{Type: SyncResource, Name: "contacts", ID: "6d2a1b2c-8e2f-4c9f-8d7b-4f3e2d1c0ba9"}, "contacts_a3ee8842"},
+		// Naming an object again returns its existing name.
+		{SyncResource, NameData{Type: SyncResource, Name: "contacts", ID: "0b6e6d1e-58a4-4f4b-9d4c-8a1e0e8c2f11"}, "contacts"},
+		// Names are unique per scope.
+		{ModelResource, NameData{Type: ModelResource, Name: "contacts", ID: "1d4c9f0e-2b7a-4c3d-8e6f-5a4b3c2d1e0f"}, "contacts"},
+		// Names are made valid identifiers.
+		{ModelResource, NameData{Type: ModelResource, Name: "1st", ID: "2e5d0a1f-3c8b-4d4e-9f70-6b5c4d3e2f10"}, "_1st"},
+		// A counter is added if the hashed name is taken.
+		{ModelResource, NameData{Type: ModelResource, Name: "contacts_1f63ad5a", ID: "3f6e1b20-4d9c-4e5f-a081-7c6d5e4f3021"}, "contacts_1f63ad5a"},
+		{ModelResource, NameData{Type: ModelResource, Name: "contacts", ID: "5c1f0f0a-7d1e-4b8e-9c6a-3e2d1c0b9a88"}, "contacts_1f63ad5a_2"},
+		// Objects without an ID are named separately.
+		{PolicyResource, NameData{Type: PolicyResource, Name: "admins"}, "admins"},
+		{PolicyResource, NameData{Type: PolicyResource, Name: "admins"}, "admins_2"},
+		{PolicyResource, NameData{Type: PolicyResource, Name: "admins"}, "admins_3"},
+	}
+	for _, tc := range tests {
+		got := n.Name(tc.scope, tc.data)
+		if got != tc.expected {
+			t.Errorf("Name(%q, %+v) = %q, expected %q", tc.scope, tc.data, got, tc.expected)
+		}
+	}
+}
+
+func TestNamerStableSuffix(t *testing.T) {
+	// An object's suffix doesn't depend on which other objects collide.
+	for _, ids := range [][]string{
+		{"0b6e6d1e-58a4-4f4b-9d4c-8a1e0e8c2f11", "6d2a1b2c-8e2f-4c9f-8d7b-4f3e2d1c0ba9"},
+		{"0b6e6d1e-58a4-4f4b-9d4c-8a1e0e8c2f11", "5c1f0f0a-7d1e-4b8e-9c6a-3e2d1c0b9a88", "6d2a1b2c-8e2f-4c9f-8d7b-4f3e2d1c0ba9"},
+	} {
+		n, err := NewNamer("")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var got string
+		for _, id := range ids {
+			got = n.Name(SyncResource, NameData{Type: SyncResource, Name: "contacts", ID: id})
+		}
+		if got != "contacts_a3ee8842" {
+			t.Errorf("unexpected name %q for IDs %v", got, ids)
+		}
+	}
+}
+
+func TestNamerKeep(t *testing.T) {
+	const (
+		first = "6d2a1b2c-8e2f-4c9f-8d7b-4f3e2d1c0ba9"
+		lower = "0b6e6d1e-58a4-4f4b-9d4c-8a1e0e8c2f11"
+	)
+	export := func(previous map[string]map[string]string, ids ...string) (*Namer, []string) {
+		n, err := NewNamer("")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		n.Keep(previous)
+		var names []string
+		for _, id := range ids {
+			names = append(names, n.Name(SyncResource, NameData{Type: SyncResource, Name: "contacts", ID: id}))
+		}
+		return n, names
+	}
+
+	n, names := export(nil, first)
+	if names[0] != "contacts" {
+		t.Fatalf("unexpected name %q", names[0])
+	}
+	// An object with a lower ID and the same name appears in the next
+	// export, and is named first.
+	_, names = export(n.Assigned(), lower, first)
+	if names[0] != "contacts_11c8087f" {
+		t.Errorf("unexpected name %q for the new object", names[0])
+	}
+	if names[1] != "contacts" {
+		t.Errorf("the existing object was renamed to %q", names[1])
+	}
+}
+
+func TestNamerTemplate(t *testing.T) {
+	n, err := NewNamer("{{.Type}}_{{.Name}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := n.Name("connections", NameData{Type: "polytomic_postgresql_connection", Name: "warehouse", ID: "abc"})
+	if got != "postgresql_connection_warehouse" {
+		t.Errorf("unexpected name %q", got)
+	}
+
+	for _, tmpl := range []string{"{{.Name", "{{.Label}}"} {
+		_, err := NewNamer(tmpl)
+		if err == nil {
+			t.Errorf("expected error for template %q", tmpl)
+		}
+	}
+}
+
+func TestSortByID(t *testing.T) {
+	ids := []string{"c", "a", "b"}
+	objects := []*string{&ids[0], &ids[1], &ids[2]}
+	sortByID(objects, func(i int) *string { return objects[i] })
+
+	var got []string
+	for _, o := range objects {
+		got = append(got, *o)
+	}
+	if got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("unexpected order %v", got)
+	}
+}
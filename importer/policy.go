@@ -2,7 +2,6 @@ package importer
 
 import (
 	"context"
-	"fmt"
 	"io"
 
 	"github.com/AlekSi/pointer"
@@ -24,14 +23,16 @@ var (
 )
 
 type Policies struct {
-	c *ptclient.Client
+	c     *ptclient.Client
+	namer *Namer
 
 	Resources map[string]*polytomic.PolicyResponse
 }
 
-func NewPolicies(c *ptclient.Client) *Policies {
+func NewPolicies(c *ptclient.Client, namer *Namer) *Policies {
 	return &Policies{
 		c:         c,
+		namer:     namer,
 		Resources: make(map[string]*polytomic.PolicyResponse),
 	}
 }
@@ -42,6 +43,7 @@ func (p *Policies) Init(ctx context.Context) error {
 		return err
 	}
 
+	sortByID(policies.Data, func(i int) *string { return policies.Data[i].Id })
 	for _, policy := range policies.Data {
 		// Skip system policies, they are not editable
 		if pointer.GetBool(policy.System) {
@@ -51,7 +53,11 @@ func (p *Policies) Init(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		name := provider.ValidName(provider.ToSnakeCase(pointer.GetString(policy.Name)))
+		name := p.namer.Name(PolicyResource, NameData{
+			Type: PolicyResource,
+			Name: provider.ToSnakeCase(pointer.GetString(policy.Name)),
+			ID:   pointer.GetString(policy.Id),
+		})
 		p.Resources[name] = hyrdatedPolicy.Data
 	}
 
@@ -95,16 +101,18 @@ func (p *Policies) GenerateTerraformFiles(ctx context.Context, writer io.Writer,
 	return nil
 }
 
-func (p *Policies) GenerateImports(ctx context.Context, writer io.Writer) error {
+func (p *Policies) Imports() []Import {
+	imports := make([]Import, 0, len(p.Resources))
 	for _, name := range sortedKeys(p.Resources) {
 		policy := p.Resources[name]
-		writer.Write([]byte(fmt.Sprintf("terraform import %s.%s %s",
-			PolicyResource,
-			name,
-			pointer.GetString(policy.Id))))
-		writer.Write([]byte(fmt.Sprintf(" # %s\n", pointer.GetString(policy.Name))))
+		imports = append(imports, Import{
+			Resource: PolicyResource,
+			Name:     name,
+			ID:       pointer.GetString(policy.Id),
+			Comment:  pointer.GetString(policy.Name),
+		})
 	}
-	return nil
+	return imports
 }
 
 func (p *Policies) Filename() string {
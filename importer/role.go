@@ -24,14 +24,16 @@ var (
 )
 
 type Roles struct {
-	c *ptclient.Client
+	c     *ptclient.Client
+	namer *Namer
 
 	Resources map[string]*polytomic.RoleResponse
 }
 
-func NewRoles(c *ptclient.Client) *Roles {
+func NewRoles(c *ptclient.Client, namer *Namer) *Roles {
 	return &Roles{
 		c:         c,
+		namer:     namer,
 		Resources: make(map[string]*polytomic.RoleResponse),
 	}
 }
@@ -42,12 +44,17 @@ func (r *Roles) Init(ctx context.Context) error {
 		return err
 	}
 
+	sortByID(roles.Data, func(i int) *string { return roles.Data[i].Id })
 	for _, role := range roles.Data {
 		// Skip system roles, they are not editable
 		if pointer.GetBool(role.System) {
 			continue
 		}
-		name := provider.ValidName(provider.ToSnakeCase(pointer.GetString(role.Name)))
+		name := r.namer.Name(RoleResource, NameData{
+			Type: RoleResource,
+			Name: provider.ToSnakeCase(pointer.GetString(role.Name)),
+			ID:   pointer.GetString(role.Id),
+		})
 		r.Resources[name] = role
 	}
 
@@ -80,16 +87,18 @@ func (r *Roles) GenerateTerraformFiles(ctx context.Context, writer io.Writer, re
 	return nil
 }
 
-func (r *Roles) GenerateImports(ctx context.Context, writer io.Writer) error {
+func (r *Roles) Imports() []Import {
+	imports := make([]Import, 0, len(r.Resources))
 	for _, name := range sortedKeys(r.Resources) {
 		role := r.Resources[name]
-		writer.Write([]byte(fmt.Sprintf("terraform import %s.%s %s",
-			RoleResource,
-			name,
-			pointer.GetString(role.Id))))
-		writer.Write([]byte(fmt.Sprintf(" # %s\n", pointer.GetString(role.Name))))
+		imports = append(imports, Import{
+			Resource: RoleResource,
+			Name:     name,
+			ID:       pointer.GetString(role.Id),
+			Comment:  pointer.GetString(role.Name),
+		})
 	}
-	return nil
+	return imports
 }
 
 func (r *Roles) Filename() string {
@@ -24,14 +24,16 @@ var (
 )
 
 type Syncs struct {
-	c *ptclient.Client
+	c     *ptclient.Client
+	namer *Namer
 
 	Resources map[string]*polytomic.ModelSyncResponse
 }
 
-func NewSyncs(c *ptclient.Client) *Syncs {
+func NewSyncs(c *ptclient.Client, namer *Namer) *Syncs {
 	return &Syncs{
 		c:         c,
+		namer:     namer,
 		Resources: make(map[string]*polytomic.ModelSyncResponse),
 	}
 }
@@ -42,8 +44,13 @@ func (s *Syncs) Init(ctx context.Context) error {
 		return err
 	}
 
+	sortByID(syncs.Data, func(i int) *string { return syncs.Data[i].Id })
 	for _, sync := range syncs.Data {
-		name := provider.ValidName(provider.ToSnakeCase(pointer.GetString(sync.Name)))
+		name := s.namer.Name(SyncResource, NameData{
+			Type: SyncResource,
+			Name: provider.ToSnakeCase(pointer.GetString(sync.Name)),
+			ID:   pointer.GetString(sync.Id),
+		})
 		s.Resources[name] = sync
 	}
 
@@ -249,16 +256,18 @@ func (s *Syncs) GenerateTerraformFiles(ctx context.Context, writer io.Writer, re
 	return nil
 }
 
-func (s *Syncs) GenerateImports(ctx context.Context, writer io.Writer) error {
+func (s *Syncs) Imports() []Import {
+	imports := make([]Import, 0, len(s.Resources))
 	for _, name := range sortedKeys(s.Resources) {
 		sync := s.Resources[name]
-		writer.Write([]byte(fmt.Sprintf("terraform import %s.%s %s",
-			SyncResource,
-			name,
-			pointer.GetString(sync.Id))))
-		writer.Write([]byte(fmt.Sprintf(" # %s\n", pointer.GetString(sync.Name))))
+		imports = append(imports, Import{
+			Resource: SyncResource,
+			Name:     name,
+			ID:       pointer.GetString(sync.Id),
+			Comment:  pointer.GetString(sync.Name),
+		})
 	}
-	return nil
+	return imports
 }
 
 func (s *Syncs) Filename() string {
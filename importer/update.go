@@ -0,0 +1,323 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rs/zerolog/log"
+)
+
+// SnapshotDirName is the directory, relative to the output path, holding a
+// copy of each file as last generated. It is the base against which --update
+// detects remote changes, and should be kept alongside the exported files.
+const SnapshotDirName = ".polytomic-importer"
+
+// NamesSnapshotFileName is the file in SnapshotDirName recording the
+// resource names assigned by the last export, so that --update keeps them.
+const NamesSnapshotFileName = "names.json"
+
+// mergeResult describes the changes made to an existing file by mergeHCL.
+// Each entry is the address of a top-level block, e.g. polytomic_sync.contacts.
+type mergeResult struct {
+	// Added blocks are new since the last export and were appended.
+	Added []string
+	// Updated blocks had at least one attribute changed remotely.
+	Updated []string
+	// Deleted blocks no longer exist remotely and were commented out.
+	Deleted []string
+}
+
+// writeSnapshot records content as the last generated version of filename.
+func writeSnapshot(dir, filename string, content []byte) error {
+	snapshotDir := filepath.Join(dir, SnapshotDirName)
+	err := os.MkdirAll(snapshotDir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(snapshotDir, filename), content, 0644)
+}
+
+// readNamesSnapshot returns the resource names assigned by the last export
+// to dir, in the form returned by Namer.Assigned. It returns nil if there is
+// no snapshot.
+func readNamesSnapshot(dir string) (map[string]map[string]string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, SnapshotDirName, NamesSnapshotFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names map[string]map[string]string
+	err = json.Unmarshal(content, &names)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", NamesSnapshotFileName, err)
+	}
+	return names, nil
+}
+
+// writeNamesSnapshot records the resource names assigned by an export to dir.
+func writeNamesSnapshot(dir string, names map[string]map[string]string) error {
+	content, err := json.MarshalIndent(names, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeSnapshot(dir, NamesSnapshotFileName, append(content, '\n'))
+}
+
+// updateFile merges generated content into an existing Terraform file,
+// preserving hand edits, and returns the addresses of the blocks which were
+// added. If the file does not exist it is created.
+func updateFile(mode fs.FileMode, content []byte, dir, filename string) ([]string, error) {
+	existing, err := os.ReadFile(filepath.Join(dir, filename))
+	if errors.Is(err, fs.ErrNotExist) {
+		f, err := createFile(false, mode, dir, filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		_, err = f.Write(content)
+		if err != nil {
+			return nil, err
+		}
+		return blockAddresses(content, filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a snapshot of the last export, the existing file is the best
+	// available base: any difference from it is treated as a remote change.
+	base, err := os.ReadFile(filepath.Join(dir, SnapshotDirName, filename))
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Warn().Str("filename", filename).
+			Msg("no snapshot of the last export found; attributes which differ from the existing file will be overwritten")
+		base = existing
+	} else if err != nil {
+		return nil, err
+	}
+
+	merged, result, err := mergeHCL(existing, base, content, filename)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range result.Added {
+		log.Info().Str("filename", filename).Str("address", addr).Msg("added")
+	}
+	for _, addr := range result.Updated {
+		log.Info().Str("filename", filename).Str("address", addr).Msg("updated")
+	}
+	for _, addr := range result.Deleted {
+		log.Warn().Str("filename", filename).Str("address", addr).
+			Msg("no longer exists in Polytomic; commented out")
+	}
+	if bytes.Equal(merged, existing) {
+		return result.Added, nil
+	}
+	return result.Added, os.WriteFile(filepath.Join(dir, filename), merged, mode)
+}
+
+// mergeHCL applies the changes between base (the last generated version of a
+// file) and generated (the current version) to existing, which may have been
+// edited by hand.
+//
+//   - Blocks which are new since base are appended.
+//   - Attributes whose generated value changed since base are replaced;
+//     attributes which are unchanged remotely are left as they are, so hand
+//     edits survive.
+//   - Blocks which are no longer generated are commented out in place.
+//   - Blocks which are still generated but were removed from existing (for
+//     example, when refactored into a for_each) are not re-added.
+//
+// Blocks which are not changed are left byte-for-byte identical.
+func mergeHCL(existing, base, generated []byte, filename string) ([]byte, mergeResult, error) {
+	var result mergeResult
+
+	existingFile, diags := hclwrite.ParseConfig(existing, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, result, fmt.Errorf("parsing %s: %w", filename, diags)
+	}
+	baseFile, diags := hclwrite.ParseConfig(base, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, result, fmt.Errorf("parsing snapshot of %s: %w", filename, diags)
+	}
+	generatedFile, diags := hclwrite.ParseConfig(generated, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, result, fmt.Errorf("parsing generated %s: %w", filename, diags)
+	}
+
+	existingBlocks := indexBlocks(existingFile.Body())
+	baseBlocks := indexBlocks(baseFile.Body())
+	generatedBlocks := indexBlocks(generatedFile.Body())
+
+	body := existingFile.Body()
+	for _, g := range generatedFile.Body().Blocks() {
+		addr := blockAddress(g)
+		e, inExisting := existingBlocks[addr]
+		b, inBase := baseBlocks[addr]
+		switch {
+		case inExisting:
+			var baseBody *hclwrite.Body
+			if inBase {
+				baseBody = b.Body()
+			}
+			if mergeBody(e.Body(), baseBody, g.Body()) {
+				result.Updated = append(result.Updated, addr)
+			}
+		case !inBase:
+			body.AppendNewline()
+			body.AppendUnstructuredTokens(g.BuildTokens(nil))
+			result.Added = append(result.Added, addr)
+		}
+	}
+
+	// Deleted blocks are commented out once the rest of the file has been
+	// rendered, as hclwrite can't insert tokens in place.
+	var deleted [][]byte
+	for _, e := range existingFile.Body().Blocks() {
+		addr := blockAddress(e)
+		if _, ok := generatedBlocks[addr]; ok {
+			continue
+		}
+		if _, ok := baseBlocks[addr]; !ok {
+			// Written by hand; not ours to remove.
+			continue
+		}
+		deleted = append(deleted, e.BuildTokens(nil).Bytes())
+		result.Deleted = append(result.Deleted, addr)
+	}
+
+	out := existingFile.Bytes()
+	for _, block := range deleted {
+		out = bytes.Replace(out, block, commentOut(block), 1)
+	}
+	return out, result, nil
+}
+
+// mergeBody updates the attributes and nested blocks of existing which
+// changed between base and generated, and reports whether anything changed.
+// base may be nil, in which case every difference is applied.
+func mergeBody(existing, base, generated *hclwrite.Body) bool {
+	changed := false
+	for name, g := range generated.Attributes() {
+		value := exprBytes(g)
+		if base != nil {
+			if b := base.GetAttribute(name); b != nil && bytes.Equal(exprBytes(b), value) {
+				// Unchanged remotely.
+				continue
+			}
+		}
+		if e := existing.GetAttribute(name); e != nil && bytes.Equal(exprBytes(e), value) {
+			continue
+		}
+		existing.SetAttributeRaw(name, g.Expr().BuildTokens(nil))
+		changed = true
+	}
+	if base != nil {
+		for name, b := range base.Attributes() {
+			if generated.GetAttribute(name) != nil {
+				continue
+			}
+			// Only remove values which were not edited by hand.
+			if e := existing.GetAttribute(name); e != nil && bytes.Equal(exprBytes(e), exprBytes(b)) {
+				existing.RemoveAttribute(name)
+				changed = true
+			}
+		}
+	}
+
+	existingBlocks := indexBlocks(existing)
+	baseBlocks := map[string]*hclwrite.Block{}
+	if base != nil {
+		baseBlocks = indexBlocks(base)
+	}
+	generatedBlocks := indexBlocks(generated)
+	for _, g := range generated.Blocks() {
+		addr := blockAddress(g)
+		e, inExisting := existingBlocks[addr]
+		b, inBase := baseBlocks[addr]
+		switch {
+		case inExisting:
+			var baseBody *hclwrite.Body
+			if inBase {
+				baseBody = b.Body()
+			}
+			changed = mergeBody(e.Body(), baseBody, g.Body()) || changed
+		case !inBase:
+			existing.AppendBlock(g)
+			changed = true
+		}
+	}
+	for addr, e := range existingBlocks {
+		_, inBase := baseBlocks[addr]
+		if _, ok := generatedBlocks[addr]; !ok && inBase {
+			existing.RemoveBlock(e)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// indexBlocks returns the blocks in body by address. Where several blocks
+// share an address, the first is used.
+func indexBlocks(body *hclwrite.Body) map[string]*hclwrite.Block {
+	blocks := map[string]*hclwrite.Block{}
+	for _, block := range body.Blocks() {
+		addr := blockAddress(block)
+		if _, ok := blocks[addr]; !ok {
+			blocks[addr] = block
+		}
+	}
+	return blocks
+}
+
+// blockAddress returns the address used to match block between versions of
+// a file: the Terraform address for resources, and the block type and labels
+// otherwise.
+func blockAddress(block *hclwrite.Block) string {
+	if block.Type() == "resource" {
+		return strings.Join(block.Labels(), ".")
+	}
+	return strings.Join(append([]string{block.Type()}, block.Labels()...), ".")
+}
+
+// blockAddresses returns the address of each top-level block in src.
+func blockAddresses(src []byte, filename string) ([]string, error) {
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing generated %s: %w", filename, diags)
+	}
+	var addrs []string
+	for _, block := range f.Body().Blocks() {
+		addrs = append(addrs, blockAddress(block))
+	}
+	return addrs, nil
+}
+
+// exprBytes returns the normalized source of an attribute's expression.
+func exprBytes(attr *hclwrite.Attribute) []byte {
+	return bytes.TrimSpace(hclwrite.Format(attr.Expr().BuildTokens(nil).Bytes()))
+}
+
+// commentOut prefixes each line of block with a comment marker.
+func commentOut(block []byte) []byte {
+	lines := strings.Split(strings.TrimSuffix(string(block), "\n"), "\n")
+	var buf bytes.Buffer
+	buf.WriteString("# Deleted from Polytomic since the last export.\n")
+	for _, line := range lines {
+		if line == "" {
+			buf.WriteString("#\n")
+			continue
+		}
+		buf.WriteString("# " + line + "\n")
+	}
+	return buf.Bytes()
+}
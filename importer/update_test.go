@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeHCL(t *testing.T) {
+	base := `resource "polytomic_sync" "contacts" {
+  name     = "Contacts"
+  active   = true
+  mode     = "update"
+  schedule = { frequency = "daily" }
+}
+
+resource "polytomic_sync" "accounts" {
+  name   = "Accounts"
+  active = true
+}
+
+resource "polytomic_sync" "leads" {
+  name = "Leads"
+}
+`
+	// contacts.active was parameterized and a comment added; leads was
+	// refactored away; accounts is untouched.
+	existing := `# Code generated by github.com/polytomic/terraform-provider-polytomic/importer
+
+# The main contacts sync.
+resource "polytomic_sync" "contacts" {
+  name     = "Contacts"
+  active   = var.contacts_active # toggled per environment
+  mode     = "update"
+  schedule = { frequency = "daily" }
+}
+
+resource "polytomic_sync" "accounts" {
+  name   = "Accounts"
+  active = true
+}
+
+resource "polytomic_sync" "manual" {
+  name = "Written by hand"
+}
+`
+	// contacts was renamed remotely and accounts deleted; opportunities is
+	// new; leads is unchanged.
+	generated := `resource "polytomic_sync" "contacts" {
+  name     = "All Contacts"
+  active   = true
+  mode     = "update"
+  schedule = { frequency = "daily" }
+}
+
+resource "polytomic_sync" "leads" {
+  name = "Leads"
+}
+
+resource "polytomic_sync" "opportunities" {
+  name = "Opportunities"
+}
+`
+
+	out, result, err := mergeHCL([]byte(existing), []byte(base), []byte(generated), "syncs.tf")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `# Code generated by github.com/polytomic/terraform-provider-polytomic/importer
+
+# The main contacts sync.
+resource "polytomic_sync" "contacts" {
+  name     = "All Contacts"
+  active   = var.contacts_active # toggled per environment
+  mode     = "update"
+  schedule = { frequency = "daily" }
+}
+
+# Deleted from Polytomic since the last export.
+# resource "polytomic_sync" "accounts" {
+#   name   = "Accounts"
+#   active = true
+# }
+
+resource "polytomic_sync" "manual" {
+  name = "Written by hand"
+}
+
+resource "polytomic_sync" "opportunities" {
+  name = "Opportunities"
+}
+`
+	if string(out) != expected {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	expectedResult := mergeResult{
+		Added:   []string{"polytomic_sync.opportunities"},
+		Updated: []string{"polytomic_sync.contacts"},
+		Deleted: []string{"polytomic_sync.accounts"},
+	}
+	if !reflect.DeepEqual(expectedResult, result) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestMergeHCLUnchanged(t *testing.T) {
+	existing := `resource "polytomic_model" "users" {
+  # Hand-written comment
+  name          = "Users"
+  connection_id = polytomic_postgresql_connection.pg.id
+  configuration = jsonencode({
+    "table" = "users"
+  })
+}
+`
+	generated := strings.Replace(existing, "  # Hand-written comment\n", "", 1)
+
+	out, result, err := mergeHCL([]byte(existing), []byte(generated), []byte(generated), "models.tf")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(out) != existing {
+		t.Errorf("expected file to be unchanged, got:\n%s", out)
+	}
+	if !reflect.DeepEqual(mergeResult{}, result) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestNamesSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	names, err := readNamesSnapshot(dir)
+	if err != nil || names != nil {
+		t.Fatalf("expected no names without a snapshot, got %v, %v", names, err)
+	}
+
+	expected := map[string]map[string]string{
+		SyncResource: {"contacts": "6d2a1b2c-8e2f-4c9f-8d7b-4f3e2d1c0ba9"},
+	}
+	err = writeNamesSnapshot(dir, expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	names, err = readNamesSnapshot(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, names) {
+		t.Errorf("unexpected names %v", names)
+	}
+}
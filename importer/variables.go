@@ -2,7 +2,7 @@ package importer
 
 import (
 	"bytes"
-	"os"
+	"io"
 	"text/template"
 )
 
@@ -28,7 +28,7 @@ type Variable struct {
 	Sensitive bool
 }
 
-func generateVariables(f *os.File, vars []Variable) error {
+func generateVariables(w io.Writer, vars []Variable) error {
 	for _, v := range vars {
 		tmpl, err := template.New("variable").Parse(varTmpl)
 		if err != nil {
@@ -39,7 +39,7 @@ func generateVariables(f *os.File, vars []Variable) error {
 		if err != nil {
 			return err
 		}
-		_, err = f.Write(buf.Bytes())
+		_, err = w.Write(buf.Bytes())
 		if err != nil {
 			return err
 		}
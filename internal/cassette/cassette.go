@@ -0,0 +1,490 @@
+// Package cassette records the HTTP requests acceptance tests make to the
+// Polytomic API and replays them, so the tests run deterministically without
+// network access or credentials.
+//
+// Cassettes are recorded when POLYTOMIC_TEST_RECORD is set, and replayed
+// otherwise. Each cassette is a JSON file of sanitized request and response
+// pairs: authorization headers are never stored, and sensitive values are
+// replaced with Redacted.
+//
+// Tests often generate unique names for the objects they create. When a
+// replayed request sends a different value than was recorded, the recorded
+// value is replaced with the sent one in the responses which follow, so the
+// API appears to return what the test sent.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// RecordEnv is the environment variable which enables recording.
+	RecordEnv = "POLYTOMIC_TEST_RECORD"
+	// Dir is the directory cassettes are stored in, relative to the test's
+	// package.
+	Dir = "testdata/cassettes"
+	// Redacted replaces sensitive values in recorded requests and responses.
+	Redacted = "REDACTED"
+)
+
+// Recording reports whether cassettes are being recorded, rather than
+// replayed.
+func Recording() bool {
+	record, _ := strconv.ParseBool(os.Getenv(RecordEnv))
+	return record
+}
+
+// Options configures a Cassette.
+type Options struct {
+	// APIKey is true if the test authenticates with an API key, rather than a
+	// deployment or partner key. Tests often create different objects
+	// depending on the key, so cassettes are only replayed with the kind of
+	// key they were recorded with.
+	APIKey bool
+	// Sensitive reports whether values of a JSON field are redacted.
+	Sensitive func(field string) bool
+	// Secrets are values which are redacted wherever they occur.
+	Secrets []string
+	// Transport makes requests when recording. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Method string `json:"method"`
+	// Path is the request path and query; the host is not recorded so
+	// cassettes can be replayed against any deployment URL.
+	Path        string          `json:"path"`
+	Request     json.RawMessage `json:"request,omitempty"`
+	Status      int             `json:"status"`
+	ContentType string          `json:"content_type,omitempty"`
+	Response    json.RawMessage `json:"response,omitempty"`
+}
+
+type file struct {
+	APIKey bool `json:"api_key"`
+	// Values are the values generated by the test when it was recorded,
+	// such as the database its connections use.
+	Values       map[string]string `json:"values,omitempty"`
+	Interactions []*Interaction    `json:"interactions"`
+}
+
+// Cassette is an http.RoundTripper which records or replays requests.
+type Cassette struct {
+	t         testing.TB
+	path      string
+	recording bool
+	opts      Options
+
+	mu   sync.Mutex
+	file file
+	// used marks replayed interactions.
+	used []bool
+	// replacements map values in recorded requests to the values sent in
+	// their place when replaying.
+	replacements map[string]string
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// New returns the cassette named name. When recording, the cassette is saved
+// when the test completes. When replaying, the test is skipped if the
+// cassette hasn't been recorded, or was recorded with a different kind of key.
+func New(t testing.TB, name string, opts Options) *Cassette {
+	t.Helper()
+
+	c := &Cassette{
+		t:         t,
+		path:      filepath.Join(Dir, unsafeChars.ReplaceAllString(name, "_")+".json"),
+		recording: Recording(),
+		opts:      opts,
+		file: file{
+			APIKey: opts.APIKey,
+			Values: map[string]string{},
+		},
+		replacements: map[string]string{},
+	}
+	if c.opts.Transport == nil {
+		c.opts.Transport = http.DefaultTransport
+	}
+
+	if c.recording {
+		t.Cleanup(func() {
+			if t.Skipped() {
+				return
+			}
+			if err := c.save(); err != nil {
+				t.Errorf("saving cassette: %s", err)
+			}
+		})
+		return c
+	}
+
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		t.Skipf("cassette %s has not been recorded; record it by running the test with %s=1", c.path, RecordEnv)
+	}
+	if err != nil {
+		t.Fatalf("reading cassette: %s", err)
+	}
+	c.file = file{}
+	if err := json.Unmarshal(data, &c.file); err != nil {
+		t.Fatalf("reading cassette %s: %s", c.path, err)
+	}
+	if c.file.APIKey != opts.APIKey {
+		t.Skipf("cassette %s was recorded with a different kind of key (API key: %t)", c.path, c.file.APIKey)
+	}
+	c.used = make([]bool, len(c.file.Interactions))
+	return c
+}
+
+// Recording reports whether the cassette is being recorded.
+func (c *Cassette) Recording() bool {
+	return c.recording
+}
+
+// Value returns the value of key, for values a test needs before it makes
+// any requests, such as the database its connections use. When recording,
+// the value is generated and stored in the cassette; when replaying, the
+// recorded value is returned.
+func (c *Cassette) Value(key string, generate func() string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.file.Values[key]; ok {
+		return v
+	}
+	if !c.recording {
+		c.t.Fatalf("cassette %s has no value for %q; re-record it with %s=1", c.path, key, RecordEnv)
+	}
+	v := generate()
+	c.file.Values[key] = v
+	return v
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	if c.recording {
+		return c.record(req)
+	}
+	return c.replay(req)
+}
+
+// Serve starts an HTTP server which records or replays requests through the
+// cassette, for clients in other processes, such as providers run by the
+// Terraform CLI. When recording, requests are forwarded to upstream. It
+// returns the server's URL.
+func (c *Cassette) Serve(upstream string) string {
+	if !strings.Contains(upstream, "://") {
+		upstream = "https://" + upstream
+	}
+	target, err := url.Parse(upstream)
+	if err != nil {
+		c.t.Fatalf("invalid upstream URL: %s", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := r.Clone(r.Context())
+		req.RequestURI = ""
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+		req.Host = target.Host
+		// Let the transport negotiate compression so recorded responses
+		// are decoded.
+		req.Header.Del("Accept-Encoding")
+
+		resp, err := c.RoundTrip(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+	c.t.Cleanup(server.Close)
+	return server.URL
+}
+
+func (c *Cassette) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := c.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.file.Interactions = append(c.file.Interactions, &Interaction{
+		Method:      req.Method,
+		Path:        c.redactString(req.URL.RequestURI()),
+		Request:     c.sanitize(reqBody),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Response:    c.sanitize(respBody),
+	})
+	return resp, nil
+}
+
+// replay returns the response to an unused interaction with the same method
+// and path, preferring the one whose request is most like req. Terraform may
+// refresh resources concurrently, so requests aren't required to be made in
+// the order they were recorded.
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+	var sent any
+	if req.Body != nil {
+		reqBody, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		sent = decode(c.sanitize(reqBody))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := req.URL.RequestURI()
+	match, best := -1, -1
+	for i, in := range c.file.Interactions {
+		if c.used[i] || in.Method != req.Method || in.Path != path {
+			continue
+		}
+		if n := similarity(c.replace(decode(in.Request)), sent); n > best {
+			match, best = i, n
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("cassette %s has no unused response for %s %s; re-record it with %s=1",
+			c.path, req.Method, path, RecordEnv)
+	}
+	in := c.file.Interactions[match]
+	c.used[match] = true
+	c.learn(decode(in.Request), sent)
+
+	var body []byte
+	if resp := decode(in.Response); resp != nil {
+		if text, ok := resp.(string); ok {
+			body = []byte(c.replace(text).(string))
+		} else {
+			var err error
+			if body, err = json.Marshal(c.replace(resp)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	header := http.Header{}
+	if in.ContentType != "" {
+		header.Set("Content-Type", in.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// similarity returns the number of values in a recorded request which were
+// sent unchanged.
+func similarity(recorded, sent any) int {
+	n := 0
+	switch recorded := recorded.(type) {
+	case map[string]any:
+		sent, _ := sent.(map[string]any)
+		for k, v := range recorded {
+			if s, ok := sent[k]; ok {
+				n += similarity(v, s)
+			}
+		}
+	case []any:
+		sent, _ := sent.([]any)
+		for i, v := range recorded {
+			if i < len(sent) {
+				n += similarity(v, sent[i])
+			}
+		}
+	default:
+		if reflect.DeepEqual(recorded, sent) {
+			n = 1
+		}
+	}
+	return n
+}
+
+// learn adds replacements for the strings in a recorded request which were
+// sent with different values.
+func (c *Cassette) learn(recorded, sent any) {
+	switch recorded := recorded.(type) {
+	case map[string]any:
+		sent, _ := sent.(map[string]any)
+		for k, v := range recorded {
+			if s, ok := sent[k]; ok {
+				c.learn(v, s)
+			}
+		}
+	case []any:
+		sent, _ := sent.([]any)
+		if len(sent) != len(recorded) {
+			return
+		}
+		for i, v := range recorded {
+			c.learn(v, sent[i])
+		}
+	case string:
+		if sent, ok := sent.(string); ok && sent != recorded && recorded != "" && recorded != Redacted {
+			c.replacements[recorded] = sent
+		}
+	}
+}
+
+// replace returns v with the replacements applied to its strings.
+func (c *Cassette) replace(v any) any {
+	if len(c.replacements) == 0 {
+		return v
+	}
+	// Longer values are replaced first, so a name isn't partially replaced
+	// by a value it contains.
+	recorded := slices.Collect(maps.Keys(c.replacements))
+	slices.SortFunc(recorded, func(a, b string) int { return len(b) - len(a) })
+	pairs := make([]string, 0, 2*len(recorded))
+	for _, r := range recorded {
+		pairs = append(pairs, r, c.replacements[r])
+	}
+	return replaceStrings(v, strings.NewReplacer(pairs...))
+}
+
+func replaceStrings(v any, r *strings.Replacer) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			v[k] = replaceStrings(val, r)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = replaceStrings(val, r)
+		}
+	case string:
+		return r.Replace(v)
+	}
+	return v
+}
+
+// decode returns the value of a sanitized body, or nil if it's empty.
+func decode(body json.RawMessage) any {
+	if len(body) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil
+	}
+	return v
+}
+
+// sanitize returns body with sensitive values redacted. JSON bodies are
+// stored as JSON so cassettes are readable; other bodies are stored as a
+// JSON string.
+func (c *Cassette) sanitize(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		v = string(body)
+	}
+	out, err := json.Marshal(c.redact(v))
+	if err != nil {
+		c.t.Fatalf("sanitizing request: %s", err)
+	}
+	return out
+}
+
+func (c *Cassette) redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if s, ok := val.(string); ok && s != "" && c.opts.Sensitive != nil && c.opts.Sensitive(k) {
+				v[k] = Redacted
+				continue
+			}
+			v[k] = c.redact(val)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = c.redact(val)
+		}
+	case string:
+		return c.redactString(v)
+	}
+	return v
+}
+
+func (c *Cassette) redactString(s string) string {
+	for _, secret := range c.opts.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return s
+}
+
+func (c *Cassette) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c.file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,210 @@
+package cassette
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, c *Cassette, url string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-key")
+	resp, err := (&http.Client{Transport: c}).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestRecordReplay(t *testing.T) {
+	t.Chdir(t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/connections/1":
+			w.Write([]byte(`{"data":{"id":"1","name":"Warehouse","configuration":{"hostname":"db","password":"hunter2"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":404,"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	opts := Options{
+		APIKey:    true,
+		Sensitive: func(field string) bool { return field == "password" },
+		Secrets:   []string{"secret-key"},
+	}
+
+	var name string
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		c := New(t, "TestRecordReplay", opts)
+		name = c.Value("name", uuid.NewString)
+
+		status, body := get(t, c, server.URL+"/api/connections/1")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, "hunter2", "responses are returned unmodified when recording")
+		status, _ = get(t, c, server.URL+"/api/connections/2?force=true")
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	data, err := os.ReadFile(filepath.Join(Dir, "TestRecordReplay.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.NotContains(t, string(data), "secret-key")
+	assert.NotContains(t, string(data), server.URL)
+
+	var f file
+	require.NoError(t, json.Unmarshal(data, &f))
+	require.Len(t, f.Interactions, 2)
+	assert.Equal(t, "/api/connections/2?force=true", f.Interactions[1].Path)
+
+	server.Close()
+	t.Run("replay", func(t *testing.T) {
+		t.Setenv(RecordEnv, "")
+		c := New(t, "TestRecordReplay", opts)
+		assert.Equal(t, name, c.Value("name", func() string { panic("not recording") }))
+
+		// Requests are matched by method and path, not order or host.
+		status, _ := get(t, c, "https://replay.invalid/api/connections/2?force=true")
+		assert.Equal(t, http.StatusNotFound, status)
+		status, body := get(t, c, "https://replay.invalid/api/connections/1")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"data":{"id":"1","name":"Warehouse","configuration":{"hostname":"db","password":"REDACTED"}}}`, body)
+
+		_, err := (&http.Client{Transport: c}).Get("https://replay.invalid/api/connections/1")
+		require.Error(t, err, "each interaction is replayed once")
+		assert.True(t, strings.Contains(err.Error(), RecordEnv))
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Chdir(t.TempDir())
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"path":"` + r.URL.Path + `"}}`))
+	}))
+	defer upstream.Close()
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		c := New(t, "TestServe", Options{})
+		resp, err := http.Get(c.Serve(upstream.URL) + "/api/me")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.JSONEq(t, `{"data":{"path":"/api/me"}}`, string(body))
+	})
+
+	upstream.Close()
+	t.Run("replay", func(t *testing.T) {
+		t.Setenv(RecordEnv, "")
+		c := New(t, "TestServe", Options{})
+		resp, err := http.Get(c.Serve("https://replay.invalid") + "/api/me")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"data":{"path":"/api/me"}}`, string(body))
+	})
+}
+
+func TestReplaySentValues(t *testing.T) {
+	t.Chdir(t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	post := func(t *testing.T, c *Cassette, url, body string) string {
+		t.Helper()
+		resp, err := (&http.Client{Transport: c}).Post(url, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		c := New(t, "TestReplaySentValues", Options{})
+		post(t, c, server.URL+"/api/connections", `{"name":"Warehouse-1a2b","type":"postgresql"}`)
+		post(t, c, server.URL+"/api/connections", `{"name":"Lake-1a2b","type":"s3"}`)
+		post(t, c, server.URL+"/api/models", `{"name":"Warehouse-1a2b users"}`)
+	})
+
+	server.Close()
+	t.Run("replay", func(t *testing.T) {
+		t.Setenv(RecordEnv, "")
+		c := New(t, "TestReplaySentValues", Options{})
+		// requests are matched to the recorded request they're the same as
+		assert.JSONEq(t, `{"data":{"name":"Lake-3c4d","type":"s3"}}`,
+			post(t, c, "https://replay.invalid/api/connections", `{"name":"Lake-3c4d","type":"s3"}`))
+		assert.JSONEq(t, `{"data":{"name":"Warehouse-3c4d","type":"postgresql"}}`,
+			post(t, c, "https://replay.invalid/api/connections", `{"name":"Warehouse-3c4d","type":"postgresql"}`))
+		// values sent earlier are replaced in later responses
+		assert.JSONEq(t, `{"data":{"name":"Warehouse-3c4d users"}}`,
+			post(t, c, "https://replay.invalid/api/models", `{}`))
+	})
+}
+
+// testTB records whether a test failed or was skipped, without stopping the
+// test using it.
+type testTB struct {
+	testing.TB
+	failed, skipped bool
+}
+
+func (t *testTB) Fatalf(format string, args ...any) {
+	t.failed = true
+	runtime.Goexit()
+}
+
+func (t *testTB) Skipf(format string, args ...any) {
+	t.skipped = true
+	runtime.Goexit()
+}
+
+func TestReplayUnavailable(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll(Dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(Dir, "deployment.json"), []byte(`{"api_key":false,"interactions":[]}`), 0o644))
+	t.Setenv(RecordEnv, "")
+
+	open := func(name string) *testTB {
+		tb := &testTB{TB: t}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			New(tb, name, Options{APIKey: true})
+		}()
+		<-done
+		return tb
+	}
+
+	missing := open("TestMissing")
+	assert.False(t, missing.failed)
+	assert.True(t, missing.skipped, "a missing cassette skips the test")
+	differentKey := open("deployment")
+	assert.False(t, differentKey.failed)
+	assert.True(t, differentKey.skipped, "a cassette recorded with a different kind of key skips the test")
+}
@@ -0,0 +1,158 @@
+package fakeapi
+
+import (
+	"maps"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// handleStartBulkSync starts an execution of a bulk sync. Executions are
+// reported running by the next request for them, and completed after that.
+func (s *Server) handleStartBulkSync(w http.ResponseWriter, r *http.Request) {
+	body, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bulk, ok := s.lookup(w, r, s.collections[BulkSyncs])
+	if !ok {
+		return
+	}
+	id := bulk["id"].(string)
+	execution := clone(body)
+	execution["id"] = uuid.NewString()
+	execution["status"] = "created"
+	s.executions[id] = append(s.executions[id], execution)
+	writeData(w, http.StatusOK, clone(execution))
+}
+
+func (s *Server) handleGetBulkSyncExecution(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bulk, ok := s.lookup(w, r, s.collections[BulkSyncs])
+	if !ok {
+		return
+	}
+	for _, execution := range s.executions[bulk["id"].(string)] {
+		if execution["id"] != r.PathValue("exec_id") {
+			continue
+		}
+		switch execution["status"] {
+		case "created":
+			execution["status"] = "running"
+		case "running":
+			execution["status"] = "completed"
+		}
+		writeData(w, http.StatusOK, clone(execution))
+		return
+	}
+	writeNotFound(w, "execution")
+}
+
+// updateBulkSync selects all of the source's schemas when an update omits
+// them. That's the default the provider assumes of the API, so tests catch
+// updates which would re-enable schemas configured individually.
+func updateBulkSync(s *Server, old, obj Object) {
+	if _, ok := obj["schemas"]; ok {
+		return
+	}
+	source, _ := obj["source_connection_id"].(string)
+	items, _ := s.bulkSources[source]["schemas"].([]any)
+	schemas := make([]any, 0, len(items))
+	for _, item := range items {
+		if schema, ok := item.(Object); ok {
+			schemas = append(schemas, Object{"id": schema["id"], "enabled": true})
+		}
+	}
+	obj["schemas"] = schemas
+}
+
+// bulkSyncSchemas returns the schemas of a bulk sync. Bulk syncs are created
+// with a list of schema IDs or schema configurations; the list is normalized to
+// configurations, which are enabled unless configured otherwise, so they can
+// be updated in place. s.mu must be held.
+func bulkSyncSchemas(bulk Object) []Object {
+	items, _ := bulk["schemas"].([]any)
+	schemas := make([]Object, 0, len(items))
+	for i, item := range items {
+		schema, ok := item.(Object)
+		if !ok {
+			id, _ := item.(string)
+			schema = Object{"id": id}
+		}
+		if _, ok := schema["enabled"]; !ok {
+			schema["enabled"] = true
+		}
+		items[i] = schema
+		schemas = append(schemas, schema)
+	}
+	return schemas
+}
+
+func (s *Server) handleGetBulkSyncSchema(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bulk, ok := s.lookup(w, r, s.collections[BulkSyncs])
+	if !ok {
+		return
+	}
+	for _, schema := range bulkSyncSchemas(bulk) {
+		if schema["id"] == r.PathValue("schema_id") {
+			writeData(w, http.StatusOK, clone(schema))
+			return
+		}
+	}
+	writeNotFound(w, "schema")
+}
+
+// handleUpdateBulkSyncSchema updates the fields of a bulk sync's schema which
+// are in the request. Schemas of the source connection which the bulk sync
+// wasn't created with are added.
+func (s *Server) handleUpdateBulkSyncSchema(w http.ResponseWriter, r *http.Request) {
+	body, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bulk, ok := s.lookup(w, r, s.collections[BulkSyncs])
+	if !ok {
+		return
+	}
+	id := r.PathValue("schema_id")
+	var schema Object
+	for _, sch := range bulkSyncSchemas(bulk) {
+		if sch["id"] == id {
+			schema = sch
+		}
+	}
+	if schema == nil {
+		if !s.bulkSourceHasSchema(bulk["source_connection_id"], id) {
+			writeNotFound(w, "schema")
+			return
+		}
+		schema = Object{"id": id, "enabled": true}
+		items, _ := bulk["schemas"].([]any)
+		bulk["schemas"] = append(items, schema)
+	}
+	maps.Copy(schema, body)
+	schema["id"] = id
+	writeData(w, http.StatusOK, clone(schema))
+}
+
+// bulkSourceHasSchema reports whether a connection's bulk sync source metadata
+// includes a schema. s.mu must be held.
+func (s *Server) bulkSourceHasSchema(connectionID any, schemaID string) bool {
+	id, _ := connectionID.(string)
+	schemas, _ := s.bulkSources[id]["schemas"].([]any)
+	for _, sch := range schemas {
+		if schema, ok := sch.(Object); ok && schema["id"] == schemaID {
+			return true
+		}
+	}
+	return false
+}
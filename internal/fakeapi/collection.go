@@ -0,0 +1,302 @@
+package fakeapi
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// collection is a set of objects managed through a REST collection endpoint.
+type collection struct {
+	// noun names an object in error messages.
+	noun string
+	// required are the fields which must be set when an object is created.
+	required []string
+	// create is called with the new object before it is stored.
+	create func(s *Server, obj Object)
+	// update is called with the stored and updated object before the update
+	// is validated and stored.
+	update func(s *Server, old, obj Object)
+	// render returns the API representation of an object.
+	render func(s *Server, obj Object) Object
+	// inUse returns the objects which prevent an object from being deleted.
+	inUse func(s *Server, obj Object) []Object
+
+	objects map[string]Object
+	// order is the order objects were created in, so lists are stable.
+	order []string
+}
+
+func newCollections() map[string]*collection {
+	return map[string]*collection{
+		Organizations: {noun: "organization"},
+		Connections: {
+			noun:     "connection",
+			required: []string{"name", "type"},
+			create:   createConnection,
+			update:   updateConnection,
+			render:   renderConnection,
+			inUse:    connectionInUse,
+		},
+		Models: {
+			noun:     "model",
+			required: []string{"name", "connection_id"},
+			create:   createModel,
+			update:   updateModel,
+		},
+		Syncs: {
+			noun:     "sync",
+			required: []string{"name", "mode", "target"},
+		},
+		BulkSyncs: {
+			noun:     "bulk sync",
+			required: []string{"name", "source_connection_id", "destination_connection_id"},
+			update:   updateBulkSync,
+		},
+		Users: {
+			noun:     "user",
+			required: []string{"email"},
+		},
+		Roles: {
+			noun:     "role",
+			required: []string{"name"},
+			create:   createPermission,
+		},
+		Policies: {
+			noun:     "policy",
+			required: []string{"name"},
+			create:   createPermission,
+		},
+	}
+}
+
+func (c *collection) put(obj Object) {
+	id := obj["id"].(string)
+	if _, ok := c.objects[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	if c.objects == nil {
+		c.objects = map[string]Object{}
+	}
+	c.objects[id] = obj
+}
+
+func (c *collection) remove(id string) {
+	delete(c.objects, id)
+	c.order = slices.DeleteFunc(c.order, func(o string) bool { return o == id })
+}
+
+// list returns the objects which match filter, in the order they were
+// created.
+func (c *collection) list(filter func(Object) bool) []Object {
+	objs := []Object{}
+	for _, id := range c.order {
+		if obj := c.objects[id]; filter(obj) {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// handleCollection registers the list, create, get, update and delete
+// endpoints of a collection at path.
+func (s *Server) handleCollection(mux *http.ServeMux, path, name string) {
+	c := s.collections[name]
+	mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+		org, ok := s.organization(w, r)
+		if !ok {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		objs := c.list(func(obj Object) bool { return obj["organization_id"] == org })
+		for i, obj := range objs {
+			objs[i] = c.output(s, obj)
+		}
+		writeData(w, http.StatusOK, objs)
+	})
+	mux.HandleFunc("POST "+path, func(w http.ResponseWriter, r *http.Request) {
+		org, ok := s.organization(w, r)
+		if !ok {
+			return
+		}
+		obj, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		if missing := missingFields(obj, c.required...); len(missing) > 0 {
+			writeValidationError(w, missing)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		obj["id"] = uuid.NewString()
+		obj["organization_id"] = org
+		obj["created_at"] = now()
+		obj["updated_at"] = obj["created_at"]
+		if c.create != nil {
+			c.create(s, obj)
+		}
+		c.put(obj)
+		writeData(w, http.StatusOK, c.output(s, obj))
+	})
+
+	item := path + "/{id}"
+	mux.HandleFunc("GET "+item, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		obj, ok := s.lookup(w, r, c)
+		if !ok {
+			return
+		}
+		writeData(w, http.StatusOK, c.output(s, obj))
+	})
+	updateItem := func(w http.ResponseWriter, r *http.Request) {
+		update, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		old, ok := s.lookup(w, r, c)
+		if !ok {
+			return
+		}
+		// PUT replaces the object, PATCH updates the fields in the request.
+		obj := update
+		if r.Method == http.MethodPatch {
+			obj = clone(old)
+			maps.Copy(obj, update)
+		}
+		for _, f := range []string{"id", "organization_id", "created_at"} {
+			obj[f] = old[f]
+		}
+		if c.update != nil {
+			c.update(s, old, obj)
+		}
+		if missing := missingFields(obj, c.required...); len(missing) > 0 {
+			writeValidationError(w, missing)
+			return
+		}
+		obj["updated_at"] = now()
+		c.put(obj)
+		writeData(w, http.StatusOK, c.output(s, obj))
+	}
+	mux.HandleFunc("PUT "+item, updateItem)
+	mux.HandleFunc("PATCH "+item, updateItem)
+	mux.HandleFunc("DELETE "+item, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		obj, ok := s.lookup(w, r, c)
+		if !ok {
+			return
+		}
+		if c.inUse != nil && r.URL.Query().Get("force") != "true" {
+			if usedBy := c.inUse(s, obj); len(usedBy) > 0 {
+				writeError(w, http.StatusUnprocessableEntity, c.noun+" in use", Object{"used_by": usedBy})
+				return
+			}
+		}
+		c.remove(obj["id"].(string))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// organization returns the organization a collection request is scoped to:
+// the organization in the path, if any, or the caller's organization.
+func (s *Server) organization(w http.ResponseWriter, r *http.Request) (string, bool) {
+	c := callerFrom(r)
+	org := c.organization
+	if pathOrg := r.PathValue("org_id"); pathOrg != "" {
+		if !c.canAccess(pathOrg) {
+			writeNotFound(w, "organization")
+			return "", false
+		}
+		org = pathOrg
+	}
+	if org == "" {
+		writeError(w, http.StatusForbidden, "organization is required", nil)
+		return "", false
+	}
+	return org, true
+}
+
+// lookup returns the object identified by the request path, writing a not
+// found response if it doesn't exist, belongs to another organization, or was
+// deleted after being listed.
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request, c *collection) (Object, bool) {
+	org, ok := s.organization(w, r)
+	if !ok {
+		return nil, false
+	}
+	obj, ok := c.objects[r.PathValue("id")]
+	if !ok || obj["organization_id"] != org || s.deletedAfterList[r.PathValue("id")] {
+		writeNotFound(w, c.noun)
+		return nil, false
+	}
+	return obj, true
+}
+
+// output returns the API representation of obj.
+func (c *collection) output(s *Server, obj Object) Object {
+	obj = clone(obj)
+	if c.render != nil {
+		obj = c.render(s, obj)
+	}
+	return obj
+}
+
+func createModel(s *Server, obj Object) {
+	modelFields(nil, obj)
+}
+
+func updateModel(s *Server, old, obj Object) {
+	modelFields(old, obj)
+}
+
+// modelFields converts the field names and additional fields of a model
+// request to the model's fields. Model fields are derived from the model's
+// query by the API; the fake has no data, so the model has the fields it's
+// given, which are strings unless the model already has them.
+func modelFields(old, obj Object) {
+	existing := map[string]any{}
+	oldFields, _ := old["fields"].([]any)
+	for _, item := range oldFields {
+		if field, ok := item.(Object); ok && field["user_added"] != true {
+			existing[field["name"].(string)] = field
+		}
+	}
+
+	items, _ := obj["fields"].([]any)
+	fields := make([]any, 0, len(items))
+	for _, item := range items {
+		switch f := item.(type) {
+		case Object:
+			fields = append(fields, f)
+		case string:
+			field, ok := existing[f]
+			if !ok {
+				field = Object{"name": f, "type": "string", "label": f}
+			}
+			fields = append(fields, field)
+		}
+	}
+	additional, _ := obj["additional_fields"].([]any)
+	for _, item := range additional {
+		if f, ok := item.(Object); ok {
+			field := clone(f)
+			field["user_added"] = true
+			fields = append(fields, field)
+		}
+	}
+	delete(obj, "additional_fields")
+	obj["fields"] = fields
+}
+
+func createPermission(s *Server, obj Object) {
+	obj["system"] = false
+}
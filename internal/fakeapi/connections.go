@@ -0,0 +1,323 @@
+package fakeapi
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// sensitiveFields are connection configuration fields which are never
+// returned by the API.
+var sensitiveFields = map[string]bool{
+	"access_key_secret":     true,
+	"access_token":          true,
+	"api_key":               true,
+	"api_secret":            true,
+	"aws_secret_access_key": true,
+	"client_secret":         true,
+	"credentials":           true,
+	"oauth_refresh_token":   true,
+	"password":              true,
+	"private_key":           true,
+	"refresh_token":         true,
+	"secret":                true,
+	"secret_access_key":     true,
+	"service_account":       true,
+	"ssh_private_key":       true,
+	"token":                 true,
+}
+
+// Sensitive reports whether a connection configuration field is masked in
+// API responses.
+func Sensitive(field string) bool {
+	return sensitiveFields[field]
+}
+
+func createConnection(s *Server, obj Object) {
+	// Connections are created with a type ID, but the API returns the type.
+	typ, _ := obj["type"].(string)
+	obj["type"] = Object{"id": typ, "name": typ}
+	obj["status"] = "healthy"
+	if s.oauthTypes[typ] {
+		obj["status"] = "unhealthy"
+	}
+	if _, ok := obj["configuration"]; !ok {
+		obj["configuration"] = Object{}
+	}
+	delete(obj, "validate")
+}
+
+func updateConnection(s *Server, old, obj Object) {
+	obj["type"] = old["type"]
+	obj["status"] = old["status"]
+	delete(obj, "validate")
+
+	// Sensitive values which are omitted from an update are unchanged, so
+	// configuration read from the API can be sent back.
+	conf, _ := obj["configuration"].(Object)
+	if conf == nil {
+		conf = Object{}
+		obj["configuration"] = conf
+	}
+	oldConf, _ := old["configuration"].(Object)
+	for k, v := range oldConf {
+		if _, ok := conf[k]; !ok && Sensitive(k) {
+			conf[k] = v
+		}
+	}
+}
+
+func renderConnection(s *Server, obj Object) Object {
+	if conf, ok := obj["configuration"].(Object); ok {
+		for k := range conf {
+			if Sensitive(k) {
+				delete(conf, k)
+			}
+		}
+	}
+	return obj
+}
+
+// connectionInUse returns the models, syncs and bulk syncs which use a
+// connection.
+func connectionInUse(s *Server, conn Object) []Object {
+	id := conn["id"]
+	var usedBy []Object
+	for _, m := range s.collections[Models].list(func(m Object) bool { return m["connection_id"] == id }) {
+		usedBy = append(usedBy, Object{"type": "model", "id": m["id"], "name": m["name"]})
+	}
+	for _, sync := range s.collections[Syncs].list(func(sync Object) bool {
+		target, _ := sync["target"].(Object)
+		return target["connection_id"] == id
+	}) {
+		usedBy = append(usedBy, Object{"type": "sync", "id": sync["id"], "name": sync["name"]})
+	}
+	for _, b := range s.collections[BulkSyncs].list(func(b Object) bool {
+		return b["source_connection_id"] == id || b["destination_connection_id"] == id
+	}) {
+		usedBy = append(usedBy, Object{"type": "bulk_sync", "id": b["id"], "name": b["name"]})
+	}
+	return usedBy
+}
+
+// handleConnect creates a link which completes the OAuth flow of a
+// connection.
+func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
+	body, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+	if missing := missingFields(body, "name", "redirect_url"); len(missing) > 0 {
+		writeValidationError(w, missing)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	org, ok := s.organization(w, r)
+	if !ok {
+		return
+	}
+	token := uuid.NewString()
+	if connID, ok := body["connection_id"].(string); ok {
+		conn, ok := s.collections[Connections].objects[connID]
+		if !ok || conn["organization_id"] != org {
+			writeNotFound(w, "connection")
+			return
+		}
+		s.connectTokens[token] = connID
+	}
+	writeData(w, http.StatusOK, Object{
+		"redirect_url": s.URL + "/connect/" + token,
+		"token":        token,
+	})
+}
+
+// connection returns the connection identified by the request path, writing
+// a not found response if it doesn't exist. s.mu must be held.
+func (s *Server) connection(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	return s.lookup(w, r, s.collections[Connections])
+}
+
+func (s *Server) handleListSchemas(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, ok := s.connection(w, r)
+	if !ok {
+		return
+	}
+
+	schemas := []Object{}
+	for _, schema := range s.schemas[conn["id"].(string)] {
+		schemas = append(schemas, clone(schema))
+	}
+	slices.SortFunc(schemas, func(a, b Object) int {
+		return strings.Compare(a["id"].(string), b["id"].(string))
+	})
+	writeData(w, http.StatusOK, schemas)
+}
+
+// handleRefreshSchemas starts a refresh of a connection's schema cache. The
+// following status requests report the connection's refresh statuses.
+func (s *Server) handleRefreshSchemas(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, ok := s.connection(w, r)
+	if !ok {
+		return
+	}
+	id := conn["id"].(string)
+	s.schemaRefreshes[id]++
+	statuses, ok := s.refreshStatuses[id]
+	if !ok {
+		statuses = []string{"refreshing", "ready"}
+	}
+	s.cacheStatuses[id] = slices.Clone(statuses)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleSchemaStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, ok := s.connection(w, r)
+	if !ok {
+		return
+	}
+	id := conn["id"].(string)
+	status := "ready"
+	if statuses := s.cacheStatuses[id]; len(statuses) > 0 {
+		status = statuses[0]
+		if len(statuses) > 1 {
+			s.cacheStatuses[id] = statuses[1:]
+		}
+	}
+	writeData(w, http.StatusOK, Object{"cache_status": status})
+}
+
+func (s *Server) handleGetSchema(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schema, ok := s.schema(w, r)
+	if !ok {
+		return
+	}
+	writeData(w, http.StatusOK, clone(schema))
+}
+
+func (s *Server) handleSetPrimaryKeys(w http.ResponseWriter, r *http.Request) {
+	body, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schema, ok := s.schema(w, r)
+	if !ok {
+		return
+	}
+
+	fields, _ := schema["fields"].([]any)
+	overrides, _ := body["fields"].([]any)
+	for _, o := range overrides {
+		override, _ := o.(Object)
+		found := false
+		for _, f := range fields {
+			if field, _ := f.(Object); field["id"] == override["field_id"] {
+				field["is_primary_key"] = override["is_primary_key"]
+				found = true
+			}
+		}
+		if !found {
+			writeError(w, http.StatusUnprocessableEntity, "field not found", Object{"field_id": override["field_id"]})
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleResetPrimaryKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schema, ok := s.schema(w, r)
+	if !ok {
+		return
+	}
+
+	fields, _ := schema["fields"].([]any)
+	for _, f := range fields {
+		if field, ok := f.(Object); ok {
+			delete(field, "is_primary_key")
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// schema returns the schema identified by the request path. s.mu must be
+// held.
+func (s *Server) schema(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	conn, ok := s.connection(w, r)
+	if !ok {
+		return nil, false
+	}
+	schema, ok := s.schemas[conn["id"].(string)][r.PathValue("schema_id")]
+	if !ok {
+		writeNotFound(w, "schema")
+		return nil, false
+	}
+	return schema, true
+}
+
+func (s *Server) handleBulkSyncSchemas(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bulk, ok := s.lookup(w, r, s.collections[BulkSyncs])
+	if !ok {
+		return
+	}
+
+	schemas := bulkSyncSchemas(bulk)
+	for i, schema := range schemas {
+		schemas[i] = clone(schema)
+	}
+	writeData(w, http.StatusOK, schemas)
+}
+
+// handleBulkMetadata returns a handler for the bulk sync source or
+// destination metadata of a connection.
+// handleTargetFields returns the fields of the sync target object named by
+// the target query parameter.
+func (s *Server) handleTargetFields(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, ok := s.connection(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := s.targetFields[conn["id"].(string)][r.URL.Query().Get("target")]
+	if !ok {
+		writeNotFound(w, "target")
+		return
+	}
+	writeData(w, http.StatusOK, Object{"fields": fields})
+}
+
+func (s *Server) handleBulkMetadata(metadata func() map[string]Object) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		conn, ok := s.connection(w, r)
+		if !ok {
+			return
+		}
+		m, ok := metadata()[conn["id"].(string)]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity,
+				"connection does not support bulk syncs", Object{"connection_id": conn["id"]})
+			return
+		}
+		writeData(w, http.StatusOK, clone(m))
+	}
+}
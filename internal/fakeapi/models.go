@@ -0,0 +1,83 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// previewConnection returns the connection and preview of the model in the
+// request body, writing an error response if the connection doesn't exist or
+// has no preview set. s.mu must be held.
+func (s *Server) previewConnection(w http.ResponseWriter, r *http.Request, body Object) (Object, Object, bool) {
+	if missing := missingFields(body, "connection_id", "configuration"); len(missing) > 0 {
+		writeValidationError(w, missing)
+		return nil, nil, false
+	}
+	org, ok := s.organization(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	connID, _ := body["connection_id"].(string)
+	conn, ok := s.collections[Connections].objects[connID]
+	if !ok || conn["organization_id"] != org {
+		writeNotFound(w, "connection")
+		return nil, nil, false
+	}
+	preview, ok := s.modelPreviews[connID]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity,
+			"connection does not support models", Object{"connection_id": connID})
+		return nil, nil, false
+	}
+	return conn, preview, true
+}
+
+// handlePreviewModel returns the fields and identifier of an unsaved model.
+func (s *Server) handlePreviewModel(w http.ResponseWriter, r *http.Request) {
+	body, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, preview, ok := s.previewConnection(w, r, body)
+	if !ok {
+		return
+	}
+	model := clone(body)
+	model["organization_id"] = conn["organization_id"]
+	model["fields"] = []any{}
+	if fields, ok := preview["fields"]; ok {
+		model["fields"] = fields
+	}
+	if identifier, ok := preview["identifier"]; ok {
+		model["identifier"] = identifier
+	}
+	writeData(w, http.StatusOK, clone(model))
+}
+
+// handleSampleModel returns sample rows of an unsaved model, limited by the
+// limit query parameter.
+func (s *Server) handleSampleModel(w http.ResponseWriter, r *http.Request) {
+	body, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, preview, ok := s.previewConnection(w, r, body)
+	if !ok {
+		return
+	}
+	s.modelSamples[body["connection_id"].(string)]++
+	rows, _ := clone(preview)["samples"].([]any)
+	if rows == nil {
+		rows = []any{}
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	writeData(w, http.StatusOK, rows)
+}
@@ -0,0 +1,590 @@
+// Package fakeapi implements an in-memory fake of the Polytomic API for
+// offline provider tests.
+//
+// The fake is stateful: objects created through the API can be read, updated,
+// listed and deleted, and are scoped to the organization of the caller.
+// Sensitive connection configuration is masked in responses, and errors use
+// the same status codes and body shape as the Polytomic API, so the provider's
+// 404 and 422 handling is exercised.
+package fakeapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Object is a JSON object stored by the fake.
+type Object = map[string]any
+
+// Collection names, for use with Server.Get and Server.Put.
+const (
+	Connections   = "connections"
+	Models        = "models"
+	Syncs         = "syncs"
+	BulkSyncs     = "bulk_syncs"
+	Users         = "users"
+	Roles         = "roles"
+	Policies      = "policies"
+	Organizations = "organizations"
+)
+
+// Server is a fake Polytomic API.
+type Server struct {
+	*httptest.Server
+
+	// Organization is the organization of APIKey.
+	Organization string
+	// APIKey authenticates as a user in Organization.
+	APIKey string
+	// PartnerKey authenticates as a partner, with access to all
+	// organizations.
+	PartnerKey string
+
+	mu          sync.Mutex
+	collections map[string]*collection
+	// schemas are the schemas of each connection, by connection and schema
+	// ID.
+	schemas map[string]map[string]Object
+	// bulkSources and bulkDestinations are the bulk sync source and
+	// destination metadata of each connection.
+	bulkSources      map[string]Object
+	bulkDestinations map[string]Object
+	// targetFields are the fields of each connection's sync targets, by
+	// connection and target object.
+	targetFields map[string]map[string][]any
+	// modelPreviews are what previewing a model of each connection returns;
+	// modelSamples counts the model samples requested for each connection.
+	modelPreviews map[string]Object
+	modelSamples  map[string]int
+	// schemaRefreshes counts the schema refreshes requested for each
+	// connection. refreshStatuses are the cache statuses reported after each
+	// connection's refresh is requested, and cacheStatuses those still to be
+	// reported.
+	schemaRefreshes map[string]int
+	refreshStatuses map[string][]string
+	cacheStatuses   map[string][]string
+	// executions are the executions started for each bulk sync.
+	executions map[string][]Object
+	// subscribers are the global error subscribers of each organization.
+	subscribers map[string][]string
+	// oauthTypes are the connection types which are authorized with OAuth;
+	// connectTokens are the connections of each connect link created.
+	oauthTypes    map[string]bool
+	connectTokens map[string]string
+	// deletedAfterList are the objects which are listed, but not found when
+	// read by ID.
+	deletedAfterList map[string]bool
+}
+
+// New starts a fake Polytomic API, which is closed when the test completes.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		Organization:     uuid.NewString(),
+		APIKey:           "fake-api-key",
+		PartnerKey:       "fake-partner-key",
+		collections:      newCollections(),
+		schemas:          map[string]map[string]Object{},
+		bulkSources:      map[string]Object{},
+		bulkDestinations: map[string]Object{},
+		targetFields:     map[string]map[string][]any{},
+		modelPreviews:    map[string]Object{},
+		modelSamples:     map[string]int{},
+		schemaRefreshes:  map[string]int{},
+		refreshStatuses:  map[string][]string{},
+		cacheStatuses:    map[string][]string{},
+		executions:       map[string][]Object{},
+		subscribers:      map[string][]string{},
+		oauthTypes:       map[string]bool{},
+		connectTokens:    map[string]string{},
+		deletedAfterList: map[string]bool{},
+	}
+	s.collections[Organizations].put(Object{
+		"id":   s.Organization,
+		"name": "Fake Organization",
+	})
+	s.Server = httptest.NewServer(s.routes())
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Get returns a copy of an object as stored by the fake, including sensitive
+// values which are masked in API responses.
+func (s *Server) Get(collection, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.collections[collection].objects[id]
+	if !ok {
+		return nil, false
+	}
+	return clone(obj), true
+}
+
+// Put stores an object, replacing any object with the same ID. Objects without
+// an ID are assigned one, and objects without an organization belong to
+// Organization. Put returns the object's ID.
+func (s *Server) Put(collection string, obj Object) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj = clone(obj)
+	if _, ok := obj["id"]; !ok {
+		obj["id"] = uuid.NewString()
+	}
+	if _, ok := obj["organization_id"]; !ok && collection != Organizations {
+		obj["organization_id"] = s.Organization
+	}
+	s.collections[collection].put(obj)
+	return obj["id"].(string)
+}
+
+// AddSchema adds a schema to a connection. The schema must have an id.
+func (s *Server) AddSchema(connectionID string, schema Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.schemas[connectionID] == nil {
+		s.schemas[connectionID] = map[string]Object{}
+	}
+	s.schemas[connectionID][schema["id"].(string)] = clone(schema)
+}
+
+// SetBulkSource sets the bulk sync source metadata of a connection.
+func (s *Server) SetBulkSource(connectionID string, source Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bulkSources[connectionID] = clone(source)
+}
+
+// SetTargetFields sets the fields of a connection's sync target object.
+func (s *Server) SetTargetFields(connectionID, target string, fields ...Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.targetFields[connectionID] == nil {
+		s.targetFields[connectionID] = map[string][]any{}
+	}
+	items := make([]any, len(fields))
+	for i, f := range fields {
+		items[i] = clone(f)
+	}
+	s.targetFields[connectionID][target] = items
+}
+
+// SchemaRefreshes returns the number of schema refreshes requested for a
+// connection.
+func (s *Server) SchemaRefreshes(connectionID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.schemaRefreshes[connectionID]
+}
+
+// SetSchemaRefreshStatuses sets the schema cache statuses reported, in turn,
+// by the status requests following a schema refresh of a connection; the last
+// is reported until the next refresh. By default a refresh is reported
+// "refreshing" once, and then "ready".
+func (s *Server) SetSchemaRefreshStatuses(connectionID string, statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshStatuses[connectionID] = slices.Clone(statuses)
+}
+
+// BulkSyncExecutions returns the executions started for a bulk sync, in the
+// order they were started.
+func (s *Server) BulkSyncExecutions(bulkSyncID string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	executions := make([]Object, len(s.executions[bulkSyncID]))
+	for i, e := range s.executions[bulkSyncID] {
+		executions[i] = clone(e)
+	}
+	return executions
+}
+
+// SetBulkDestination sets the bulk sync destination metadata of a connection.
+func (s *Server) SetBulkDestination(connectionID string, destination Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bulkDestinations[connectionID] = clone(destination)
+}
+
+// SetModelPreview sets what previewing a model of a connection returns: the
+// model's "fields" and suggested "identifier", and the "samples" rows.
+func (s *Server) SetModelPreview(connectionID string, preview Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.modelPreviews[connectionID] = clone(preview)
+}
+
+// SetOAuthType makes a connection type authorized with OAuth: connections of
+// the type are created unhealthy until they're authorized.
+func (s *Server) SetOAuthType(typ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.oauthTypes[typ] = true
+}
+
+// Authorize completes the OAuth flow of a connection, making it healthy.
+func (s *Server) Authorize(connectionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if conn, ok := s.collections[Connections].objects[connectionID]; ok {
+		conn["status"] = "healthy"
+	}
+}
+
+// ConnectLinks returns the number of connect links created for a connection.
+func (s *Server) ConnectLinks(connectionID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, id := range s.connectTokens {
+		if id == connectionID {
+			n++
+		}
+	}
+	return n
+}
+
+// DeleteAfterList makes an object appear to be deleted after it's listed:
+// it's still included in list responses, but requests for it by ID return
+// not found.
+func (s *Server) DeleteAfterList(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deletedAfterList[id] = true
+}
+
+// ModelSamples returns the number of model samples requested for a
+// connection.
+func (s *Server) ModelSamples(connectionID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.modelSamples[connectionID]
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/me", s.handleIdentity)
+
+	mux.HandleFunc("GET /api/organizations", s.handleListOrganizations)
+	mux.HandleFunc("POST /api/organizations", s.handleCreateOrganization)
+	for _, method := range []string{"GET", "PUT", "PATCH", "DELETE"} {
+		mux.HandleFunc(method+" /api/organizations/{id}", s.handleOrganization)
+	}
+
+	mux.HandleFunc("POST /api/connections/connect", s.handleConnect)
+	s.handleCollection(mux, "/api/connections", Connections)
+	mux.HandleFunc("POST /api/models/preview", s.handlePreviewModel)
+	mux.HandleFunc("POST /api/models/sample", s.handleSampleModel)
+	s.handleCollection(mux, "/api/models", Models)
+	s.handleCollection(mux, "/api/syncs", Syncs)
+	s.handleCollection(mux, "/api/bulk/syncs", BulkSyncs)
+	s.handleCollection(mux, "/api/organizations/{org_id}/users", Users)
+	s.handleCollection(mux, "/api/permissions/roles", Roles)
+	s.handleCollection(mux, "/api/permissions/policies", Policies)
+
+	mux.HandleFunc("GET /api/connection_types", s.handleConnectionTypes)
+	mux.HandleFunc("GET /api/connections/{id}/schemas", s.handleListSchemas)
+	mux.HandleFunc("POST /api/connections/{id}/schemas/refresh", s.handleRefreshSchemas)
+	mux.HandleFunc("GET /api/connections/{id}/schemas/status", s.handleSchemaStatus)
+	mux.HandleFunc("GET /api/connections/{id}/schemas/{schema_id}", s.handleGetSchema)
+	mux.HandleFunc("PUT /api/connections/{id}/schemas/{schema_id}/primary_keys", s.handleSetPrimaryKeys)
+	mux.HandleFunc("DELETE /api/connections/{id}/schemas/{schema_id}/primary_keys", s.handleResetPrimaryKeys)
+	mux.HandleFunc("GET /api/connections/{id}/modelsync/target/fields", s.handleTargetFields)
+
+	mux.HandleFunc("GET /api/bulk/syncs/{id}/schemas", s.handleBulkSyncSchemas)
+	mux.HandleFunc("GET /api/bulk/syncs/{id}/schemas/{schema_id}", s.handleGetBulkSyncSchema)
+	mux.HandleFunc("PUT /api/bulk/syncs/{id}/schemas/{schema_id}", s.handleUpdateBulkSyncSchema)
+	mux.HandleFunc("POST /api/bulk/syncs/{id}/executions", s.handleStartBulkSync)
+	mux.HandleFunc("GET /api/bulk/syncs/{id}/executions/{exec_id}", s.handleGetBulkSyncExecution)
+	mux.HandleFunc("GET /api/bulk/source/{id}", s.handleBulkMetadata(func() map[string]Object { return s.bulkSources }))
+	mux.HandleFunc("GET /api/bulk/dest/{id}", s.handleBulkMetadata(func() map[string]Object { return s.bulkDestinations }))
+
+	mux.HandleFunc("GET /api/notifications/global-errors-subscribers", s.handleGetSubscribers)
+	mux.HandleFunc("PUT /api/notifications/global-errors-subscribers", s.handleSetSubscribers)
+
+	return s.authenticate(mux)
+}
+
+// caller is the authenticated client of a request.
+type caller struct {
+	// organization is empty for partner requests which aren't scoped to an
+	// organization.
+	organization string
+	partner      bool
+}
+
+// canAccess reports whether the caller has access to organization.
+func (c caller) canAccess(organization string) bool {
+	return c.partner || c.organization == organization
+}
+
+type callerKey struct{}
+
+func withCaller(ctx context.Context, c caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+func callerFrom(r *http.Request) caller {
+	c, _ := r.Context().Value(callerKey{}).(caller)
+	return c
+}
+
+// authenticate identifies the caller from a bearer API or partner key, or
+// basic authentication with an organization ID and partner key.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var c caller
+		auth := r.Header.Get("Authorization")
+		switch {
+		case auth == "Bearer "+s.APIKey:
+			c = caller{organization: s.Organization}
+		case auth == "Bearer "+s.PartnerKey:
+			c = caller{partner: true}
+		case strings.HasPrefix(auth, "Basic "):
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "Basic "))
+			org, key, _ := strings.Cut(string(decoded), ":")
+			if err != nil || key != s.PartnerKey {
+				writeError(w, http.StatusUnauthorized, "invalid credentials", nil)
+				return
+			}
+			c = caller{organization: org, partner: true}
+		default:
+			writeError(w, http.StatusUnauthorized, "invalid credentials", nil)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(withCaller(r.Context(), c)))
+	})
+}
+
+func (s *Server) handleIdentity(w http.ResponseWriter, r *http.Request) {
+	c := callerFrom(r)
+	if c.organization == "" {
+		writeError(w, http.StatusForbidden, "organization is required", nil)
+		return
+	}
+
+	s.mu.Lock()
+	org := s.collections[Organizations].objects[c.organization]
+	s.mu.Unlock()
+	writeData(w, http.StatusOK, Object{
+		"id":                    "fake-user",
+		"email":                 "fake@example.com",
+		"organization_id":       c.organization,
+		"organization_name":     org["name"],
+		"is_organization_admin": true,
+		"is_partner":            c.partner,
+	})
+}
+
+func (s *Server) handleListOrganizations(w http.ResponseWriter, r *http.Request) {
+	if !callerFrom(r).partner {
+		writeError(w, http.StatusForbidden, "a partner key is required", nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeData(w, http.StatusOK, s.collections[Organizations].list(func(Object) bool { return true }))
+}
+
+func (s *Server) handleCreateOrganization(w http.ResponseWriter, r *http.Request) {
+	if !callerFrom(r).partner {
+		writeError(w, http.StatusForbidden, "a partner key is required", nil)
+		return
+	}
+	obj, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+	if missing := missingFields(obj, "name"); len(missing) > 0 {
+		writeValidationError(w, missing)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj["id"] = uuid.NewString()
+	s.collections[Organizations].put(obj)
+	writeData(w, http.StatusOK, obj)
+}
+
+func (s *Server) handleOrganization(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !callerFrom(r).canAccess(id) {
+		writeNotFound(w, "organization")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orgs := s.collections[Organizations]
+	obj, ok := orgs.objects[id]
+	if !ok {
+		writeNotFound(w, "organization")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, obj)
+	case http.MethodPut, http.MethodPatch:
+		update, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		maps.Copy(obj, update)
+		obj["id"] = id
+		writeData(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		orgs.remove(id)
+		for _, c := range s.collections {
+			for objID, o := range c.objects {
+				if o["organization_id"] == id {
+					c.remove(objID)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleConnectionTypes(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := map[string]bool{}
+	types := []Object{}
+	for _, conn := range s.collections[Connections].list(func(Object) bool { return true }) {
+		typ, _ := conn["type"].(Object)
+		if id, _ := typ["id"].(string); id != "" && !seen[id] {
+			seen[id] = true
+			types = append(types, typ)
+		}
+	}
+	writeData(w, http.StatusOK, types)
+}
+
+func (s *Server) handleGetSubscribers(w http.ResponseWriter, r *http.Request) {
+	c := callerFrom(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, Object{"emails": emails(s.subscribers[c.organization])})
+}
+
+func (s *Server) handleSetSubscribers(w http.ResponseWriter, r *http.Request) {
+	c := callerFrom(r)
+	var body struct {
+		Emails []string `json:"emails"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err), nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers[c.organization] = body.Emails
+	writeJSON(w, http.StatusOK, Object{"emails": emails(body.Emails)})
+}
+
+// emails returns a non-nil list of emails, so it is encoded as an array.
+func emails(e []string) []string {
+	if e == nil {
+		return []string{}
+	}
+	return e
+}
+
+// readObject decodes a JSON object from the request body, writing an error
+// response if it isn't valid.
+func readObject(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	obj := Object{}
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err), nil)
+		return nil, false
+	}
+	return obj, true
+}
+
+// writeData writes a response with the data envelope used by most of the
+// Polytomic API.
+func writeData(w http.ResponseWriter, status int, data any) {
+	writeJSON(w, status, Object{"data": data})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error response in the shape used by the Polytomic API.
+func writeError(w http.ResponseWriter, status int, message string, metadata Object) {
+	body := Object{
+		"status":  status,
+		"message": message,
+	}
+	if metadata != nil {
+		body["metadata"] = metadata
+	}
+	writeJSON(w, status, body)
+}
+
+func writeNotFound(w http.ResponseWriter, noun string) {
+	writeError(w, http.StatusNotFound, noun+" not found", nil)
+}
+
+func writeValidationError(w http.ResponseWriter, missing []string) {
+	fields := Object{}
+	for _, f := range missing {
+		fields[f] = "is required"
+	}
+	writeError(w, http.StatusUnprocessableEntity,
+		fmt.Sprintf("validation failed: %s is required", strings.Join(missing, ", ")),
+		Object{"fields": fields})
+}
+
+// missingFields returns the fields which are absent or empty in obj.
+func missingFields(obj Object, fields ...string) []string {
+	var missing []string
+	for _, f := range fields {
+		if v, ok := obj[f]; !ok || v == nil || v == "" {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// clone returns a deep copy of a JSON object.
+func clone(obj Object) Object {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+	var out Object
+	if err := json.Unmarshal(b, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
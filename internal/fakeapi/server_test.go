@@ -0,0 +1,440 @@
+package fakeapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// do makes a request to the fake with the API key, returning the status and
+// decoded response body.
+func do(t *testing.T, s *Server, method, path string, body any) (int, Object) {
+	t.Helper()
+	return doAuth(t, s, "Bearer "+s.APIKey, method, path, body)
+}
+
+func doAuth(t *testing.T, s *Server, auth, method, path string, body any) (int, Object) {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+	req, err := http.NewRequest(method, s.URL+path, &reqBody)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", auth)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var out Object
+	if resp.StatusCode != http.StatusNoContent {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	}
+	return resp.StatusCode, out
+}
+
+func TestConnectionLifecycle(t *testing.T) {
+	s := New(t)
+
+	status, body := do(t, s, "POST", "/api/connections", Object{
+		"name": "Warehouse",
+		"type": "postgresql",
+		"configuration": Object{
+			"hostname": "db.example.com",
+			"password": "secret",
+		},
+	})
+	require.Equal(t, http.StatusOK, status)
+	created := body["data"].(Object)
+	id := created["id"].(string)
+	assert.Equal(t, s.Organization, created["organization_id"])
+	assert.Equal(t, "postgresql", created["type"].(Object)["id"])
+	assert.Equal(t, Object{"hostname": "db.example.com"}, created["configuration"],
+		"sensitive values are masked")
+
+	stored, ok := s.Get(Connections, id)
+	require.True(t, ok)
+	assert.Equal(t, "secret", stored["configuration"].(Object)["password"])
+
+	// Omitted sensitive values are unchanged by an update.
+	status, body = do(t, s, "PUT", "/api/connections/"+id, Object{
+		"name":          "Warehouse 2",
+		"configuration": Object{"hostname": "db2.example.com"},
+	})
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Warehouse 2", body["data"].(Object)["name"])
+	assert.Equal(t, "postgresql", body["data"].(Object)["type"].(Object)["id"])
+	stored, _ = s.Get(Connections, id)
+	assert.Equal(t, "secret", stored["configuration"].(Object)["password"])
+
+	status, body = do(t, s, "GET", "/api/connections", nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, body["data"], 1)
+
+	status, _ = do(t, s, "DELETE", "/api/connections/"+id, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	status, body = do(t, s, "GET", "/api/connections/"+id, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, float64(http.StatusNotFound), body["status"])
+	assert.Equal(t, "connection not found", body["message"])
+}
+
+func TestOAuthConnection(t *testing.T) {
+	s := New(t)
+	s.SetOAuthType("airtable")
+
+	status, body := do(t, s, "POST", "/api/connections", Object{"name": "Bases", "type": "airtable"})
+	require.Equal(t, http.StatusOK, status)
+	id := body["data"].(Object)["id"].(string)
+	assert.Equal(t, "unhealthy", body["data"].(Object)["status"])
+
+	status, body = do(t, s, "POST", "/api/connections/connect", Object{
+		"connection_id": id,
+		"name":          "Bases",
+		"redirect_url":  "https://example.com/done",
+	})
+	require.Equal(t, http.StatusOK, status)
+	assert.NotEmpty(t, body["data"].(Object)["redirect_url"])
+	assert.Equal(t, 1, s.ConnectLinks(id))
+
+	s.Authorize(id)
+	_, body = do(t, s, "GET", "/api/connections/"+id, nil)
+	assert.Equal(t, "healthy", body["data"].(Object)["status"])
+
+	status, _ = do(t, s, "POST", "/api/connections/connect", Object{
+		"connection_id": "missing",
+		"name":          "Bases",
+		"redirect_url":  "https://example.com/done",
+	})
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestDeleteAfterList(t *testing.T) {
+	s := New(t)
+	id := s.Put(Roles, Object{"name": "Analysts"})
+	s.DeleteAfterList(id)
+
+	status, body := do(t, s, "GET", "/api/permissions/roles", nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, body["data"], 1)
+
+	status, _ = do(t, s, "GET", "/api/permissions/roles/"+id, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestValidationError(t *testing.T) {
+	s := New(t)
+
+	status, body := do(t, s, "POST", "/api/models", Object{"name": "Users"})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, float64(http.StatusUnprocessableEntity), body["status"])
+	assert.Contains(t, body["message"], "connection_id is required")
+	assert.Equal(t, Object{"connection_id": "is required"}, body["metadata"].(Object)["fields"])
+}
+
+func TestConnectionInUse(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "POST", "/api/connections", Object{"name": "Warehouse", "type": "postgresql"})
+	connID := body["data"].(Object)["id"].(string)
+	_, body = do(t, s, "POST", "/api/models", Object{"name": "Users", "connection_id": connID})
+	modelID := body["data"].(Object)["id"].(string)
+
+	status, body := do(t, s, "DELETE", "/api/connections/"+connID, nil)
+	require.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "connection in use", body["message"])
+	assert.Equal(t, []any{
+		Object{"type": "model", "id": modelID, "name": "Users"},
+	}, body["metadata"].(Object)["used_by"])
+
+	status, _ = do(t, s, "DELETE", "/api/connections/"+connID+"?force=true", nil)
+	assert.Equal(t, http.StatusNoContent, status)
+}
+
+func TestOrganizationScope(t *testing.T) {
+	s := New(t)
+
+	partner := "Bearer " + s.PartnerKey
+	status, body := doAuth(t, s, partner, "POST", "/api/organizations", Object{"name": "Other"})
+	require.Equal(t, http.StatusOK, status)
+	otherOrg := body["data"].(Object)["id"].(string)
+
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte(otherOrg+":"+s.PartnerKey))
+	status, body = doAuth(t, s, basic, "POST", "/api/permissions/roles", Object{"name": "Analyst"})
+	require.Equal(t, http.StatusOK, status)
+	roleID := body["data"].(Object)["id"].(string)
+	assert.Equal(t, otherOrg, body["data"].(Object)["organization_id"])
+	assert.Equal(t, false, body["data"].(Object)["system"])
+
+	// Objects in other organizations aren't visible with the API key.
+	status, _ = do(t, s, "GET", "/api/permissions/roles/"+roleID, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	_, body = do(t, s, "GET", "/api/permissions/roles", nil)
+	assert.Empty(t, body["data"])
+
+	status, _ = do(t, s, "GET", "/api/organizations", nil)
+	assert.Equal(t, http.StatusForbidden, status, "listing organizations requires a partner key")
+
+	status, _ = doAuth(t, s, "Bearer wrong", "GET", "/api/me", nil)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestUsers(t *testing.T) {
+	s := New(t)
+
+	path := "/api/organizations/" + s.Organization + "/users"
+	status, body := do(t, s, "POST", path, Object{"email": "user@example.com", "role": "user"})
+	require.Equal(t, http.StatusOK, status)
+	id := body["data"].(Object)["id"].(string)
+
+	status, body = do(t, s, "PATCH", path+"/"+id, Object{"role": "admin"})
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "user@example.com", body["data"].(Object)["email"], "PATCH preserves omitted fields")
+	assert.Equal(t, "admin", body["data"].(Object)["role"])
+
+	status, _ = do(t, s, "GET", "/api/organizations/00000000-0000-0000-0000-000000000000/users", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestSchemas(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "POST", "/api/connections", Object{"name": "Warehouse", "type": "postgresql"})
+	connID := body["data"].(Object)["id"].(string)
+	s.AddSchema(connID, Object{
+		"id":     "public.users",
+		"fields": []any{Object{"id": "id"}, Object{"id": "email"}},
+	})
+
+	path := "/api/connections/" + connID + "/schemas/public.users"
+	status, _ := do(t, s, "PUT", path+"/primary_keys", Object{
+		"fields": []any{Object{"field_id": "email", "is_primary_key": true}},
+	})
+	require.Equal(t, http.StatusNoContent, status)
+
+	_, body = do(t, s, "GET", path, nil)
+	fields := body["data"].(Object)["fields"].([]any)
+	assert.Equal(t, true, fields[1].(Object)["is_primary_key"])
+
+	status, _ = do(t, s, "PUT", path+"/primary_keys", Object{
+		"fields": []any{Object{"field_id": "missing", "is_primary_key": true}},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	status, _ = do(t, s, "GET", "/api/connections/"+connID+"/schemas/missing", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestTargetFields(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "POST", "/api/connections", Object{"name": "CRM", "type": "salesforce"})
+	connID := body["data"].(Object)["id"].(string)
+	s.SetTargetFields(connID, "Contact", Object{"id": "Email", "name": "Email"})
+
+	path := "/api/connections/" + connID + "/modelsync/target/fields"
+	_, body = do(t, s, "GET", path+"?target=Contact", nil)
+	assert.Equal(t, Object{"fields": []any{Object{"id": "Email", "name": "Email"}}}, body["data"])
+
+	status, _ := do(t, s, "GET", path+"?target=Lead", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestSchemaRefresh(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "POST", "/api/connections", Object{"name": "Warehouse", "type": "postgresql"})
+	connID := body["data"].(Object)["id"].(string)
+	path := "/api/connections/" + connID + "/schemas"
+
+	_, body = do(t, s, "GET", path+"/status", nil)
+	assert.Equal(t, "ready", body["data"].(Object)["cache_status"])
+
+	status, _ := do(t, s, "POST", path+"/refresh", nil)
+	require.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, 1, s.SchemaRefreshes(connID))
+
+	_, body = do(t, s, "GET", path+"/status", nil)
+	assert.Equal(t, "refreshing", body["data"].(Object)["cache_status"])
+	_, body = do(t, s, "GET", path+"/status", nil)
+	assert.Equal(t, "ready", body["data"].(Object)["cache_status"])
+
+	status, _ = do(t, s, "POST", "/api/connections/missing/schemas/refresh", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	s.SetSchemaRefreshStatuses(connID, "ready", "refreshing", "error")
+	status, _ = do(t, s, "POST", path+"/refresh", nil)
+	require.Equal(t, http.StatusNoContent, status)
+	for _, expected := range []string{"ready", "refreshing", "error", "error"} {
+		_, body = do(t, s, "GET", path+"/status", nil)
+		assert.Equal(t, expected, body["data"].(Object)["cache_status"])
+	}
+}
+
+func TestBulkSyncExecutions(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "POST", "/api/bulk/syncs", Object{
+		"name":                      "Warehouse",
+		"source_connection_id":      "source",
+		"destination_connection_id": "destination",
+	})
+	bulkID := body["data"].(Object)["id"].(string)
+	path := "/api/bulk/syncs/" + bulkID + "/executions"
+
+	_, body = do(t, s, "POST", path, Object{"resync": true, "schemas": []any{"public.users"}})
+	execution := body["data"].(Object)
+	assert.Equal(t, "created", execution["status"])
+	assert.Equal(t, true, execution["resync"])
+
+	for _, want := range []string{"running", "completed", "completed"} {
+		_, body = do(t, s, "GET", path+"/"+execution["id"].(string), nil)
+		assert.Equal(t, want, body["data"].(Object)["status"])
+	}
+	executions := s.BulkSyncExecutions(bulkID)
+	require.Len(t, executions, 1)
+	assert.Equal(t, []any{"public.users"}, executions[0]["schemas"])
+
+	status, _ := do(t, s, "GET", path+"/missing", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = do(t, s, "POST", "/api/bulk/syncs/missing/executions", Object{})
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestBulkSyncSchemas(t *testing.T) {
+	s := New(t)
+	s.SetBulkSource("source", Object{"schemas": []any{
+		Object{"id": "public.users"},
+		Object{"id": "public.orders"},
+	}})
+
+	_, body := do(t, s, "POST", "/api/bulk/syncs", Object{
+		"name":                      "Warehouse",
+		"source_connection_id":      "source",
+		"destination_connection_id": "destination",
+		"schemas":                   []any{"public.users"},
+	})
+	bulkID := body["data"].(Object)["id"].(string)
+	path := "/api/bulk/syncs/" + bulkID + "/schemas"
+
+	_, body = do(t, s, "GET", path+"/public.users", nil)
+	assert.Equal(t, Object{"id": "public.users", "enabled": true}, body["data"])
+
+	_, body = do(t, s, "PUT", path+"/public.users", Object{"tracking_field": "updated_at"})
+	assert.Equal(t, "updated_at", body["data"].(Object)["tracking_field"])
+	assert.Equal(t, true, body["data"].(Object)["enabled"])
+
+	// schemas of the source can be added
+	status, _ := do(t, s, "PUT", path+"/public.orders", Object{"enabled": false})
+	require.Equal(t, http.StatusOK, status)
+	_, body = do(t, s, "GET", path, nil)
+	assert.Len(t, body["data"], 2)
+
+	status, _ = do(t, s, "PUT", path+"/public.missing", Object{"enabled": true})
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = do(t, s, "GET", path+"/public.missing", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// patching the bulk sync leaves its schemas unchanged
+	status, _ = do(t, s, "PATCH", "/api/bulk/syncs/"+bulkID, Object{"name": "Lake"})
+	require.Equal(t, http.StatusOK, status)
+	_, body = do(t, s, "GET", path+"/public.users", nil)
+	assert.Equal(t, "updated_at", body["data"].(Object)["tracking_field"])
+
+	// updating it without schemas selects all of the source's schemas
+	status, _ = do(t, s, "PUT", "/api/bulk/syncs/"+bulkID, Object{
+		"name":                      "Warehouse",
+		"source_connection_id":      "source",
+		"destination_connection_id": "destination",
+	})
+	require.Equal(t, http.StatusOK, status)
+	_, body = do(t, s, "GET", path, nil)
+	assert.Equal(t, []any{
+		Object{"id": "public.users", "enabled": true},
+		Object{"id": "public.orders", "enabled": true},
+	}, body["data"])
+}
+
+func TestGlobalErrorSubscribers(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "GET", "/api/notifications/global-errors-subscribers", nil)
+	assert.Equal(t, []any{}, body["emails"])
+
+	status, _ := do(t, s, "PUT", "/api/notifications/global-errors-subscribers", Object{
+		"emails": []string{"ops@example.com"},
+	})
+	require.Equal(t, http.StatusOK, status)
+	_, body = do(t, s, "GET", "/api/notifications/global-errors-subscribers", nil)
+	assert.Equal(t, []any{"ops@example.com"}, body["emails"])
+}
+
+func TestModelPreview(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "POST", "/api/connections", Object{"name": "Warehouse", "type": "postgresql"})
+	connID := body["data"].(Object)["id"].(string)
+	model := Object{"connection_id": connID, "configuration": Object{"table": "users"}}
+
+	status, _ := do(t, s, "POST", "/api/models/preview", model)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	s.SetModelPreview(connID, Object{
+		"fields":     []any{Object{"name": "id", "type": "integer"}},
+		"identifier": "id",
+		"samples":    []any{Object{"id": 1}, Object{"id": 2}, Object{"id": 3}},
+	})
+	status, body = do(t, s, "POST", "/api/models/preview", model)
+	require.Equal(t, http.StatusOK, status)
+	preview := body["data"].(Object)
+	assert.Equal(t, "id", preview["identifier"])
+	assert.Equal(t, []any{Object{"name": "id", "type": "integer"}}, preview["fields"])
+	assert.Equal(t, Object{"table": "users"}, preview["configuration"])
+
+	status, body = do(t, s, "POST", "/api/models/sample?limit=2", model)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []any{Object{"id": float64(1)}, Object{"id": float64(2)}}, body["data"])
+	assert.Equal(t, 1, s.ModelSamples(connID))
+
+	status, _ = do(t, s, "POST", "/api/models/preview", Object{"connection_id": "missing", "configuration": Object{}})
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = do(t, s, "POST", "/api/models/preview", Object{"connection_id": connID})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+}
+
+func TestModelFields(t *testing.T) {
+	s := New(t)
+
+	_, body := do(t, s, "POST", "/api/models", Object{
+		"name":              "Users",
+		"connection_id":     "warehouse",
+		"fields":            []any{"id", "email"},
+		"additional_fields": []any{Object{"name": "score", "type": "number", "label": "Score"}},
+	})
+	model := body["data"].(Object)
+	assert.Equal(t, []any{
+		Object{"name": "id", "type": "string", "label": "id"},
+		Object{"name": "email", "type": "string", "label": "email"},
+		Object{"name": "score", "type": "number", "label": "Score", "user_added": true},
+	}, model["fields"])
+
+	s.Put(Models, Object{"id": model["id"], "name": "Users", "connection_id": "warehouse", "fields": []any{
+		Object{"name": "id", "type": "integer", "label": "ID"},
+	}})
+	_, body = do(t, s, "PUT", "/api/models/"+model["id"].(string), Object{
+		"name":          "Users",
+		"connection_id": "warehouse",
+		"fields":        []any{"id"},
+		"relations":     []any{Object{"from": "id", "to": Object{"model_id": "accounts", "field": "user_id"}}},
+	})
+	model = body["data"].(Object)
+	assert.Equal(t, []any{Object{"name": "id", "type": "integer", "label": "ID"}}, model["fields"])
+	assert.Len(t, model["relations"], 1)
+}
@@ -0,0 +1,108 @@
+// Package listresource implements Terraform list resources for Polytomic
+// resources, which are listed by organization and identified by their
+// organization and ID.
+package listresource
+
+import (
+	"context"
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
+)
+
+// ConfigSchema is the configuration schema of Polytomic list resources.
+var ConfigSchema = listschema.Schema{
+	Attributes: map[string]listschema.Attribute{
+		"organization": listschema.StringAttribute{
+			Description: "Organization ID to list objects in. Defaults to the organization of the API key.",
+			Optional:    true,
+		},
+	},
+}
+
+// Config is the configuration of a Polytomic list resource.
+type Config struct {
+	Organization types.String `tfsdk:"organization"`
+}
+
+// Item is an object found by a list resource.
+type Item struct {
+	Organization string
+	ID           string
+	DisplayName  string
+}
+
+// Results returns the list results for items. Items without an organization
+// use the organization from the list configuration, if any. When the request
+// includes resource data, each item is read with the resource's Read method,
+// exactly as if it had been imported; items which no longer exist are
+// omitted, and don't count toward the request's limit.
+func Results(ctx context.Context, req list.ListRequest, r resource.Resource, config Config, items []Item) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		var pushed int64
+		for _, item := range items {
+			if req.Limit > 0 && pushed >= req.Limit {
+				return
+			}
+			if item.Organization == "" {
+				item.Organization = config.Organization.ValueString()
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = item.DisplayName
+			result.Diagnostics.Append(resourceidentity.Set(ctx, result.Identity,
+				types.StringValue(item.Organization), types.StringValue(item.ID))...)
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				if !read(ctx, r, item, &result) {
+					continue
+				}
+			}
+			if !push(result) {
+				return
+			}
+			pushed++
+		}
+	}
+}
+
+// Error returns list results reporting a single error.
+func Error(summary, detail string) iter.Seq[list.ListResult] {
+	var diags diag.Diagnostics
+	diags.AddError(summary, detail)
+	return list.ListResultsStreamDiagnostics(diags)
+}
+
+// read populates the resource data of result by reading item, returning false
+// if the item no longer exists.
+func read(ctx context.Context, r resource.Resource, item Item, result *list.ListResult) bool {
+	state := tfsdk.State{
+		Schema: result.Resource.Schema,
+		Raw:    result.Resource.Raw,
+	}
+	result.Diagnostics.Append(state.SetAttribute(ctx, path.Root("id"), item.ID)...)
+	if item.Organization != "" {
+		result.Diagnostics.Append(state.SetAttribute(ctx, path.Root("organization"), item.Organization)...)
+	}
+	if result.Diagnostics.HasError() {
+		return true
+	}
+
+	resp := resource.ReadResponse{
+		State:    state,
+		Identity: result.Identity,
+	}
+	r.Read(ctx, resource.ReadRequest{State: state, Identity: result.Identity}, &resp)
+	result.Diagnostics.Append(resp.Diagnostics...)
+	if resp.State.Raw.IsNull() && !resp.Diagnostics.HasError() {
+		return false
+	}
+	result.Resource.Raw = resp.State.Raw
+	return true
+}
@@ -0,0 +1,90 @@
+// Package resourceidentity implements Terraform resource identity for
+// Polytomic resources, which are identified by their organization and ID.
+package resourceidentity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Schema is the identity schema of resources which belong to an
+// organization.
+var Schema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"organization": identityschema.StringAttribute{
+			Description:       "Organization ID",
+			OptionalForImport: true,
+		},
+		"id": identityschema.StringAttribute{
+			Description:       "Resource ID",
+			RequiredForImport: true,
+		},
+	},
+}
+
+// Model is the identity of a resource which belongs to an organization.
+type Model struct {
+	Organization types.String `tfsdk:"organization"`
+	ID           types.String `tfsdk:"id"`
+}
+
+// Set sets the identity of a resource. It is a no-op if the Terraform client
+// doesn't support resource identity.
+func Set(ctx context.Context, identity *tfsdk.ResourceIdentity, organization, id types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, Model{
+		Organization: organization,
+		ID:           id,
+	})
+}
+
+// ParseImportID parses an import ID in the format org_id/id or id. The
+// organization is empty if the ID doesn't include one.
+func ParseImportID(importID string) (string, string, error) {
+	parts := strings.Split(importID, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return "", parts[0], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("expected import ID in format: id or org_id/id, got: %s", importID)
+}
+
+// ImportState imports a resource by ID, org_id/id, or identity, setting its
+// id and organization attributes. Setting the organization allows partner
+// and deployment key clients to be resolved when the resource is read.
+func ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var data Model
+	if req.ID != "" {
+		organization, id, err := ParseImportID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		data.ID = types.StringValue(id)
+		if organization != "" {
+			data.Organization = types.StringValue(organization)
+		}
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.ID)...)
+	if data.Organization.ValueString() != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), data.Organization)...)
+	}
+}
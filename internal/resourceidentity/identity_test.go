@@ -0,0 +1,63 @@
+package resourceidentity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportID(t *testing.T) {
+	tests := []struct {
+		name         string
+		importID     string
+		organization string
+		id           string
+		wantErr      bool
+	}{
+		{
+			name:     "id only",
+			importID: "3ed9a1b2-5b8c-4c4e-9a55-7b1c2f1d0a11",
+			id:       "3ed9a1b2-5b8c-4c4e-9a55-7b1c2f1d0a11",
+		},
+		{
+			name:         "organization and id",
+			importID:     "0d5c6a3e-1f2b-4d7e-8a9b-c0d1e2f3a4b5/3ed9a1b2-5b8c-4c4e-9a55-7b1c2f1d0a11",
+			organization: "0d5c6a3e-1f2b-4d7e-8a9b-c0d1e2f3a4b5",
+			id:           "3ed9a1b2-5b8c-4c4e-9a55-7b1c2f1d0a11",
+		},
+		{
+			name:     "empty",
+			importID: "",
+			wantErr:  true,
+		},
+		{
+			name:     "empty organization",
+			importID: "/3ed9a1b2",
+			wantErr:  true,
+		},
+		{
+			name:     "empty id",
+			importID: "0d5c6a3e/",
+			wantErr:  true,
+		},
+		{
+			name:     "too many parts",
+			importID: "a/b/c",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			organization, id, err := ParseImportID(tt.importID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.organization, organization)
+			assert.Equal(t, tt.id, id)
+		})
+	}
+}
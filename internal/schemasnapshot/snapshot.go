@@ -0,0 +1,291 @@
+// Package schemasnapshot serializes a provider's schema so changes to it can
+// be reviewed, and reports the changes which break existing configuration or
+// state.
+package schemasnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Snapshot is the schema of a provider's configuration, resources and data
+// sources.
+type Snapshot struct {
+	Provider    Schema            `json:"provider"`
+	Resources   map[string]Schema `json:"resources"`
+	DataSources map[string]Schema `json:"data_sources"`
+}
+
+// Schema is the version and attributes of a schema. Attributes are keyed by
+// their path; nested attributes are separated by a dot.
+type Schema struct {
+	Version    int64                `json:"version"`
+	Attributes map[string]Attribute `json:"attributes"`
+}
+
+// Attribute is the type and flags of an attribute or block.
+type Attribute struct {
+	// Type is the attribute's Terraform type, or the nesting mode of a
+	// nested attribute or block, such as "nested_list" or "block_set".
+	Type      string `json:"type"`
+	Required  bool   `json:"required,omitempty"`
+	Optional  bool   `json:"optional,omitempty"`
+	Computed  bool   `json:"computed,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	WriteOnly bool   `json:"write_only,omitempty"`
+}
+
+// configurable reports whether the attribute can be set in configuration.
+func (a Attribute) configurable() bool {
+	return a.Required || a.Optional
+}
+
+// FromProvider returns the snapshot of a provider server's schema.
+func FromProvider(ctx context.Context, server tfprotov6.ProviderServer) (*Snapshot, error) {
+	resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return nil, fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	s := &Snapshot{
+		Provider:    fromSchema(resp.Provider),
+		Resources:   map[string]Schema{},
+		DataSources: map[string]Schema{},
+	}
+	for name, schema := range resp.ResourceSchemas {
+		s.Resources[name] = fromSchema(schema)
+	}
+	for name, schema := range resp.DataSourceSchemas {
+		s.DataSources[name] = fromSchema(schema)
+	}
+	return s, nil
+}
+
+func fromSchema(schema *tfprotov6.Schema) Schema {
+	s := Schema{Attributes: map[string]Attribute{}}
+	if schema == nil {
+		return s
+	}
+	s.Version = schema.Version
+	addBlock(s.Attributes, "", schema.Block)
+	return s
+}
+
+func addBlock(attrs map[string]Attribute, prefix string, block *tfprotov6.SchemaBlock) {
+	if block == nil {
+		return
+	}
+	for _, a := range block.Attributes {
+		addAttribute(attrs, prefix+a.Name, a)
+	}
+	for _, b := range block.BlockTypes {
+		attrs[prefix+b.TypeName] = Attribute{Type: blockNesting(b.Nesting)}
+		addBlock(attrs, prefix+b.TypeName+".", b.Block)
+	}
+}
+
+func addAttribute(attrs map[string]Attribute, path string, a *tfprotov6.SchemaAttribute) {
+	attr := Attribute{
+		Required:  a.Required,
+		Optional:  a.Optional,
+		Computed:  a.Computed,
+		Sensitive: a.Sensitive,
+		WriteOnly: a.WriteOnly,
+	}
+	if a.NestedType != nil {
+		attr.Type = objectNesting(a.NestedType.Nesting)
+		for _, nested := range a.NestedType.Attributes {
+			addAttribute(attrs, path+"."+nested.Name, nested)
+		}
+	} else if a.Type != nil {
+		attr.Type = a.Type.String()
+	}
+	attrs[path] = attr
+}
+
+func objectNesting(mode tfprotov6.SchemaObjectNestingMode) string {
+	switch mode {
+	case tfprotov6.SchemaObjectNestingModeSingle:
+		return "nested_single"
+	case tfprotov6.SchemaObjectNestingModeList:
+		return "nested_list"
+	case tfprotov6.SchemaObjectNestingModeSet:
+		return "nested_set"
+	case tfprotov6.SchemaObjectNestingModeMap:
+		return "nested_map"
+	}
+	return "nested_invalid"
+}
+
+func blockNesting(mode tfprotov6.SchemaNestedBlockNestingMode) string {
+	switch mode {
+	case tfprotov6.SchemaNestedBlockNestingModeSingle:
+		return "block_single"
+	case tfprotov6.SchemaNestedBlockNestingModeList:
+		return "block_list"
+	case tfprotov6.SchemaNestedBlockNestingModeSet:
+		return "block_set"
+	case tfprotov6.SchemaNestedBlockNestingModeMap:
+		return "block_map"
+	case tfprotov6.SchemaNestedBlockNestingModeGroup:
+		return "block_group"
+	}
+	return "block_invalid"
+}
+
+// Load reads the snapshot at path.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("reading schema snapshot %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Write writes the snapshot to path. Keys are sorted, so the snapshot's diff
+// shows only schema changes.
+func (s *Snapshot) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Kind is the kind of schema a change was made to.
+type Kind string
+
+const (
+	KindProvider   Kind = "provider"
+	KindResource   Kind = "resource"
+	KindDataSource Kind = "data source"
+)
+
+// Change is a breaking change to a schema.
+type Change struct {
+	Kind Kind
+	// Name is the resource or data source type; it is empty for the
+	// provider's schema.
+	Name string
+	// Path is the changed attribute; it is empty if the resource or data
+	// source was removed.
+	Path   string
+	Reason string
+	// Upgraded is true if the change was made to a resource whose schema
+	// version was incremented, so existing state is upgraded.
+	Upgraded bool
+}
+
+func (c Change) String() string {
+	var s strings.Builder
+	s.WriteString(string(c.Kind))
+	if c.Name != "" {
+		s.WriteString(" " + c.Name)
+	}
+	if c.Path != "" {
+		s.WriteString(" attribute " + c.Path)
+	}
+	s.WriteString(": " + c.Reason)
+	return s.String()
+}
+
+// BreakingChanges returns the changes from old to new which break existing
+// configuration or state: removed resources, data sources and attributes,
+// attributes which become required or are no longer configurable, and changes
+// to attribute types and sensitivity. Changes are sorted by kind, name and
+// path.
+func BreakingChanges(old, new *Snapshot) []Change {
+	changes := compareSchema(KindProvider, "", old.Provider, new.Provider)
+	for name, oldSchema := range old.Resources {
+		newSchema, ok := new.Resources[name]
+		if !ok {
+			changes = append(changes, Change{Kind: KindResource, Name: name, Reason: "removed"})
+			continue
+		}
+		for _, c := range compareSchema(KindResource, name, oldSchema, newSchema) {
+			c.Upgraded = newSchema.Version > oldSchema.Version
+			changes = append(changes, c)
+		}
+	}
+	for name, oldSchema := range old.DataSources {
+		newSchema, ok := new.DataSources[name]
+		if !ok {
+			changes = append(changes, Change{Kind: KindDataSource, Name: name, Reason: "removed"})
+			continue
+		}
+		changes = append(changes, compareSchema(KindDataSource, name, oldSchema, newSchema)...)
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return strings.Compare(
+			string(a.Kind)+"\x00"+a.Name+"\x00"+a.Path,
+			string(b.Kind)+"\x00"+b.Name+"\x00"+b.Path,
+		)
+	})
+	return changes
+}
+
+func compareSchema(kind Kind, name string, old, new Schema) []Change {
+	var changes []Change
+	change := func(path, reason string, args ...any) {
+		changes = append(changes, Change{Kind: kind, Name: name, Path: path, Reason: fmt.Sprintf(reason, args...)})
+	}
+
+	for path, o := range old.Attributes {
+		n, ok := new.Attributes[path]
+		if !ok {
+			// Report only the outermost removed attribute.
+			if _, ok := new.Attributes[parent(path)]; ok || parent(path) == "" {
+				change(path, "removed")
+			}
+			continue
+		}
+		if o.Type != n.Type {
+			change(path, "type changed from %s to %s", o.Type, n.Type)
+		}
+		if !o.Required && n.Required {
+			change(path, "became required")
+		}
+		if o.configurable() && !n.configurable() {
+			change(path, "is no longer configurable")
+		}
+		if o.Sensitive != n.Sensitive {
+			change(path, "sensitive changed from %t to %t", o.Sensitive, n.Sensitive)
+		}
+	}
+	for path, n := range new.Attributes {
+		if _, ok := old.Attributes[path]; ok || !n.Required {
+			continue
+		}
+		// A required attribute of a new nested attribute only applies when
+		// the new attribute is set.
+		if _, ok := old.Attributes[parent(path)]; ok || parent(path) == "" {
+			change(path, "added as a required attribute")
+		}
+	}
+	return changes
+}
+
+// parent returns the path of the attribute containing path, or "" for a top
+// level attribute.
+func parent(path string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package schemasnapshot
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSchema(t *testing.T) {
+	schema := fromSchema(&tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{Name: "id", Type: tftypes.String, Computed: true},
+				{
+					Name:     "configuration",
+					Optional: true,
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{Name: "port", Type: tftypes.Number, Optional: true},
+							{Name: "password", Type: tftypes.String, Optional: true, Sensitive: true},
+						},
+					},
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "filter",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{Name: "values", Type: tftypes.List{ElementType: tftypes.String}, Required: true},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, Schema{
+		Version: 1,
+		Attributes: map[string]Attribute{
+			"id":                     {Type: "tftypes.String", Computed: true},
+			"configuration":          {Type: "nested_single", Optional: true},
+			"configuration.port":     {Type: "tftypes.Number", Optional: true},
+			"configuration.password": {Type: "tftypes.String", Optional: true, Sensitive: true},
+			"filter":                 {Type: "block_list"},
+			"filter.values":          {Type: "tftypes.List[tftypes.String]", Required: true},
+		},
+	}, schema)
+}
+
+func TestBreakingChanges(t *testing.T) {
+	old := &Snapshot{
+		Resources: map[string]Schema{
+			"polytomic_postgresql_connection": {
+				Version: 0,
+				Attributes: map[string]Attribute{
+					"name":                   {Type: "tftypes.String", Required: true},
+					"configuration":          {Type: "nested_single", Optional: true},
+					"configuration.port":     {Type: "tftypes.Number", Optional: true},
+					"configuration.database": {Type: "tftypes.String", Optional: true},
+					"configuration.password": {Type: "tftypes.String", Optional: true, Sensitive: true},
+					"configuration.ssh":      {Type: "nested_single", Optional: true},
+					"configuration.ssh.host": {Type: "tftypes.String", Optional: true},
+				},
+			},
+			"polytomic_model": {
+				Version: 0,
+				Attributes: map[string]Attribute{
+					"name":   {Type: "tftypes.String", Required: true},
+					"query":  {Type: "tftypes.String", Optional: true},
+					"labels": {Type: "tftypes.Set[tftypes.String]", Optional: true},
+				},
+			},
+			"polytomic_removed": {},
+		},
+		DataSources: map[string]Schema{
+			"polytomic_caller_identity": {
+				Attributes: map[string]Attribute{
+					"id":   {Type: "tftypes.String", Computed: true},
+					"name": {Type: "tftypes.String", Computed: true},
+				},
+			},
+		},
+	}
+	new := &Snapshot{
+		Resources: map[string]Schema{
+			"polytomic_postgresql_connection": {
+				Version: 1,
+				Attributes: map[string]Attribute{
+					"name":                   {Type: "tftypes.String", Required: true},
+					"configuration":          {Type: "nested_single", Optional: true},
+					"configuration.port":     {Type: "tftypes.Number", Optional: true},
+					"configuration.database": {Type: "tftypes.String", Required: true},
+					"configuration.password": {Type: "tftypes.String", Optional: true},
+				},
+			},
+			"polytomic_model": {
+				Version: 0,
+				Attributes: map[string]Attribute{
+					"name":           {Type: "tftypes.String", Required: true},
+					"query":          {Type: "tftypes.String", Computed: true},
+					"labels":         {Type: "tftypes.List[tftypes.String]", Optional: true},
+					"connection_id":  {Type: "tftypes.String", Required: true},
+					"relations":      {Type: "nested_list", Optional: true},
+					"relations.to":   {Type: "tftypes.String", Required: true},
+					"identifier":     {Type: "tftypes.String", Optional: true},
+					"refresh_period": {Type: "tftypes.Number", Optional: true, Computed: true},
+				},
+			},
+		},
+		DataSources: map[string]Schema{
+			"polytomic_caller_identity": {
+				Attributes: map[string]Attribute{
+					"id": {Type: "tftypes.String", Computed: true},
+				},
+			},
+		},
+	}
+
+	var got []string
+	var upgraded []string
+	for _, c := range BreakingChanges(old, new) {
+		got = append(got, c.String())
+		if c.Upgraded {
+			upgraded = append(upgraded, c.String())
+		}
+	}
+	assert.Equal(t, []string{
+		"data source polytomic_caller_identity attribute name: removed",
+		"resource polytomic_model attribute connection_id: added as a required attribute",
+		"resource polytomic_model attribute labels: type changed from tftypes.Set[tftypes.String] to tftypes.List[tftypes.String]",
+		"resource polytomic_model attribute query: is no longer configurable",
+		"resource polytomic_postgresql_connection attribute configuration.database: became required",
+		"resource polytomic_postgresql_connection attribute configuration.password: sensitive changed from true to false",
+		"resource polytomic_postgresql_connection attribute configuration.ssh: removed",
+		"resource polytomic_removed: removed",
+	}, got)
+	assert.Equal(t, []string{
+		"resource polytomic_postgresql_connection attribute configuration.database: became required",
+		"resource polytomic_postgresql_connection attribute configuration.password: sensitive changed from true to false",
+		"resource polytomic_postgresql_connection attribute configuration.ssh: removed",
+	}, upgraded, "changes to resources whose version was incremented are upgraded")
+
+	assert.Empty(t, BreakingChanges(new, new))
+}
+
+func TestWriteLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	s := &Snapshot{
+		Provider: Schema{Attributes: map[string]Attribute{"api_key": {Type: "tftypes.String", Optional: true, Sensitive: true}}},
+		Resources: map[string]Schema{
+			"polytomic_role": {Version: 1, Attributes: map[string]Attribute{"name": {Type: "tftypes.String", Required: true}}},
+		},
+		DataSources: map[string]Schema{},
+	}
+	require.NoError(t, s.Write(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, s, loaded)
+}
@@ -3,9 +3,11 @@ package provider
 import "github.com/hashicorp/terraform-plugin-framework/types"
 
 type bulkSourceDatasourceData struct {
-	ConnectionID types.String `tfsdk:"connection_id"`
-	Organization types.String `tfsdk:"organization"`
-	Schemas      types.List   `tfsdk:"schemas"`
+	ConnectionID   types.String `tfsdk:"connection_id"`
+	Organization   types.String `tfsdk:"organization"`
+	SchemaFilter   types.String `tfsdk:"schema_filter"`
+	RefreshSchemas types.Bool   `tfsdk:"refresh_schemas"`
+	Schemas        types.List   `tfsdk:"schemas"`
 }
 
 type bulkDestinationDatasourceData struct {
@@ -0,0 +1,469 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/polytomic/polytomic-go"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+const (
+	fieldRuleObfuscate = "obfuscate"
+	fieldRuleExclude   = "exclude"
+)
+
+// bulkSyncFieldRule obfuscates or excludes the fields of a bulk sync's
+// schemas which match it.
+type bulkSyncFieldRule struct {
+	Fields types.Set    `tfsdk:"fields"`
+	Types  types.Set    `tfsdk:"types"`
+	Action types.String `tfsdk:"action"`
+}
+
+func (bulkSyncFieldRule) SchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"fields": schema.SetAttribute{
+			MarkdownDescription: "[Patterns](#patterns) of the field names the rule applies to, e.g. `email` or `*_ssn`, matched case-insensitively.",
+			ElementType:         types.StringType,
+			Required:            true,
+		},
+		"types": schema.SetAttribute{
+			MarkdownDescription: "Types of the fields the rule applies to, as reported by the `polytomic_bulk_source` data source. The rule applies to fields of any type if unset.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"action": schema.StringAttribute{
+			MarkdownDescription: "`obfuscate` to obfuscate the fields, or `exclude` to exclude them from the sync.",
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(fieldRuleObfuscate, fieldRuleExclude),
+			},
+		},
+	}
+}
+
+func (bulkSyncFieldRule) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"fields": types.SetType{ElemType: types.StringType},
+		"types":  types.SetType{ElemType: types.StringType},
+		"action": types.StringType,
+	}
+}
+
+// bulkSyncFieldRuleMatch is a field which a field rule applies to.
+type bulkSyncFieldRuleMatch struct {
+	SchemaID types.String `tfsdk:"schema_id"`
+	FieldID  types.String `tfsdk:"field_id"`
+	Action   types.String `tfsdk:"action"`
+}
+
+func (bulkSyncFieldRuleMatch) SchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"schema_id": schema.StringAttribute{
+			MarkdownDescription: "Schema ID",
+			Computed:            true,
+		},
+		"field_id": schema.StringAttribute{
+			MarkdownDescription: "Field ID",
+			Computed:            true,
+		},
+		"action": schema.StringAttribute{
+			MarkdownDescription: "Action applied to the field: `obfuscate` or `exclude`",
+			Computed:            true,
+		},
+	}
+}
+
+func (bulkSyncFieldRuleMatch) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"schema_id": types.StringType,
+		"field_id":  types.StringType,
+		"action":    types.StringType,
+	}
+}
+
+// fieldRule is a field rule, as matched by matchFieldRules.
+type fieldRule struct {
+	fields []string
+	types  []string
+	action string
+}
+
+// sourceField is a field of a bulk sync source's schema.
+type sourceField struct {
+	id, name, typ string
+}
+
+// fieldRuleMatch is a field which a field rule applies to.
+type fieldRuleMatch struct {
+	schemaID, fieldID, action string
+}
+
+// matchFieldRules returns the fields of the selected schemas which rules apply
+// to, sorted by schema and field ID. The first rule a field matches applies
+// to it.
+func matchFieldRules(rules []fieldRule, schemas map[string][]sourceField, selected []string) ([]fieldRuleMatch, error) {
+	matches := []fieldRuleMatch{}
+	for _, schemaID := range slices.Sorted(slices.Values(selected)) {
+		fields := slices.Clone(schemas[schemaID])
+		slices.SortFunc(fields, func(a, b sourceField) int { return cmp.Compare(a.id, b.id) })
+		for _, f := range fields {
+			name := strings.ToLower(cmp.Or(f.name, f.id))
+			for _, rule := range rules {
+				if len(rule.types) > 0 && !slices.ContainsFunc(rule.types, func(t string) bool { return strings.EqualFold(t, f.typ) }) {
+					continue
+				}
+				patterns := make([]string, len(rule.fields))
+				for i, p := range rule.fields {
+					patterns[i] = strings.ToLower(p)
+				}
+				ok, err := matchSchemaPatterns(patterns, name)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					matches = append(matches, fieldRuleMatch{schemaID: schemaID, fieldID: f.id, action: rule.action})
+					break
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+// applyFieldRuleMatches configures the fields which field rules apply to on
+// schemas. Rules take precedence over the fields' configuration in schemas.
+// If no schemas are configured, all of the source's schemas, sourceIDs, are.
+func applyFieldRuleMatches(ctx context.Context, schemas []bulkSyncSchema, matches []fieldRuleMatch, sourceIDs []string) ([]bulkSyncSchema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(matches) == 0 {
+		return schemas, diags
+	}
+	if len(schemas) == 0 {
+		// The bulk sync syncs all of the source's schemas by default;
+		// they're configured so their fields can be.
+		for _, id := range slices.Sorted(slices.Values(sourceIDs)) {
+			schemas = append(schemas, newBulkSyncSchema(id, true))
+		}
+	}
+
+	bySchema := map[string][]fieldRuleMatch{}
+	for _, m := range matches {
+		bySchema[m.schemaID] = append(bySchema[m.schemaID], m)
+	}
+	fieldType := types.ObjectType{AttrTypes: bulkSyncSchemaField{}.AttrTypes()}
+	for i, s := range schemas {
+		schemaMatches := bySchema[s.Id.ValueString()]
+		if len(schemaMatches) == 0 {
+			continue
+		}
+		var fields []bulkSyncSchemaField
+		if !s.Fields.IsNull() && !s.Fields.IsUnknown() {
+			diags.Append(s.Fields.ElementsAs(ctx, &fields, false)...)
+			if diags.HasError() {
+				return nil, diags
+			}
+		}
+		for _, m := range schemaMatches {
+			j := slices.IndexFunc(fields, func(f bulkSyncSchemaField) bool { return f.Id.ValueString() == m.fieldID })
+			if j < 0 {
+				fields = append(fields, bulkSyncSchemaField{
+					Id:             types.StringValue(m.fieldID),
+					Enabled:        types.BoolNull(),
+					Obfuscate:      types.BoolNull(),
+					OutputName:     types.StringNull(),
+					UserOutputName: types.StringNull(),
+				})
+				j = len(fields) - 1
+			}
+			switch m.action {
+			case fieldRuleObfuscate:
+				fields[j].Obfuscate = types.BoolValue(true)
+			case fieldRuleExclude:
+				fields[j].Enabled = types.BoolValue(false)
+			}
+		}
+		var d diag.Diagnostics
+		schemas[i].Fields, d = types.SetValueFrom(ctx, fieldType, fields)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	return schemas, diags
+}
+
+// fieldRuleMatchesValue returns matches as the value of field_rule_matches.
+func fieldRuleMatchesValue(ctx context.Context, matches []fieldRuleMatch) (types.List, diag.Diagnostics) {
+	values := make([]bulkSyncFieldRuleMatch, len(matches))
+	for i, m := range matches {
+		values[i] = bulkSyncFieldRuleMatch{
+			SchemaID: types.StringValue(m.schemaID),
+			FieldID:  types.StringValue(m.fieldID),
+			Action:   types.StringValue(m.action),
+		}
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: bulkSyncFieldRuleMatch{}.AttrTypes()}, values)
+}
+
+// fieldRuleMatches resolves data's field_rules against the fields of its
+// source connection's schemas. The rules apply to the enabled schemas, or to
+// all of the source's schemas if none are configured. It returns the matching
+// fields and the IDs of the source's schemas.
+func (r *bulkSyncResource) fieldRuleMatches(ctx context.Context, data bulkSyncResourceData, schemas []bulkSyncSchema) ([]fieldRuleMatch, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var tfRules []bulkSyncFieldRule
+	diags.Append(data.FieldRules.ElementsAs(ctx, &tfRules, false)...)
+	var source bulkSyncConnection
+	diags.Append(data.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+	rules := make([]fieldRule, len(tfRules))
+	for i, rule := range tfRules {
+		rules[i].action = rule.Action.ValueString()
+		diags.Append(rule.Fields.ElementsAs(ctx, &rules[i].fields, false)...)
+		if !rule.Types.IsNull() {
+			diags.Append(rule.Types.ElementsAs(ctx, &rules[i].types, false)...)
+		}
+	}
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		diags.AddError("Error getting client", err.Error())
+		return nil, nil, diags
+	}
+	sourceMeta, err := retryOnCacheRefresh(ctx, "get bulk source", func() (*polytomic.BulkSyncSourceEnvelope, error) {
+		return client.BulkSync.GetSource(ctx, source.ConnectionID.ValueString(), &polytomic.BulkSyncGetSourceRequest{})
+	})
+	if err != nil {
+		diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading bulk source schemas: %s", err))
+		return nil, nil, diags
+	}
+	sourceIDs := make([]string, 0, len(sourceMeta.Data.Schemas))
+	sourceFields := map[string][]sourceField{}
+	for _, s := range sourceMeta.Data.Schemas {
+		id := pointer.Get(s.Id)
+		sourceIDs = append(sourceIDs, id)
+		for _, f := range s.Fields {
+			sourceFields[id] = append(sourceFields[id], sourceField{
+				id:   pointer.Get(f.Id),
+				name: pointer.Get(f.Name),
+				typ:  string(pointer.Get(f.Type)),
+			})
+		}
+	}
+
+	selected := sourceIDs
+	if len(schemas) > 0 {
+		selected = nil
+		for _, s := range schemas {
+			if !s.Enabled.IsNull() && !s.Enabled.IsUnknown() && !s.Enabled.ValueBool() {
+				continue
+			}
+			selected = append(selected, s.Id.ValueString())
+		}
+	}
+	matches, err := matchFieldRules(rules, sourceFields, selected)
+	if err != nil {
+		diags.AddAttributeError(path.Root("field_rules"), "Invalid field rule", err.Error())
+		return nil, nil, diags
+	}
+	return matches, sourceIDs, diags
+}
+
+// plannedSchemas returns the schemas planned for data, as they're passed to
+// applyFieldRules, and whether they're known.
+func plannedSchemas(ctx context.Context, data bulkSyncResourceData) ([]bulkSyncSchema, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !data.SchemaSelection.IsNull() {
+		// only the selected schemas are enabled
+		if data.SelectedSchemas.IsUnknown() {
+			return nil, false, diags
+		}
+		var selected []string
+		diags.Append(data.SelectedSchemas.ElementsAs(ctx, &selected, false)...)
+		schemas := make([]bulkSyncSchema, len(selected))
+		for i, id := range selected {
+			schemas[i] = newBulkSyncSchema(id, true)
+		}
+		return schemas, true, diags
+	}
+	if data.Schemas.IsNull() || data.Schemas.IsUnknown() {
+		return nil, true, diags
+	}
+	var schemas []bulkSyncSchema
+	diags.Append(data.Schemas.ElementsAs(ctx, &schemas, false)...)
+	for _, s := range schemas {
+		if s.Id.IsUnknown() || s.Enabled.IsUnknown() {
+			return nil, false, diags
+		}
+	}
+	return schemas, true, diags
+}
+
+// planFieldRules plans field_rule_matches as the fields which field_rules
+// apply to. It must be called after the schema selection is planned.
+func (r *bulkSyncResource) planFieldRules(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan bulkSyncResourceData
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	matchesPath := path.Root("field_rule_matches")
+	matchType := types.ObjectType{AttrTypes: bulkSyncFieldRuleMatch{}.AttrTypes()}
+	if plan.FieldRules.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, matchesPath, types.ListNull(matchType))...)
+		return
+	}
+
+	rules, err := plan.FieldRules.ToTerraformValue(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading field rules", err.Error())
+		return
+	}
+	schemas, known, diags := plannedSchemas(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !known || !rules.IsFullyKnown() || !sourceConnectionKnown(ctx, plan) {
+		// applyFieldRules resolves them
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, matchesPath, types.ListUnknown(matchType))...)
+		return
+	}
+
+	matches, _, diags := r.fieldRuleMatches(ctx, plan, schemas)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var configured types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schemas"), &configured)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateFieldRuleMatches(ctx, configured, matches)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	matchesVal, diags := fieldRuleMatchesValue(ctx, matches)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, matchesPath, matchesVal)...)
+}
+
+// validateFieldRuleMatches reports the fields configured in schemas whose
+// configuration contradicts the field rule matching them. Rules take
+// precedence, so the configured value would never be applied.
+func validateFieldRuleMatches(ctx context.Context, schemas types.Set, matches []fieldRuleMatch) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if schemas.IsNull() || schemas.IsUnknown() {
+		return diags
+	}
+	actions := map[[2]string]string{}
+	for _, m := range matches {
+		actions[[2]string{m.schemaID, m.fieldID}] = m.action
+	}
+
+	var configured []bulkSyncSchema
+	diags.Append(schemas.ElementsAs(ctx, &configured, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	for _, s := range configured {
+		if s.Fields.IsNull() || s.Fields.IsUnknown() {
+			continue
+		}
+		var fields []bulkSyncSchemaField
+		diags.Append(s.Fields.ElementsAs(ctx, &fields, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		for _, f := range fields {
+			var conflict string
+			switch actions[[2]string{s.Id.ValueString(), f.Id.ValueString()}] {
+			case fieldRuleObfuscate:
+				if !f.Obfuscate.IsNull() && !f.Obfuscate.IsUnknown() && !f.Obfuscate.ValueBool() {
+					conflict = "obfuscate = false, but a field rule obfuscates it"
+				}
+			case fieldRuleExclude:
+				if f.Enabled.ValueBool() {
+					conflict = "enabled = true, but a field rule excludes it"
+				}
+			}
+			if conflict != "" {
+				diags.AddAttributeError(path.Root("schemas"), "Field conflicts with field rule",
+					fmt.Sprintf("Field %s of schema %s is configured with %s. Remove the setting from schemas, or change field_rules so the field doesn't match.",
+						f.Id.ValueString(), s.Id.ValueString(), conflict))
+			}
+		}
+	}
+	return diags
+}
+
+// sourceConnectionKnown reports whether data's source connection is known.
+func sourceConnectionKnown(ctx context.Context, data bulkSyncResourceData) bool {
+	if data.Source.IsUnknown() {
+		return false
+	}
+	var source bulkSyncConnection
+	if diags := data.Source.As(ctx, &source, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return false
+	}
+	return !source.ConnectionID.IsUnknown()
+}
+
+// applyFieldRules returns schemas with the fields which data's field_rules
+// apply to configured, and sets field_rule_matches if it wasn't known when
+// planning.
+func (r *bulkSyncResource) applyFieldRules(ctx context.Context, data *bulkSyncResourceData, schemas []bulkSyncSchema) ([]bulkSyncSchema, diag.Diagnostics) {
+	matches, sourceIDs, diags := r.fieldRuleMatches(ctx, *data, schemas)
+	if diags.HasError() {
+		return nil, diags
+	}
+	// Apply what was planned, unless it couldn't be resolved at plan time.
+	if !data.FieldRuleMatches.IsUnknown() && !data.FieldRuleMatches.IsNull() {
+		var planned []bulkSyncFieldRuleMatch
+		diags.Append(data.FieldRuleMatches.ElementsAs(ctx, &planned, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		matches = make([]fieldRuleMatch, len(planned))
+		for i, m := range planned {
+			matches[i] = fieldRuleMatch{
+				schemaID: m.SchemaID.ValueString(),
+				fieldID:  m.FieldID.ValueString(),
+				action:   m.Action.ValueString(),
+			}
+		}
+	} else {
+		var d diag.Diagnostics
+		data.FieldRuleMatches, d = fieldRuleMatchesValue(ctx, matches)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	return applyFieldRuleMatches(ctx, schemas, matches, sourceIDs)
+}
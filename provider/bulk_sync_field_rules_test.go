@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchFieldRules(t *testing.T) {
+	schemas := map[string][]sourceField{
+		"public.users": {
+			{id: "ssn", name: "SSN", typ: "string"},
+			{id: "email", name: "Email", typ: "string"},
+			{id: "id", name: "id", typ: "integer"},
+			{id: "tax_id", name: "tax_id", typ: "integer"},
+		},
+		"sales.accounts": {
+			{id: "email", name: "email", typ: "string"},
+			{id: "billing_email", typ: "string"},
+		},
+	}
+
+	tests := map[string]struct {
+		rules    []fieldRule
+		selected []string
+		want     []fieldRuleMatch
+	}{
+		"case insensitive": {
+			rules:    []fieldRule{{fields: []string{"ssn", "EMAIL"}, action: fieldRuleObfuscate}},
+			selected: []string{"public.users"},
+			want: []fieldRuleMatch{
+				{"public.users", "email", fieldRuleObfuscate},
+				{"public.users", "ssn", fieldRuleObfuscate},
+			},
+		},
+		"only selected schemas": {
+			rules:    []fieldRule{{fields: []string{"*email"}, action: fieldRuleExclude}},
+			selected: []string{"sales.accounts"},
+			want: []fieldRuleMatch{
+				{"sales.accounts", "billing_email", fieldRuleExclude},
+				{"sales.accounts", "email", fieldRuleExclude},
+			},
+		},
+		"types": {
+			rules:    []fieldRule{{fields: []string{"*id"}, types: []string{"Integer"}, action: fieldRuleObfuscate}},
+			selected: []string{"public.users", "sales.accounts"},
+			want: []fieldRuleMatch{
+				{"public.users", "id", fieldRuleObfuscate},
+				{"public.users", "tax_id", fieldRuleObfuscate},
+			},
+		},
+		"first rule wins": {
+			rules: []fieldRule{
+				{fields: []string{"email"}, action: fieldRuleExclude},
+				{fields: []string{"*"}, types: []string{"string"}, action: fieldRuleObfuscate},
+			},
+			selected: []string{"sales.accounts"},
+			want: []fieldRuleMatch{
+				{"sales.accounts", "billing_email", fieldRuleObfuscate},
+				{"sales.accounts", "email", fieldRuleExclude},
+			},
+		},
+		"no match": {
+			rules:    []fieldRule{{fields: []string{"phone"}, action: fieldRuleObfuscate}},
+			selected: []string{"public.users", "sales.accounts"},
+			want:     []fieldRuleMatch{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := matchFieldRules(tc.rules, schemas, tc.selected)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := matchFieldRules([]fieldRule{{fields: []string{"[a"}}}, schemas, []string{"public.users"})
+		assert.ErrorContains(t, err, `invalid pattern "[a"`)
+	})
+}
+
+func TestBulkSyncResourceFieldRules(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	sourceID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	destID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "destination", "type": "postgresql"})
+
+	users := []any{
+		fakeapi.Object{"id": "id", "name": "id", "type": "integer"},
+		fakeapi.Object{"id": "email", "name": "email", "type": "string"},
+		fakeapi.Object{"id": "ssn", "name": "ssn", "type": "string"},
+	}
+	tables := func(users []any) fakeapi.Object {
+		return fakeapi.Object{"schemas": []any{
+			fakeapi.Object{"id": "public.users", "name": "users", "fields": users},
+			fakeapi.Object{"id": "sales.accounts", "name": "accounts", "fields": []any{
+				fakeapi.Object{"id": "email", "name": "email", "type": "string"},
+			}},
+		}}
+	}
+	server.SetBulkSource(sourceID, tables(users))
+	server.SetBulkDestination(destID, fakeapi.Object{
+		"configuration": fakeapi.Object{},
+		"modes":         []any{fakeapi.Object{"id": "replicate", "label": "Replicate"}},
+	})
+
+	config := fmt.Sprintf(`
+resource "polytomic_bulk_sync" "test" {
+  name   = "TestBulkSyncResourceFieldRules"
+  active = true
+  mode   = "replicate"
+
+  schedule = {
+    frequency = "manual"
+  }
+
+  source = {
+    connection_id = %q
+  }
+
+  destination = {
+    connection_id = %q
+  }
+
+  schema_selection = {
+    include = ["public.*"]
+  }
+
+  field_rules = [
+    {
+      fields = ["ssn"]
+      action = "exclude"
+    },
+    {
+      fields = ["*"]
+      types  = ["string"]
+      action = "obfuscate"
+    },
+  ]
+}
+`, sourceID, destID)
+
+	match := func(schemaID, fieldID, action string) knownvalue.Check {
+		return knownvalue.ObjectExact(map[string]knownvalue.Check{
+			"schema_id": knownvalue.StringExact(schemaID),
+			"field_id":  knownvalue.StringExact(fieldID),
+			"action":    knownvalue.StringExact(action),
+		})
+	}
+	// configured checks the fields the bulk sync was configured with.
+	configured := func(schemaID string, want map[string]fakeapi.Object) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			bulk, ok := server.Get(fakeapi.BulkSyncs, s.RootModule().Resources["polytomic_bulk_sync.test"].Primary.ID)
+			if !ok {
+				return fmt.Errorf("bulk sync not found")
+			}
+			got := map[string]fakeapi.Object{}
+			for _, item := range bulk["schemas"].([]any) {
+				schema := item.(fakeapi.Object)
+				if schema["id"] != schemaID {
+					continue
+				}
+				fields, _ := schema["fields"].([]any)
+				for _, f := range fields {
+					field := f.(fakeapi.Object)
+					got[field["id"].(string)] = field
+				}
+			}
+			for id, w := range want {
+				for k, v := range w {
+					if got[id][k] != v {
+						return fmt.Errorf("expected field %s.%s %s to be %v, got %v", schemaID, id, k, v, got[id])
+					}
+				}
+			}
+			if len(got) != len(want) {
+				return fmt.Errorf("expected %d fields of %s to be configured, got %v", len(want), schemaID, got)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync.test",
+						tfjsonpath.New("field_rule_matches"),
+						knownvalue.ListExact([]knownvalue.Check{
+							match("public.users", "email", "obfuscate"),
+							match("public.users", "ssn", "exclude"),
+						}),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					configured("public.users", map[string]fakeapi.Object{
+						"email": {"obfuscate": true},
+						"ssn":   {"enabled": false},
+					}),
+					configured("sales.accounts", map[string]fakeapi.Object{}),
+				),
+			},
+			{
+				// a new field matching a rule is planned
+				PreConfig: func() {
+					server.SetBulkSource(sourceID, tables(append(users,
+						fakeapi.Object{"id": "phone", "name": "phone", "type": "string"},
+					)))
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("polytomic_bulk_sync.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(
+							"polytomic_bulk_sync.test",
+							tfjsonpath.New("field_rule_matches"),
+							knownvalue.ListExact([]knownvalue.Check{
+								match("public.users", "email", "obfuscate"),
+								match("public.users", "phone", "obfuscate"),
+								match("public.users", "ssn", "exclude"),
+							}),
+						),
+					},
+				},
+				Check: configured("public.users", map[string]fakeapi.Object{
+					"email": {"obfuscate": true},
+					"phone": {"obfuscate": true},
+					"ssn":   {"enabled": false},
+				}),
+			},
+			{
+				// fields configured in schemas can't contradict their rule
+				Config: strings.Replace(config, "  field_rules = [", `  schemas = [
+    {
+      id     = "public.users"
+      fields = [
+        {
+          id        = "email"
+          obfuscate = false
+        },
+      ]
+    },
+  ]
+
+  field_rules = [`, 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Field conflicts with field rule`),
+			},
+		},
+	})
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	stdpath "path"
+	"slices"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/polytomic/polytomic-go"
+	ptclient "github.com/polytomic/polytomic-go/client"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// bulkSyncSchemaSelection selects a bulk sync's schemas by matching the
+// source's schema IDs against glob patterns, such as "public.*".
+type bulkSyncSchemaSelection struct {
+	Include types.Set `tfsdk:"include"`
+	Exclude types.Set `tfsdk:"exclude"`
+}
+
+func (bulkSyncSchemaSelection) SchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"include": schema.SetAttribute{
+			MarkdownDescription: "[Patterns](#patterns) of schema IDs to sync, e.g. `public.*`.",
+			ElementType:         types.StringType,
+			Required:            true,
+		},
+		"exclude": schema.SetAttribute{
+			MarkdownDescription: "Patterns of schema IDs to exclude from the included schemas, e.g. `public.audit_*`.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+	}
+}
+
+func (bulkSyncSchemaSelection) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"include": types.SetType{ElemType: types.StringType},
+		"exclude": types.SetType{ElemType: types.StringType},
+	}
+}
+
+// matchSchemaPatterns reports whether id matches any of patterns.
+func matchSchemaPatterns(patterns []string, id string) (bool, error) {
+	for _, p := range patterns {
+		ok, err := stdpath.Match(p, id)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchSchemaSelection returns the sorted IDs which match include and don't
+// match exclude.
+func matchSchemaSelection(include, exclude, ids []string) ([]string, error) {
+	selected := []string{}
+	for _, id := range ids {
+		included, err := matchSchemaPatterns(include, id)
+		if err != nil {
+			return nil, err
+		}
+		excluded, err := matchSchemaPatterns(exclude, id)
+		if err != nil {
+			return nil, err
+		}
+		if included && !excluded {
+			selected = append(selected, id)
+		}
+	}
+	slices.Sort(selected)
+	return slices.Compact(selected), nil
+}
+
+// bulkSourceSchemaIDs returns the IDs of the schemas a connection can sync
+// from; the same schemas the polytomic_bulk_source data source returns.
+func bulkSourceSchemaIDs(ctx context.Context, client *ptclient.Client, connectionID string) ([]string, error) {
+	source, err := retryOnCacheRefresh(ctx, "get bulk source", func() (*polytomic.BulkSyncSourceEnvelope, error) {
+		return client.BulkSync.GetSource(ctx, connectionID, &polytomic.BulkSyncGetSourceRequest{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(source.Data.Schemas))
+	for _, s := range source.Data.Schemas {
+		ids = append(ids, pointer.Get(s.Id))
+	}
+	return ids, nil
+}
+
+// selectedSchemaIDs returns the IDs of the enabled schemas of a bulk sync.
+func selectedSchemaIDs(ctx context.Context, schemas []*polytomic.BulkSchema) (types.Set, diag.Diagnostics) {
+	ids := []string{}
+	for _, s := range schemas {
+		if pointer.Get(s.Enabled) {
+			ids = append(ids, pointer.Get(s.Id))
+		}
+	}
+	slices.Sort(ids)
+	return types.SetValueFrom(ctx, types.StringType, ids)
+}
+
+// schemaSelectionKnown reports whether data's schema_selection can be
+// resolved, i.e. whether it and the source connection are known.
+func schemaSelectionKnown(ctx context.Context, data bulkSyncResourceData) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.SchemaSelection.IsUnknown() || data.Source.IsUnknown() {
+		return false, diags
+	}
+	var source bulkSyncConnection
+	diags.Append(data.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+	var selection bulkSyncSchemaSelection
+	diags.Append(data.SchemaSelection.As(ctx, &selection, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return false, diags
+	}
+	return !source.ConnectionID.IsUnknown() && !selection.Include.IsUnknown() && !selection.Exclude.IsUnknown(), diags
+}
+
+// selectSchemas resolves data's schema_selection against the schemas of its
+// source connection, returning the selected schema IDs and the IDs of all the
+// source's schemas. Per-schema overrides in schemas must be for selected
+// schemas.
+func (r *bulkSyncResource) selectSchemas(ctx context.Context, data bulkSyncResourceData) ([]string, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var source bulkSyncConnection
+	diags.Append(data.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+	var selection bulkSyncSchemaSelection
+	diags.Append(data.SchemaSelection.As(ctx, &selection, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+	var include, exclude []string
+	diags.Append(selection.Include.ElementsAs(ctx, &include, false)...)
+	if !selection.Exclude.IsNull() {
+		diags.Append(selection.Exclude.ElementsAs(ctx, &exclude, false)...)
+	}
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		diags.AddError("Error getting client", err.Error())
+		return nil, nil, diags
+	}
+	ids, err := bulkSourceSchemaIDs(ctx, client, source.ConnectionID.ValueString())
+	if err != nil {
+		diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading bulk source schemas: %s", err))
+		return nil, nil, diags
+	}
+	selected, err := matchSchemaSelection(include, exclude, ids)
+	if err != nil {
+		diags.AddAttributeError(path.Root("schema_selection"), "Invalid schema selection", err.Error())
+		return nil, nil, diags
+	}
+
+	if !data.Schemas.IsNull() && !data.Schemas.IsUnknown() {
+		var overrides []bulkSyncSchema
+		diags.Append(data.Schemas.ElementsAs(ctx, &overrides, false)...)
+		if diags.HasError() {
+			return nil, nil, diags
+		}
+		for _, s := range overrides {
+			if !s.Id.IsUnknown() && !slices.Contains(selected, s.Id.ValueString()) {
+				diags.AddAttributeError(path.Root("schemas"), "Schema not selected",
+					fmt.Sprintf("Schema %q is configured in schemas but isn't selected by schema_selection.", s.Id.ValueString()))
+			}
+		}
+	}
+	return selected, ids, diags
+}
+
+// planSchemaSelection plans selected_schemas as the resolved schema_selection.
+func (r *bulkSyncResource) planSchemaSelection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan bulkSyncResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.SchemaSelection.IsNull() {
+		return
+	}
+
+	known, diags := schemaSelectionKnown(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !known {
+		// applySchemaSelection resolves it
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("selected_schemas"), types.SetUnknown(types.StringType))...)
+		return
+	}
+
+	selected, ids, diags := r.selectSchemas(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(selected) == 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("schema_selection"), "No schemas selected",
+			fmt.Sprintf("schema_selection doesn't match any of the %d schemas of the source connection.", len(ids)))
+	}
+
+	selectedVal, diags := types.SetValueFrom(ctx, types.StringType, selected)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("selected_schemas"), selectedVal)...)
+}
+
+// applySchemaSelection returns the schemas to configure for a bulk sync with a
+// schema_selection: the selected schemas are enabled, with any overrides from
+// schemas, and the source's other schemas are disabled.
+func (r *bulkSyncResource) applySchemaSelection(ctx context.Context, data bulkSyncResourceData, overrides []bulkSyncSchema) ([]bulkSyncSchema, diag.Diagnostics) {
+	selected, ids, diags := r.selectSchemas(ctx, data)
+	if diags.HasError() {
+		return nil, diags
+	}
+	// Configure what was planned, unless it couldn't be resolved at plan time.
+	if !data.SelectedSchemas.IsUnknown() && !data.SelectedSchemas.IsNull() {
+		selected = nil
+		diags.Append(data.SelectedSchemas.ElementsAs(ctx, &selected, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	configured := map[string]bulkSyncSchema{}
+	for _, s := range overrides {
+		configured[s.Id.ValueString()] = s
+	}
+	schemas := make([]bulkSyncSchema, 0, len(ids))
+	for _, id := range slices.Compact(slices.Sorted(slices.Values(append(ids, selected...)))) {
+		if s, ok := configured[id]; ok {
+			if s.Enabled.IsNull() || s.Enabled.IsUnknown() {
+				s.Enabled = types.BoolValue(true)
+			}
+			schemas = append(schemas, s)
+			continue
+		}
+		schemas = append(schemas, newBulkSyncSchema(id, slices.Contains(selected, id)))
+	}
+	return schemas, diags
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchSchemaSelection(t *testing.T) {
+	ids := []string{"public.users", "public.audit_log", "public.audit_events", "sales.orders", "public.orders"}
+
+	tests := map[string]struct {
+		include, exclude []string
+		want             []string
+	}{
+		"wildcard": {
+			include: []string{"public.*"},
+			want:    []string{"public.audit_events", "public.audit_log", "public.orders", "public.users"},
+		},
+		"exclude": {
+			include: []string{"public.*"},
+			exclude: []string{"public.audit_*"},
+			want:    []string{"public.orders", "public.users"},
+		},
+		"multiple patterns": {
+			include: []string{"*.orders", "public.users"},
+			want:    []string{"public.orders", "public.users", "sales.orders"},
+		},
+		"exact": {
+			include: []string{"sales.orders"},
+			want:    []string{"sales.orders"},
+		},
+		"no match": {
+			include: []string{"marketing.*"},
+			want:    []string{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := matchSchemaSelection(tc.include, tc.exclude, ids)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := matchSchemaSelection([]string{"public.[a"}, nil, ids)
+		assert.ErrorContains(t, err, `invalid pattern "public.[a"`)
+	})
+}
+
+func TestBulkSyncResourceSchemaSelection(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	sourceID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	destID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "destination", "type": "postgresql"})
+
+	tables := []any{
+		fakeapi.Object{"id": "public.users", "name": "users"},
+		fakeapi.Object{"id": "public.orders", "name": "orders"},
+		fakeapi.Object{"id": "public.audit_log", "name": "audit_log"},
+		fakeapi.Object{"id": "sales.accounts", "name": "accounts"},
+	}
+	server.SetBulkSource(sourceID, fakeapi.Object{"schemas": tables})
+	server.SetBulkDestination(destID, fakeapi.Object{
+		"configuration": fakeapi.Object{},
+		"modes":         []any{fakeapi.Object{"id": "replicate", "label": "Replicate"}},
+	})
+
+	config := func(schemas string) string {
+		return fmt.Sprintf(`
+resource "polytomic_bulk_sync" "test" {
+  name   = "TestBulkSyncResourceSchemaSelection"
+  active = true
+  mode   = "replicate"
+
+  schedule = {
+    frequency = "manual"
+  }
+
+  source = {
+    connection_id = %q
+  }
+
+  destination = {
+    connection_id = %q
+  }
+
+  schema_selection = {
+    include = ["public.*"]
+    exclude = ["public.audit_*"]
+  }
+%s
+}
+`, sourceID, destID, schemas)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  schemas = [{
+    id             = "public.users"
+    tracking_field = "updated_at"
+  }]
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync.test",
+						tfjsonpath.New("selected_schemas"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("public.orders"),
+							knownvalue.StringExact("public.users"),
+						}),
+					),
+				},
+			},
+			{
+				// a new table matching the selection is planned
+				PreConfig: func() {
+					server.SetBulkSource(sourceID, fakeapi.Object{"schemas": append(tables,
+						fakeapi.Object{"id": "public.invoices", "name": "invoices"},
+					)})
+				},
+				Config: config(`
+  schemas = [{
+    id             = "public.users"
+    tracking_field = "updated_at"
+  }]
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("polytomic_bulk_sync.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync.test",
+						tfjsonpath.New("selected_schemas"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("public.invoices"),
+							knownvalue.StringExact("public.orders"),
+							knownvalue.StringExact("public.users"),
+						}),
+					),
+				},
+			},
+			{
+				Config: config(`
+  schemas = [{
+    id = "public.audit_log"
+  }]
+`),
+				ExpectError: regexp.MustCompile(`Schema "public.audit_log" is configured in schemas but isn't selected`),
+			},
+		},
+	})
+}
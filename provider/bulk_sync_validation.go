@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// bulkDestinationSpec is what a destination connection supports for bulk
+// syncs, as reported by the polytomic_bulk_destination data source.
+type bulkDestinationSpec struct {
+	// configuration are the destination configuration keys, all of which
+	// are required.
+	configuration []string
+	modes         []string
+}
+
+// validate checks a bulk sync's mode and destination configuration against
+// the destination. Missing configuration keys and unsupported modes are
+// errors; configuration keys the destination doesn't define are warnings.
+func (s bulkDestinationSpec) validate(mode string, configuration map[string]any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	configPath := path.Root("destination").AtName("configuration")
+
+	if mode != "" && len(s.modes) > 0 && !slices.Contains(s.modes, mode) {
+		diags.AddAttributeError(path.Root("mode"), "Unsupported bulk sync mode",
+			fmt.Sprintf("The destination connection doesn't support mode %q; supported modes are: %s.", mode, strings.Join(s.modes, ", ")))
+	}
+
+	var missing []string
+	for _, k := range s.configuration {
+		if _, ok := configuration[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		diags.AddAttributeError(configPath, "Missing destination configuration",
+			fmt.Sprintf("The destination connection requires configuration for: %s.", strings.Join(missing, ", ")))
+	}
+
+	var unknown []string
+	for k := range configuration {
+		if !slices.Contains(s.configuration, k) {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		diags.AddAttributeWarning(configPath, "Unknown destination configuration",
+			fmt.Sprintf("The destination connection doesn't define configuration for: %s. Supported keys are: %s.",
+				strings.Join(unknown, ", "), strings.Join(s.configuration, ", ")))
+	}
+	return diags
+}
+
+// planDestinationValidation validates the planned mode and destination
+// configuration against the destination connection when the bulk sync is
+// created, or its mode or destination changes.
+func (r *bulkSyncResource) planDestinationValidation(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan bulkSyncResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state bulkSyncResourceData
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.Mode.Equal(state.Mode) && plan.Destination.Equal(state.Destination) {
+			return
+		}
+	}
+	if plan.Mode.IsUnknown() || plan.Destination.IsUnknown() {
+		return
+	}
+
+	var destination bulkSyncConnection
+	resp.Diagnostics.Append(plan.Destination.As(ctx, &destination, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if destination.ConnectionID.IsUnknown() || destination.Configuration.IsUnknown() {
+		return
+	}
+	configuration := map[string]any{}
+	if !destination.Configuration.IsNull() {
+		resp.Diagnostics.Append(destination.Configuration.Unmarshal(&configuration)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client, err := r.provider.Client(ctx, plan.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	dest, err := client.BulkSync.GetDestination(ctx, destination.ConnectionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("destination").AtName("connection_id"), providerclient.ErrorSummary,
+			fmt.Sprintf("Error reading bulk destination: %s", err))
+		return
+	}
+
+	var spec bulkDestinationSpec
+	for k := range dest.Data.Configuration {
+		spec.configuration = append(spec.configuration, k)
+	}
+	slices.Sort(spec.configuration)
+	for _, m := range dest.Data.Modes {
+		spec.modes = append(spec.modes, pointer.Get(m.Id))
+	}
+	resp.Diagnostics.Append(spec.validate(plan.Mode.ValueString(), configuration)...)
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkDestinationSpecValidate(t *testing.T) {
+	spec := bulkDestinationSpec{
+		configuration: []string{"schema", "table_prefix"},
+		modes:         []string{"replicate", "snapshot"},
+	}
+	configPath := path.Root("destination").AtName("configuration")
+
+	tests := map[string]struct {
+		mode          string
+		configuration map[string]any
+		want          diag.Diagnostics
+	}{
+		"valid": {
+			mode:          "replicate",
+			configuration: map[string]any{"schema": "public", "table_prefix": "pt_"},
+		},
+		"unsupported mode": {
+			mode:          "append",
+			configuration: map[string]any{"schema": "public", "table_prefix": "pt_"},
+			want: diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("mode"), "Unsupported bulk sync mode",
+				`The destination connection doesn't support mode "append"; supported modes are: replicate, snapshot.`)},
+		},
+		"missing configuration": {
+			mode:          "snapshot",
+			configuration: map[string]any{},
+			want: diag.Diagnostics{diag.NewAttributeErrorDiagnostic(configPath, "Missing destination configuration",
+				"The destination connection requires configuration for: schema, table_prefix.")},
+		},
+		"unknown configuration": {
+			mode:          "replicate",
+			configuration: map[string]any{"schema": "public", "table_prefix": "pt_", "tabel": "x", "db": "y"},
+			want: diag.Diagnostics{diag.NewAttributeWarningDiagnostic(configPath, "Unknown destination configuration",
+				"The destination connection doesn't define configuration for: db, tabel. Supported keys are: schema, table_prefix.")},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, spec.validate(tc.mode, tc.configuration))
+		})
+	}
+
+	t.Run("destination without modes", func(t *testing.T) {
+		assert.Empty(t, bulkDestinationSpec{}.validate("replicate", nil))
+	})
+}
+
+func TestBulkSyncResourceDestinationValidation(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	sourceID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	destID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "destination", "type": "postgresql"})
+	server.SetBulkDestination(destID, fakeapi.Object{
+		"configuration": fakeapi.Object{"schema": fakeapi.Object{"type": "string"}},
+		"modes": []any{
+			fakeapi.Object{"id": "replicate", "label": "Replicate"},
+			fakeapi.Object{"id": "snapshot", "label": "Snapshot"},
+		},
+	})
+
+	config := func(mode, configuration string) string {
+		return fmt.Sprintf(`
+resource "polytomic_bulk_sync" "test" {
+  name   = "TestBulkSyncResourceDestinationValidation"
+  active = true
+  mode   = %q
+
+  schedule = {
+    frequency = "manual"
+  }
+
+  source = {
+    connection_id = %q
+  }
+
+  destination = {
+    connection_id = %q
+    configuration = jsonencode(%s)
+  }
+
+  schemas = []
+}
+`, mode, sourceID, destID, configuration)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("append", `{ schema = "public" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`doesn't support mode "append"`),
+			},
+			{
+				Config:      config("replicate", `{}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`requires configuration for: schema`),
+			},
+			{
+				Config: config("replicate", `{ schema = "public" }`),
+			},
+		},
+	})
+}
@@ -18,6 +18,7 @@ var (
 
 	// OAuthConnections identifies connection types whose credentials come from
 	// an OAuth flow and therefore cannot be supplied as Terraform inputs.
+	// These connections are managed with polytomic_oauth_connection.
 	OAuthConnections = connections.OAuthConnections
 )
 
@@ -26,12 +26,13 @@ type connectionSchemaDataSource struct {
 }
 
 type connectionSchemaDataSourceModel struct {
-	Organization types.String `tfsdk:"organization"`
-	ConnectionID types.String `tfsdk:"connection_id"`
-	SchemaID     types.String `tfsdk:"schema_id"`
-	Name         types.String `tfsdk:"name"`
-	Fields       types.Set    `tfsdk:"fields"`
-	ID           types.String `tfsdk:"id"`
+	Organization   types.String `tfsdk:"organization"`
+	ConnectionID   types.String `tfsdk:"connection_id"`
+	SchemaID       types.String `tfsdk:"schema_id"`
+	RefreshSchemas types.Bool   `tfsdk:"refresh_schemas"`
+	Name           types.String `tfsdk:"name"`
+	Fields         types.Set    `tfsdk:"fields"`
+	ID             types.String `tfsdk:"id"`
 }
 
 type schemaFieldModel struct {
@@ -67,6 +68,10 @@ func (d *connectionSchemaDataSource) Schema(ctx context.Context, req datasource.
 				MarkdownDescription: "Schema ID",
 				Required:            true,
 			},
+			"refresh_schemas": schema.BoolAttribute{
+				MarkdownDescription: "Refresh the connection's schema cache, and wait for the refresh to complete, before reading the schema. Use this to discover tables and fields added to the source.",
+				Optional:            true,
+			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Schema name",
 				Computed:            true,
@@ -120,6 +125,12 @@ func (d *connectionSchemaDataSource) Read(ctx context.Context, req datasource.Re
 		resp.Diagnostics.AddError("Error getting client", err.Error())
 		return
 	}
+	if data.RefreshSchemas.ValueBool() {
+		if err := refreshConnectionSchemas(ctx, client, data.ConnectionID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(providerclient.ErrorSummary, err.Error())
+			return
+		}
+	}
 
 	schemaResp, err := client.Schemas.Get(ctx, data.ConnectionID.ValueString(), data.SchemaID.ValueString())
 	if err != nil {
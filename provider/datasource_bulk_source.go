@@ -2,12 +2,16 @@ package provider
 
 import (
 	"context"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/polytomic/polytomic-go"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
@@ -44,24 +48,18 @@ func (d *bulkSourceDatasource) Schema(ctx context.Context, req datasource.Schema
 				Optional:            true,
 				Description:         "The organization to which the connection belongs. This is required when using a partner or deployment key.",
 			},
+			"schema_filter": schema.StringAttribute{
+				MarkdownDescription: "Only return schemas whose ID matches this pattern: a [glob pattern](../resources/bulk_sync#patterns), e.g. `public.*`, or a regular expression enclosed in slashes, e.g. `/^public\\.(users|orders)$/`.",
+				Optional:            true,
+			},
+			"refresh_schemas": schema.BoolAttribute{
+				MarkdownDescription: "Refresh the connection's schema cache, and wait for the refresh to complete, before reading the schemas. Use this to discover tables added to the source.",
+				Optional:            true,
+			},
 			"schemas": schema.ListAttribute{
 				MarkdownDescription: "",
-				ElementType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"id":   types.StringType,
-						"name": types.StringType,
-						"fields": types.ListType{
-							ElemType: types.ObjectType{
-								AttrTypes: map[string]attr.Type{
-									"id":   types.StringType,
-									"name": types.StringType,
-									"type": types.StringType,
-								},
-							},
-						},
-					},
-				},
-				Computed: true,
+				ElementType:         types.ObjectType{AttrTypes: sourceSchema{}.AttrTypes()},
+				Computed:            true,
 			},
 		},
 	}
@@ -76,49 +74,69 @@ func (d *bulkSourceDatasource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	match := func(string) bool { return true }
+	if !data.SchemaFilter.IsNull() {
+		var err error
+		match, err = schemaFilter(data.SchemaFilter.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("schema_filter"), "Invalid schema filter", err.Error())
+			return
+		}
+	}
+
 	// Get the schemas
 	client, err := d.provider.Client(ctx, data.Organization.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error getting client", err.Error())
 		return
 	}
+	if data.RefreshSchemas.ValueBool() {
+		if err := refreshConnectionSchemas(ctx, client, data.ConnectionID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(providerclient.ErrorSummary, err.Error())
+			return
+		}
+	}
 	source, err := client.BulkSync.GetSource(ctx, data.ConnectionID.ValueString(), &polytomic.BulkSyncGetSourceRequest{})
 	if err != nil {
 		resp.Diagnostics.AddError("Error getting connection", err.Error())
 		return
 	}
 
-	schemas := make([]sourceSchema, len(source.Data.Schemas))
-	for i, s := range source.Data.Schemas {
+	schemas := make([]sourceSchema, 0, len(source.Data.Schemas))
+	for _, s := range source.Data.Schemas {
+		id := pointer.Get(s.Id)
+		if !match(id) {
+			continue
+		}
+
 		fields := make([]sourceSchemaField, len(s.Fields))
 		for j, f := range s.Fields {
 			fields[j] = sourceSchemaField{
-				ID:   pointer.Get(f.Id),
-				Name: pointer.Get(f.Name),
-				Type: string(pointer.Get(f.Type)),
+				ID:                   pointer.Get(f.Id),
+				Name:                 pointer.Get(f.Name),
+				Type:                 string(pointer.Get(f.Type)),
+				IsPrimaryKey:         types.BoolPointerValue(f.IsPrimaryKey),
+				Nullable:             types.BoolPointerValue(f.Nullable),
+				SupportsTracking:     types.BoolPointerValue(f.SupportsTracking),
+				SupportsPartitioning: types.BoolPointerValue(f.SupportsPartitioning),
+				Obfuscatable:         types.BoolPointerValue(f.Obfuscatable),
 			}
 		}
-		schemas[i] = sourceSchema{
-			ID:     pointer.Get(s.Id),
+		schema := sourceSchema{
+			ID:     id,
 			Name:   pointer.Get(s.Name),
 			Fields: fields,
 		}
+		if s.RowCount != nil {
+			schema.RowCount = pointer.To(int64(*s.RowCount))
+		}
+		if s.LastRefreshedAt != nil {
+			schema.LastRefreshedAt = pointer.To(s.LastRefreshedAt.Format(time.RFC3339))
+		}
+		schemas = append(schemas, schema)
 	}
 	var diags diag.Diagnostics
-	data.Schemas, diags = types.ListValueFrom(ctx, types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"id":   types.StringType,
-			"name": types.StringType,
-			"fields": types.ListType{
-				ElemType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"id":   types.StringType,
-						"name": types.StringType,
-						"type": types.StringType,
-					},
-				},
-			},
-		}}, schemas)
+	data.Schemas, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: sourceSchema{}.AttrTypes()}, schemas)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -128,14 +146,65 @@ func (d *bulkSourceDatasource) Read(ctx context.Context, req datasource.ReadRequ
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// schemaFilter returns a function which reports whether a schema ID matches
+// pattern: a regular expression if it's enclosed in slashes, and a glob
+// otherwise.
+func schemaFilter(pattern string) (func(id string) bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+	// check the pattern is valid before matching any IDs
+	if _, err := matchSchemaPatterns([]string{pattern}, ""); err != nil {
+		return nil, err
+	}
+	return func(id string) bool {
+		ok, _ := matchSchemaPatterns([]string{pattern}, id)
+		return ok
+	}, nil
+}
+
 type sourceSchema struct {
-	ID     string              `json:"id" tfsdk:"id"`
-	Name   string              `json:"name" tfsdk:"name"`
-	Fields []sourceSchemaField `json:"fields" tfsdk:"fields"`
+	ID              string              `json:"id" tfsdk:"id"`
+	Name            string              `json:"name" tfsdk:"name"`
+	Fields          []sourceSchemaField `json:"fields" tfsdk:"fields"`
+	RowCount        *int64              `json:"row_count" tfsdk:"row_count"`
+	LastRefreshedAt *string             `json:"last_refreshed_at" tfsdk:"last_refreshed_at"`
+}
+
+func (sourceSchema) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":                types.StringType,
+		"name":              types.StringType,
+		"fields":            types.ListType{ElemType: types.ObjectType{AttrTypes: sourceSchemaField{}.AttrTypes()}},
+		"row_count":         types.Int64Type,
+		"last_refreshed_at": types.StringType,
+	}
 }
 
 type sourceSchemaField struct {
-	ID   string `json:"id" tfsdk:"id"`
-	Name string `json:"name" tfsdk:"name"`
-	Type string `json:"type" tfsdk:"type"`
+	ID                   string     `json:"id" tfsdk:"id"`
+	Name                 string     `json:"name" tfsdk:"name"`
+	Type                 string     `json:"type" tfsdk:"type"`
+	IsPrimaryKey         types.Bool `json:"is_primary_key" tfsdk:"is_primary_key"`
+	Nullable             types.Bool `json:"nullable" tfsdk:"nullable"`
+	SupportsTracking     types.Bool `json:"supports_tracking" tfsdk:"supports_tracking"`
+	SupportsPartitioning types.Bool `json:"supports_partitioning" tfsdk:"supports_partitioning"`
+	Obfuscatable         types.Bool `json:"obfuscatable" tfsdk:"obfuscatable"`
+}
+
+func (sourceSchemaField) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":                    types.StringType,
+		"name":                  types.StringType,
+		"type":                  types.StringType,
+		"is_primary_key":        types.BoolType,
+		"nullable":              types.BoolType,
+		"supports_tracking":     types.BoolType,
+		"supports_partitioning": types.BoolType,
+		"obfuscatable":          types.BoolType,
+	}
 }
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkSourceDataSource(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	connID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	server.SetBulkSource(connID, fakeapi.Object{"schemas": []any{
+		fakeapi.Object{
+			"id":                "public.users",
+			"name":              "users",
+			"row_count":         1200,
+			"last_refreshed_at": "2026-01-02T03:04:05Z",
+			"fields": []any{
+				fakeapi.Object{"id": "id", "name": "id", "type": "number", "is_primary_key": true, "supports_partitioning": true},
+				fakeapi.Object{"id": "updated_at", "name": "updated_at", "type": "datetime", "nullable": true, "supports_tracking": true},
+				fakeapi.Object{"id": "email", "name": "email", "type": "string", "nullable": true, "obfuscatable": true},
+			},
+		},
+		fakeapi.Object{"id": "public.orders", "name": "orders"},
+		fakeapi.Object{"id": "public.audit_log", "name": "audit_log"},
+		fakeapi.Object{"id": "sales.accounts", "name": "accounts"},
+	}})
+
+	config := func(filters string) string {
+		return fmt.Sprintf(`
+data "polytomic_bulk_source" "test" {
+  connection_id = %q
+%s
+}
+`, connID, filters)
+	}
+	schemaIDs := func(ids ...string) knownvalue.Check {
+		checks := make([]knownvalue.Check, len(ids))
+		for i, id := range ids {
+			checks[i] = knownvalue.ObjectPartial(map[string]knownvalue.Check{
+				"id": knownvalue.StringExact(id),
+			})
+		}
+		return knownvalue.ListExact(checks)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.polytomic_bulk_source.test",
+						tfjsonpath.New("schemas"),
+						schemaIDs("public.users", "public.orders", "public.audit_log", "sales.accounts"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.polytomic_bulk_source.test",
+						tfjsonpath.New("schemas").AtSliceIndex(0),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"row_count":         knownvalue.Int64Exact(1200),
+							"last_refreshed_at": knownvalue.StringExact("2026-01-02T03:04:05Z"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.polytomic_bulk_source.test",
+						tfjsonpath.New("schemas").AtSliceIndex(0).AtMapKey("fields"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"id":                    knownvalue.StringExact("id"),
+								"name":                  knownvalue.StringExact("id"),
+								"type":                  knownvalue.StringExact("number"),
+								"is_primary_key":        knownvalue.Bool(true),
+								"supports_partitioning": knownvalue.Bool(true),
+								// flags the API doesn't return are null
+								"nullable":          knownvalue.Null(),
+								"supports_tracking": knownvalue.Null(),
+								"obfuscatable":      knownvalue.Null(),
+							}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"id":                knownvalue.StringExact("updated_at"),
+								"nullable":          knownvalue.Bool(true),
+								"supports_tracking": knownvalue.Bool(true),
+							}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"id":           knownvalue.StringExact("email"),
+								"obfuscatable": knownvalue.Bool(true),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.polytomic_bulk_source.test",
+						tfjsonpath.New("schemas").AtSliceIndex(1),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"row_count":         knownvalue.Null(),
+							"last_refreshed_at": knownvalue.Null(),
+						}),
+					),
+				},
+			},
+			{
+				Config: config(`  schema_filter = "public.*"`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.polytomic_bulk_source.test",
+						tfjsonpath.New("schemas"),
+						schemaIDs("public.users", "public.orders", "public.audit_log"),
+					),
+				},
+			},
+			{
+				Config: config(`  schema_filter = "/^[a-z]+\\.(users|accounts)$/"`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.polytomic_bulk_source.test",
+						tfjsonpath.New("schemas"),
+						schemaIDs("public.users", "sales.accounts"),
+					),
+				},
+			},
+			{
+				Config:      config(`  schema_filter = "/public.(/"`),
+				ExpectError: regexp.MustCompile(`Invalid schema filter`),
+			},
+			{
+				Config:      config(`  schema_filter = "public.[a"`),
+				ExpectError: regexp.MustCompile(`Invalid schema filter`),
+			},
+		},
+	})
+}
+
+func TestSchemaFilter(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		matches []string
+		misses  []string
+	}{
+		"glob": {
+			pattern: "public.*",
+			matches: []string{"public.users", "public.orders"},
+			misses:  []string{"sales.accounts", "public"},
+		},
+		"regular expression": {
+			pattern: "/^public\\.(users|orders)$/",
+			matches: []string{"public.users", "public.orders"},
+			misses:  []string{"public.audit_log", "sales.users"},
+		},
+		"slash": {
+			pattern: "/",
+			matches: []string{"/"},
+			misses:  []string{"public.users"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			match, err := schemaFilter(tc.pattern)
+			require.NoError(t, err)
+			for _, id := range tc.matches {
+				assert.True(t, match(id), id)
+			}
+			for _, id := range tc.misses {
+				assert.False(t, match(id), id)
+			}
+		})
+	}
+
+	for _, pattern := range []string{"public.[a", "/public.(/"} {
+		_, err := schemaFilter(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
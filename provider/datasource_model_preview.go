@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/polytomic-go"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// maxModelSampleRows is the most rows a model preview may sample.
+const maxModelSampleRows = 100
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &modelPreviewDatasource{}
+
+type modelPreviewDatasource struct {
+	provider *providerclient.Provider
+}
+
+func (d *modelPreviewDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		d.provider = provider
+	}
+}
+
+func (d *modelPreviewDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_preview"
+}
+
+func (d *modelPreviewDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: ":meta:subcategory:Models: Model Preview",
+		Attributes: map[string]schema.Attribute{
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "",
+				Required:            true,
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "",
+				Optional:            true,
+				Description:         "The organization to which the connection belongs. This is required when using a partner or deployment key.",
+			},
+			"configuration": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded model configuration, as passed to `polytomic_model`.",
+				Required:            true,
+			},
+			"sample_rows": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of rows of the model to sample, up to %d. Rows are only sampled when this is set.", maxModelSampleRows),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxModelSampleRows),
+				},
+			},
+			"fields": schema.ListAttribute{
+				MarkdownDescription: "The fields of the model, in the order returned by the connection.",
+				ElementType:         types.ObjectType{AttrTypes: previewField{}.AttrTypes()},
+				Computed:            true,
+			},
+			"identifier": schema.StringAttribute{
+				MarkdownDescription: "The suggested identifier of the model: the identifier reported by the connection, or a field named `id`. Null if there is no suggestion.",
+				Computed:            true,
+			},
+			"tracking_columns": schema.ListAttribute{
+				MarkdownDescription: "Fields which may be used as tracking columns: date and time fields, with fields whose names suggest they record modification first.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"samples": schema.ListAttribute{
+				MarkdownDescription: "JSON encoded sample rows of the model. Null unless `sample_rows` is set.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+type modelPreviewDatasourceData struct {
+	ConnectionID    types.String `tfsdk:"connection_id"`
+	Organization    types.String `tfsdk:"organization"`
+	Configuration   types.String `tfsdk:"configuration"`
+	SampleRows      types.Int64  `tfsdk:"sample_rows"`
+	Fields          types.List   `tfsdk:"fields"`
+	Identifier      types.String `tfsdk:"identifier"`
+	TrackingColumns types.List   `tfsdk:"tracking_columns"`
+	Samples         types.List   `tfsdk:"samples"`
+}
+
+type previewField struct {
+	Name  string `tfsdk:"name"`
+	Type  string `tfsdk:"type"`
+	Label string `tfsdk:"label"`
+}
+
+func (previewField) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":  types.StringType,
+		"type":  types.StringType,
+		"label": types.StringType,
+	}
+}
+
+func (d *modelPreviewDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data modelPreviewDatasourceData
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configuration map[string]interface{}
+	if err := json.Unmarshal([]byte(data.Configuration.ValueString()), &configuration); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("configuration"), "Invalid model configuration", err.Error())
+		return
+	}
+	request := &polytomic.CreateModelRequest{
+		Name:          "preview",
+		ConnectionId:  data.ConnectionID.ValueString(),
+		Configuration: configuration,
+	}
+	if !data.Organization.IsNull() && data.Organization.ValueString() != "" {
+		request.OrganizationId = data.Organization.ValueStringPointer()
+	}
+
+	client, err := d.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	preview, err := client.Models.Preview(ctx, &polytomic.ModelsPreviewRequest{Body: request})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error previewing model: %s", err))
+		return
+	}
+
+	fields := make([]previewField, 0, len(preview.Data.Fields))
+	for _, f := range preview.Data.Fields {
+		fields = append(fields, previewField{
+			Name:  pointer.GetString(f.Name),
+			Type:  pointer.GetString(f.Type),
+			Label: pointer.GetString(f.Label),
+		})
+	}
+	var diags diag.Diagnostics
+	data.Fields, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: previewField{}.AttrTypes()}, fields)
+	resp.Diagnostics.Append(diags...)
+	data.TrackingColumns, diags = types.ListValueFrom(ctx, types.StringType, trackingColumnCandidates(fields))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Identifier = types.StringNull()
+	if identifier := suggestIdentifier(pointer.GetString(preview.Data.Identifier), fields); identifier != "" {
+		data.Identifier = types.StringValue(identifier)
+	}
+
+	data.Samples = types.ListNull(types.StringType)
+	if !data.SampleRows.IsNull() {
+		sample, err := client.Models.Sample(ctx, &polytomic.ModelsSampleRequest{
+			Limit: pointer.ToInt(int(data.SampleRows.ValueInt64())),
+			Body:  request,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error sampling model: %s", err))
+			return
+		}
+		samples := make([]string, 0, len(sample.Data))
+		for _, row := range sample.Data {
+			enc, err := json.Marshal(row)
+			if err != nil {
+				resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error encoding sample row: %s", err))
+				return
+			}
+			samples = append(samples, string(enc))
+		}
+		data.Samples, diags = types.ListValueFrom(ctx, types.StringType, samples)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// suggestIdentifier returns the identifier to suggest for a model: the
+// identifier reported by the API, if any, or else a field named id. It
+// returns an empty string if there is no suggestion.
+func suggestIdentifier(identifier string, fields []previewField) string {
+	if identifier != "" {
+		return identifier
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, "id") {
+			return f.Name
+		}
+	}
+	return ""
+}
+
+// trackingColumnTypes are the field types which may be used as tracking
+// columns.
+var trackingColumnTypes = []string{"date", "datetime", "timestamp"}
+
+// trackingColumnCandidates returns the names of fields which may be used as
+// tracking columns. Fields whose names suggest they record when a row was
+// modified are returned first; otherwise fields keep their order.
+func trackingColumnCandidates(fields []previewField) []string {
+	var candidates []previewField
+	for _, f := range fields {
+		if slices.ContainsFunc(trackingColumnTypes, func(t string) bool { return strings.EqualFold(f.Type, t) }) {
+			candidates = append(candidates, f)
+		}
+	}
+	modified := func(f previewField) bool {
+		name := strings.ToLower(f.Name)
+		return strings.Contains(name, "updated") || strings.Contains(name, "modified")
+	}
+	slices.SortStableFunc(candidates, func(a, b previewField) int {
+		switch {
+		case modified(a) && !modified(b):
+			return -1
+		case modified(b) && !modified(a):
+			return 1
+		}
+		return 0
+	})
+
+	names := make([]string, len(candidates))
+	for i, f := range candidates {
+		names[i] = f.Name
+	}
+	return names
+}
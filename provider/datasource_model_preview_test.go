@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestIdentifier(t *testing.T) {
+	fields := []previewField{{Name: "email"}, {Name: "ID"}}
+	assert.Equal(t, "email", suggestIdentifier("email", fields))
+	assert.Equal(t, "ID", suggestIdentifier("", fields))
+	assert.Equal(t, "", suggestIdentifier("", []previewField{{Name: "user_id"}}))
+}
+
+func TestTrackingColumnCandidates(t *testing.T) {
+	fields := []previewField{
+		{Name: "created_at", Type: "datetime"},
+		{Name: "id", Type: "integer"},
+		{Name: "birthday", Type: "Date"},
+		{Name: "LastModified", Type: "timestamp"},
+		{Name: "updated_at", Type: "datetime"},
+		{Name: "updated_by", Type: "string"},
+	}
+	assert.Equal(t,
+		[]string{"LastModified", "updated_at", "created_at", "birthday"},
+		trackingColumnCandidates(fields))
+	assert.Empty(t, trackingColumnCandidates([]previewField{{Name: "id", Type: "integer"}}))
+}
+
+func TestModelPreviewDataSource(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	connID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "warehouse", "type": "postgresql"})
+	server.SetModelPreview(connID, fakeapi.Object{
+		"fields": []any{
+			fakeapi.Object{"name": "id", "type": "integer", "label": "ID"},
+			fakeapi.Object{"name": "email", "type": "string", "label": "Email"},
+			fakeapi.Object{"name": "updated_at", "type": "datetime", "label": "Updated At"},
+		},
+		"samples": []any{
+			fakeapi.Object{"id": 1, "email": "a@example.com"},
+			fakeapi.Object{"id": 2, "email": "b@example.com"},
+		},
+	})
+
+	config := func(extra string) string {
+		return fmt.Sprintf(`
+data "polytomic_model_preview" "test" {
+  connection_id = %q
+  configuration = jsonencode({ table = "public.users" })
+%s
+}
+`, connID, extra)
+	}
+	field := func(name, typ, label string) knownvalue.Check {
+		return knownvalue.ObjectExact(map[string]knownvalue.Check{
+			"name":  knownvalue.StringExact(name),
+			"type":  knownvalue.StringExact(typ),
+			"label": knownvalue.StringExact(label),
+		})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.polytomic_model_preview.test",
+						tfjsonpath.New("fields"),
+						knownvalue.ListExact([]knownvalue.Check{
+							field("id", "integer", "ID"),
+							field("email", "string", "Email"),
+							field("updated_at", "datetime", "Updated At"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.polytomic_model_preview.test",
+						tfjsonpath.New("identifier"),
+						knownvalue.StringExact("id"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.polytomic_model_preview.test",
+						tfjsonpath.New("tracking_columns"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("updated_at")}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.polytomic_model_preview.test",
+						tfjsonpath.New("samples"),
+						knownvalue.Null(),
+					),
+				},
+				Check: func(*terraform.State) error {
+					if n := server.ModelSamples(connID); n != 0 {
+						return fmt.Errorf("expected no samples to be requested, got %d", n)
+					}
+					return nil
+				},
+			},
+			{
+				Config: config("  sample_rows = 1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.polytomic_model_preview.test",
+						tfjsonpath.New("samples"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact(`{"email":"a@example.com","id":1}`),
+						}),
+					),
+				},
+			},
+			{
+				Config:      config("  sample_rows = 1000"),
+				ExpectError: regexp.MustCompile(`must be between 1 and 100`),
+			},
+		},
+	})
+}
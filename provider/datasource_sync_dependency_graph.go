@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &syncDependencyGraphDatasource{}
+
+type syncDependencyGraphDatasource struct {
+	provider *providerclient.Provider
+}
+
+type syncDependencyGraphDatasourceData struct {
+	Organization types.String `tfsdk:"organization"`
+	Nodes        types.List   `tfsdk:"nodes"`
+	Edges        types.List   `tfsdk:"edges"`
+	Roots        types.List   `tfsdk:"roots"`
+	Cycles       types.List   `tfsdk:"cycles"`
+	Missing      types.List   `tfsdk:"missing"`
+}
+
+type syncDependencyGraphNode struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Active types.Bool   `tfsdk:"active"`
+}
+
+func (syncDependencyGraphNode) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":     types.StringType,
+		"name":   types.StringType,
+		"type":   types.StringType,
+		"active": types.BoolType,
+	}
+}
+
+type syncDependencyGraphEdge struct {
+	From types.String `tfsdk:"from"`
+	To   types.String `tfsdk:"to"`
+}
+
+func (syncDependencyGraphEdge) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"from": types.StringType,
+		"to":   types.StringType,
+	}
+}
+
+func (d *syncDependencyGraphDatasource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		d.provider = provider
+	}
+}
+
+func (d *syncDependencyGraphDatasource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sync_dependency_graph"
+}
+
+func (d *syncDependencyGraphDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: ":meta:subcategory:Model Syncs: Sync Dependency Graph\n\n" +
+			"Returns the organization's run-after dependency graph: every model sync and bulk sync, " +
+			"the `schedule.run_after` edges between them, the syncs that start a chain, and any cycles.",
+		Attributes: map[string]schema.Attribute{
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "Organization ID",
+				Optional:            true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				MarkdownDescription: "Model syncs and bulk syncs in the organization, ordered by ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Sync ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Sync name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Either `sync` or `bulk_sync`.",
+							Computed:            true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the sync is active. Syncs which run after an inactive sync are never triggered.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"edges": schema.ListNestedAttribute{
+				MarkdownDescription: "Run-after dependencies, ordered by `from` then `to`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"from": schema.StringAttribute{
+							MarkdownDescription: "ID of the upstream sync.",
+							Computed:            true,
+						},
+						"to": schema.StringAttribute{
+							MarkdownDescription: "ID of the sync that runs after `from`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"roots": schema.ListAttribute{
+				MarkdownDescription: "IDs of syncs which start a run-after chain: they have downstream syncs but do not run after anything themselves.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"cycles": schema.ListAttribute{
+				MarkdownDescription: "Groups of sync IDs which transitively run after themselves. Syncs in a cycle are never triggered by their run-after schedule.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+			"missing": schema.ListAttribute{
+				MarkdownDescription: "IDs referenced by `run_after` which no longer exist.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *syncDependencyGraphDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data syncDependencyGraphDatasourceData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+
+	graph, err := loadSyncGraph(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary,
+			fmt.Sprintf("Error reading sync dependency graph: %s", err))
+		return
+	}
+
+	nodes := []syncDependencyGraphNode{}
+	for _, n := range graph.sortedNodes() {
+		nodes = append(nodes, syncDependencyGraphNode{
+			ID:     types.StringValue(n.ID),
+			Name:   types.StringValue(n.Name),
+			Type:   types.StringValue(n.Type),
+			Active: types.BoolValue(n.Active),
+		})
+	}
+	edges := []syncDependencyGraphEdge{}
+	for _, e := range graph.edges() {
+		edges = append(edges, syncDependencyGraphEdge{
+			From: types.StringValue(e.From),
+			To:   types.StringValue(e.To),
+		})
+	}
+	roots := append([]string{}, graph.roots()...)
+	cycles := append([][]string{}, graph.cycles()...)
+	missing := append([]string{}, graph.missing()...)
+
+	var diags diag.Diagnostics
+	data.Nodes, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: syncDependencyGraphNode{}.AttrTypes()}, nodes)
+	resp.Diagnostics.Append(diags...)
+	data.Edges, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: syncDependencyGraphEdge{}.AttrTypes()}, edges)
+	resp.Diagnostics.Append(diags...)
+	data.Roots, diags = types.ListValueFrom(ctx, types.StringType, roots)
+	resp.Diagnostics.Append(diags...)
+	data.Cycles, diags = types.ListValueFrom(ctx, types.ListType{ElemType: types.StringType}, cycles)
+	resp.Diagnostics.Append(diags...)
+	data.Missing, diags = types.ListValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &cronToScheduleFunction{}
+
+var cronScheduleAttrTypes = map[string]attr.Type{
+	"frequency":    types.StringType,
+	"minute":       types.StringType,
+	"hour":         types.StringType,
+	"day_of_month": types.StringType,
+	"month":        types.StringType,
+	"day_of_week":  types.StringType,
+}
+
+// cronMacros are the non-standard cron macros which map to a five field
+// expression.
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+var (
+	cronDaysOfWeek = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+	cronMonths     = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+)
+
+// cronSchedule is a sync schedule equivalent to a cron expression. Fields
+// which don't apply to the frequency are nil.
+type cronSchedule struct {
+	Frequency  string
+	Minute     *string
+	Hour       *string
+	DayOfMonth *string
+	Month      *string
+	DayOfWeek  *string
+}
+
+// cronToSchedule converts a five field cron expression to a sync schedule.
+// Expressions which run every hour, day or week at a fixed time use the
+// hourly, daily and weekly frequencies; all others use the custom frequency.
+func cronToSchedule(expr string) (cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("expected a cron expression with 5 fields, got %d: %q", len(fields), expr)
+	}
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	days := make([]string, len(cronDaysOfWeek))
+	for i, day := range cronDaysOfWeek {
+		days[i] = day[:3]
+	}
+	bounds := []struct {
+		name     string
+		min, max int
+		names    []string
+	}{
+		{"minute", 0, 59, nil},
+		{"hour", 0, 23, nil},
+		{"day of month", 1, 31, nil},
+		{"month", 1, 12, cronMonths},
+		{"day of week", 0, 7, days},
+	}
+	for i, f := range fields {
+		if err := validateCronField(f, bounds[i].min, bounds[i].max, bounds[i].names); err != nil {
+			return cronSchedule{}, fmt.Errorf("invalid %s %q: %w", bounds[i].name, f, err)
+		}
+	}
+
+	_, fixedMinute := cronNumber(minute)
+	_, fixedHour := cronNumber(hour)
+	day, fixedDay := cronDayOfWeek(dow)
+	switch {
+	case fixedMinute && hour == "*" && dom == "*" && month == "*" && dow == "*":
+		return cronSchedule{Frequency: "hourly", Minute: &minute}, nil
+	case fixedMinute && fixedHour && dom == "*" && month == "*" && dow == "*":
+		return cronSchedule{Frequency: "daily", Minute: &minute, Hour: &hour}, nil
+	case fixedMinute && fixedHour && dom == "*" && month == "*" && fixedDay:
+		return cronSchedule{Frequency: "weekly", Minute: &minute, Hour: &hour, DayOfWeek: &day}, nil
+	}
+	return cronSchedule{
+		Frequency:  "custom",
+		Minute:     &minute,
+		Hour:       &hour,
+		DayOfMonth: &dom,
+		Month:      &month,
+		DayOfWeek:  &dow,
+	}, nil
+}
+
+// validateCronField checks that each value in a cron field is in range or
+// one of names. Lists, ranges and steps are permitted.
+func validateCronField(field string, min, max int, names []string) error {
+	for _, part := range strings.Split(field, ",") {
+		values, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, ok := cronNumber(step); !ok || n == 0 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+		if values == "*" {
+			continue
+		}
+		lo, hi, _ := strings.Cut(values, "-")
+		for _, v := range []string{lo, hi} {
+			if v == "" {
+				continue
+			}
+			n, ok := cronNumber(v)
+			if !ok {
+				if slices.Contains(names, strings.ToLower(v)) {
+					continue
+				}
+				return fmt.Errorf("%q is not a number", v)
+			}
+			if n < min || n > max {
+				return fmt.Errorf("%d is out of range %d-%d", n, min, max)
+			}
+		}
+	}
+	return nil
+}
+
+// cronNumber returns the value of a single numeric cron field.
+func cronNumber(field string) (int, bool) {
+	n, err := strconv.Atoi(field)
+	return n, err == nil
+}
+
+// cronDayOfWeek returns the day name for a single day of week cron field,
+// which may be a number (0 or 7 for Sunday) or an abbreviated name.
+func cronDayOfWeek(field string) (string, bool) {
+	if n, ok := cronNumber(field); ok {
+		if n < 0 || n > 7 {
+			return "", false
+		}
+		return cronDaysOfWeek[n%7], true
+	}
+	for _, day := range cronDaysOfWeek {
+		if strings.EqualFold(field, day[:3]) {
+			return day, true
+		}
+	}
+	return "", false
+}
+
+func NewCronToScheduleFunction() function.Function {
+	return &cronToScheduleFunction{}
+}
+
+type cronToScheduleFunction struct{}
+
+func (f *cronToScheduleFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cron_to_schedule"
+}
+
+func (f *cronToScheduleFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert a cron expression to a sync schedule",
+		MarkdownDescription: "Converts a five field cron expression, or a macro such as `@daily`, to a value for the " +
+			"`schedule` attribute of `polytomic_sync`. Expressions which run every hour, day or week at a fixed " +
+			"time use the `hourly`, `daily` and `weekly` frequencies; all others use the `custom` frequency.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "expression",
+				MarkdownDescription: "Cron expression, e.g. `30 6 * * 1`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: cronScheduleAttrTypes,
+		},
+	}
+}
+
+func (f *cronToScheduleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expr string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &expr))
+	if resp.Error != nil {
+		return
+	}
+
+	schedule, err := cronToSchedule(expr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	result, diags := types.ObjectValue(cronScheduleAttrTypes, map[string]attr.Value{
+		"frequency":    types.StringValue(schedule.Frequency),
+		"minute":       types.StringPointerValue(schedule.Minute),
+		"hour":         types.StringPointerValue(schedule.Hour),
+		"day_of_month": types.StringPointerValue(schedule.DayOfMonth),
+		"month":        types.StringPointerValue(schedule.Month),
+		"day_of_week":  types.StringPointerValue(schedule.DayOfWeek),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronToSchedule(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		expected  cronSchedule
+		expectErr bool
+	}{
+		{
+			name:     "hourly",
+			input:    "15 * * * *",
+			expected: cronSchedule{Frequency: "hourly", Minute: pointer.ToString("15")},
+		},
+		{
+			name:     "daily",
+			input:    "30 6 * * *",
+			expected: cronSchedule{Frequency: "daily", Minute: pointer.ToString("30"), Hour: pointer.ToString("6")},
+		},
+		{
+			name:  "weekly",
+			input: "0 9 * * 1",
+			expected: cronSchedule{
+				Frequency: "weekly",
+				Minute:    pointer.ToString("0"),
+				Hour:      pointer.ToString("9"),
+				DayOfWeek: pointer.ToString("monday"),
+			},
+		},
+		{
+			name:  "weekly by name",
+			input: "0 9 * * SUN",
+			expected: cronSchedule{
+				Frequency: "weekly",
+				Minute:    pointer.ToString("0"),
+				Hour:      pointer.ToString("9"),
+				DayOfWeek: pointer.ToString("sunday"),
+			},
+		},
+		{
+			name:     "macro",
+			input:    "@daily",
+			expected: cronSchedule{Frequency: "daily", Minute: pointer.ToString("0"), Hour: pointer.ToString("0")},
+		},
+		{
+			name:  "custom",
+			input: "*/15 9-17 * jan-jun mon-fri",
+			expected: cronSchedule{
+				Frequency:  "custom",
+				Minute:     pointer.ToString("*/15"),
+				Hour:       pointer.ToString("9-17"),
+				DayOfMonth: pointer.ToString("*"),
+				Month:      pointer.ToString("jan-jun"),
+				DayOfWeek:  pointer.ToString("mon-fri"),
+			},
+		},
+		{
+			name:      "too few fields",
+			input:     "0 9 * *",
+			expectErr: true,
+		},
+		{
+			name:      "out of range",
+			input:     "0 24 * * *",
+			expectErr: true,
+		},
+		{
+			name:      "invalid step",
+			input:     "*/0 * * * *",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := cronToSchedule(tc.input)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
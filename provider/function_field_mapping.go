@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &fieldMappingFunction{}
+
+var (
+	fieldMappingSourceAttrTypes = map[string]attr.Type{
+		"model_id": types.StringType,
+		"field":    types.StringType,
+	}
+	fieldMappingAttrTypes = map[string]attr.Type{
+		"source": types.ObjectType{AttrTypes: fieldMappingSourceAttrTypes},
+		"target": types.StringType,
+	}
+)
+
+func NewFieldMappingFunction() function.Function {
+	return &fieldMappingFunction{}
+}
+
+type fieldMappingFunction struct{}
+
+func (f *fieldMappingFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "field_mapping"
+}
+
+func (f *fieldMappingFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build sync fields from a map",
+		MarkdownDescription: "Returns a value for the `fields` attribute of `polytomic_sync` from a map of target " +
+			"field to source model field, e.g. `{ Email = \"email\" }`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "model_id",
+				MarkdownDescription: "Source model ID",
+			},
+			function.MapParameter{
+				Name:                "fields",
+				MarkdownDescription: "Map of target field to source model field.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.SetReturn{
+			ElementType: types.ObjectType{AttrTypes: fieldMappingAttrTypes},
+		},
+	}
+}
+
+func (f *fieldMappingFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var modelID string
+	var fields map[string]string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &modelID, &fields))
+	if resp.Error != nil {
+		return
+	}
+	if modelID == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Value must be non-empty.")
+		return
+	}
+
+	elements := make([]attr.Value, 0, len(fields))
+	for target, field := range fields {
+		source, diags := types.ObjectValue(fieldMappingSourceAttrTypes, map[string]attr.Value{
+			"model_id": types.StringValue(modelID),
+			"field":    types.StringValue(field),
+		})
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		if resp.Error != nil {
+			return
+		}
+		element, diags := types.ObjectValue(fieldMappingAttrTypes, map[string]attr.Value{
+			"source": source,
+			"target": types.StringValue(target),
+		})
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		if resp.Error != nil {
+			return
+		}
+		elements = append(elements, element)
+	}
+
+	result, diags := types.SetValue(types.ObjectType{AttrTypes: fieldMappingAttrTypes}, elements)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
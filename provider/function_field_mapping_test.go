@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestFieldMappingFunction(t *testing.T) {
+	factories, _ := FakeProtoV6ProviderFactories(t)
+	mapping := func(field, target string) knownvalue.Check {
+		return knownvalue.ObjectExact(map[string]knownvalue.Check{
+			"source": knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"model_id": knownvalue.StringExact("model-1"),
+				"field":    knownvalue.StringExact(field),
+			}),
+			"target": knownvalue.StringExact(target),
+		})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "fields" {
+  value = provider::polytomic::field_mapping("model-1", {
+    Email     = "email"
+    FirstName = "first_name"
+  })
+}
+
+output "empty" {
+  value = provider::polytomic::field_mapping("model-1", {})
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("fields", knownvalue.SetExact([]knownvalue.Check{
+						mapping("email", "Email"),
+						mapping("first_name", "FirstName"),
+					})),
+					statecheck.ExpectKnownOutputValue("empty", knownvalue.SetSizeExact(0)),
+				},
+			},
+			{
+				Config: `
+output "fields" {
+  value = provider::polytomic::field_mapping("", { Email = "email" })
+}
+`,
+				ExpectError: regexp.MustCompile(`Value must be non-empty`),
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &schemaIDFunction{}
+var _ function.Function = &parseSchemaIDFunction{}
+
+// schemaID returns the composite identifier of a connection schema, as used by
+// polytomic_connection_schema_primary_keys.
+func schemaID(organization, connectionID, schemaID string) string {
+	return strings.Join([]string{organization, connectionID, schemaID}, "/")
+}
+
+// parseSchemaID splits a composite connection schema identifier into its
+// organization, connection and schema IDs. Schema IDs may contain "/", e.g.
+// for file-based connections, so everything after the connection ID is the
+// schema ID.
+func parseSchemaID(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("expected ID in format: organization/connection_id/schema_id, got: %s", id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func NewSchemaIDFunction() function.Function {
+	return &schemaIDFunction{}
+}
+
+type schemaIDFunction struct{}
+
+func (f *schemaIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "schema_id"
+}
+
+func (f *schemaIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a connection schema identifier",
+		MarkdownDescription: "Returns the identifier of a connection schema in the format `organization/connection_id/schema_id`, " +
+			"as used to import `polytomic_connection_schema_primary_keys`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "organization",
+				MarkdownDescription: "Organization ID",
+			},
+			function.StringParameter{
+				Name:                "connection_id",
+				MarkdownDescription: "Connection ID",
+			},
+			function.StringParameter{
+				Name:                "schema_id",
+				MarkdownDescription: "Schema ID",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *schemaIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var organization, connectionID, schema string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &organization, &connectionID, &schema))
+	if resp.Error != nil {
+		return
+	}
+
+	// schema IDs may contain "/", e.g. for file-based connections
+	for i, v := range []string{organization, connectionID} {
+		if v == "" || strings.Contains(v, "/") {
+			resp.Error = function.NewArgumentFuncError(int64(i), "Value must be non-empty and may not contain \"/\".")
+			return
+		}
+	}
+	if schema == "" {
+		resp.Error = function.NewArgumentFuncError(2, "Value must be non-empty.")
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, schemaID(organization, connectionID, schema)))
+}
+
+func NewParseSchemaIDFunction() function.Function {
+	return &parseSchemaIDFunction{}
+}
+
+type parseSchemaIDFunction struct{}
+
+var parseSchemaIDAttrTypes = map[string]attr.Type{
+	"organization":  types.StringType,
+	"connection_id": types.StringType,
+	"schema_id":     types.StringType,
+}
+
+func (f *parseSchemaIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_schema_id"
+}
+
+func (f *parseSchemaIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a connection schema identifier",
+		MarkdownDescription: "Parses an identifier in the format `organization/connection_id/schema_id`, returning an object " +
+			"with `organization`, `connection_id` and `schema_id` attributes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "Connection schema identifier",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseSchemaIDAttrTypes,
+		},
+	}
+}
+
+func (f *parseSchemaIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	organization, connectionID, schema, err := parseSchemaID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	result, diags := types.ObjectValue(parseSchemaIDAttrTypes, map[string]attr.Value{
+		"organization":  types.StringValue(organization),
+		"connection_id": types.StringValue(connectionID),
+		"schema_id":     types.StringValue(schema),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
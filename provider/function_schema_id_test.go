@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchemaID(t *testing.T) {
+	org, conn, schema, err := parseSchemaID(schemaID("org-1", "conn-1", "public.users"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"org-1", "conn-1", "public.users"}, []string{org, conn, schema})
+
+	// everything after the connection ID is the schema ID
+	_, _, schema, err = parseSchemaID("org-1/conn-1/files/users.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, "files/users.csv", schema)
+
+	for _, id := range []string{"org-1/conn-1", "org-1//public.users", "/conn-1/public.users", "org-1/conn-1/"} {
+		_, _, _, err = parseSchemaID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestSchemaIDFunctions(t *testing.T) {
+	factories, _ := FakeProtoV6ProviderFactories(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "id" {
+  value = provider::polytomic::schema_id("org-1", "conn-1", "files/users.csv")
+}
+
+output "parsed" {
+  value = provider::polytomic::parse_schema_id("org-1/conn-1/files/users.csv")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("id", knownvalue.StringExact("org-1/conn-1/files/users.csv")),
+					statecheck.ExpectKnownOutputValue("parsed", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"organization":  knownvalue.StringExact("org-1"),
+						"connection_id": knownvalue.StringExact("conn-1"),
+						"schema_id":     knownvalue.StringExact("files/users.csv"),
+					})),
+				},
+			},
+			{
+				Config: `
+output "id" {
+  value = provider::polytomic::schema_id("org-1", "conn/1", "public.users")
+}
+`,
+				ExpectError: regexp.MustCompile(`may not contain "/"`),
+			},
+			{
+				Config: `
+output "parsed" {
+  value = provider::polytomic::parse_schema_id("org-1/conn-1")
+}
+`,
+				ExpectError: regexp.MustCompile(`expected ID in format`),
+			},
+		},
+	})
+}
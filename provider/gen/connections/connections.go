@@ -88,14 +88,14 @@ var (
 			GoType: "string",
 		},
 		"number": {
-			AttrType:     "schema.NumberAttribute",
-			TfType:       "Number",
-			ReadAttrType: "types.NumberType",
+			AttrType:     "schema.Float64Attribute",
+			TfType:       "Float64",
+			ReadAttrType: "types.Float64Type",
 			Default: DefaultValue{
-				Value:  "int64default.StaticInt64(0)",
-				Import: "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default",
+				Value:  "float64default.StaticFloat64(0)",
+				Import: "github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default",
 			},
-			GoType: "int64",
+			GoType: "float64",
 		},
 		"bool": {
 			AttrType:     "schema.BoolAttribute",
@@ -112,7 +112,7 @@ var (
 		"int": {
 			AttrType:     "schema.Int64Attribute",
 			TfType:       "Int64",
-			ReadAttrType: "types.NumberType",
+			ReadAttrType: "types.Int64Type",
 			Default: DefaultValue{
 				Value:  "int64default.StaticInt64(0)",
 				Import: "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default",
@@ -122,7 +122,7 @@ var (
 		"int64": {
 			AttrType:     "schema.Int64Attribute",
 			TfType:       "Int64",
-			ReadAttrType: "types.NumberType",
+			ReadAttrType: "types.Int64Type",
 			Default: DefaultValue{
 				Value:  "int64default.StaticInt64(0)",
 				Import: "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default",
@@ -132,7 +132,7 @@ var (
 		"integer": {
 			AttrType:     "schema.Int64Attribute",
 			TfType:       "Int64",
-			ReadAttrType: "types.NumberType",
+			ReadAttrType: "types.Int64Type",
 			Default: DefaultValue{
 				Value:  "int64default.StaticInt64(0)",
 				Import: "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default",
@@ -142,6 +142,13 @@ var (
 	}
 )
 
+// integerFormats are the JSON schema formats of numbers which are integers.
+var integerFormats = map[string]bool{
+	"int32":   true,
+	"int64":   true,
+	"integer": true,
+}
+
 type DefaultValue struct {
 	Value  string
 	Import string
@@ -179,6 +186,9 @@ type Connection struct {
 	Resource     bool            `yaml:"resource"`
 	ExtraImports map[string]bool `yaml:"-"`
 	Imports      string          `yaml:"-"`
+	// SchemaVersion is the version of the resource's schema; see
+	// nextSchemaVersion.
+	SchemaVersion int64 `yaml:"-"`
 }
 
 // AttrCondition describes when an attribute is applicable, based on
@@ -228,18 +238,22 @@ var defaultImports = `
 "github.com/AlekSi/pointer"
 "github.com/mitchellh/mapstructure"
 "github.com/hashicorp/terraform-plugin-framework/attr"
+"github.com/hashicorp/terraform-plugin-framework/list"
 "github.com/hashicorp/terraform-plugin-framework/path"
 "github.com/hashicorp/terraform-plugin-framework/resource"
 "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 "github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 "github.com/hashicorp/terraform-plugin-framework/types"
 "github.com/hashicorp/terraform-plugin-log/tflog"
 "github.com/polytomic/polytomic-go"
 ptcore "github.com/polytomic/polytomic-go/core"
+"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 "github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 `
 
 type Importable struct {
@@ -319,6 +333,12 @@ func GenerateConnections(ctx context.Context) error {
 		return err
 	}
 
+	prevVersions, err := readSchemaVersions(schemaVersionsPath)
+	if err != nil {
+		return err
+	}
+	versions := map[string]SchemaVersion{}
+
 	resources := []Importable{}
 	datasources := []Importable{}
 
@@ -371,6 +391,13 @@ func GenerateConnections(ctx context.Context) error {
 			r.Name = strings.Title(r.Connection)
 		}
 		if r.Resource {
+			var prev *SchemaVersion
+			if v, ok := prevVersions[r.Connection]; ok {
+				prev = &v
+			}
+			versions[r.Connection] = nextSchemaVersion(prev, r.Attributes)
+			r.SchemaVersion = versions[r.Connection].Version
+
 			err := writeConnectionResource(r)
 			if err != nil {
 				return err
@@ -415,6 +442,11 @@ func GenerateConnections(ctx context.Context) error {
 		return err
 	}
 
+	err = writeSchemaVersions(schemaVersionsPath, versions)
+	if err != nil {
+		return fmt.Errorf("error writing schema versions: %w", err)
+	}
+
 	// Build the set of connection IDs that were generated so we can
 	// remove orphaned artifacts from previous runs.
 	generated := make(map[string]bool, len(data))
@@ -430,6 +462,12 @@ func GenerateConnections(ctx context.Context) error {
 	return nil
 }
 
+// handWrittenConnections are connection resources which aren't generated but
+// whose artifacts follow the generated naming convention.
+var handWrittenConnections = map[string]bool{
+	"oauth": true,
+}
+
 // cleanupOrphanedConnections removes generated files for connection types
 // that no longer exist in the API response.
 func cleanupOrphanedConnections(generated map[string]bool) error {
@@ -462,7 +500,10 @@ func cleanupOrphanedConnections(generated map[string]bool) error {
 		}
 		for _, e := range entries {
 			name := e.Name()
-			if !strings.HasPrefix(name, t.prefix) || !strings.HasSuffix(name, t.suffix) {
+			// The length check keeps hand-written artifacts for the generic
+			// connection (e.g. polytomic_connection) from matching.
+			if len(name) <= len(t.prefix)+len(t.suffix) ||
+				!strings.HasPrefix(name, t.prefix) || !strings.HasSuffix(name, t.suffix) {
 				continue
 			}
 			connID := strings.TrimPrefix(name, t.prefix)
@@ -470,7 +511,7 @@ func cleanupOrphanedConnections(generated map[string]bool) error {
 			if connID == "" {
 				continue
 			}
-			if generated[connID] {
+			if generated[connID] || handWrittenConnections[connID] {
 				continue
 			}
 			path := filepath.Join(t.dir, name)
@@ -749,6 +790,11 @@ func tfAttr(k string, a *jsonschema.Schema, required []string) (Attribute, error
 		}
 	}
 
+	// Numbers with an integer format are integers.
+	if schemaType == "number" && integerFormats[a.Format] {
+		schemaType = "integer"
+	}
+
 	t, ok := TypeMap[schemaType]
 	if !ok {
 		return Attribute{}, fmt.Errorf("type %s not found for %s", a.Type, k)
@@ -1018,14 +1064,15 @@ func writeConnectionResource(r Connection) error {
 
 	defer f.Close()
 	err = tmpl.Execute(&buf, Connection{
-		Name:         r.Name,
-		Conn:         r.Connection,
-		Connection:   strings.Title(r.Connection),
-		ResourceName: r.Connection,
-		Attributes:   r.Attributes,
-		Type:         r.Type,
-		Config:       r.Config,
-		Imports:      imports,
+		Name:          r.Name,
+		Conn:          r.Connection,
+		Connection:    strings.Title(r.Connection),
+		ResourceName:  r.Connection,
+		Attributes:    r.Attributes,
+		Type:          r.Type,
+		Config:        r.Config,
+		Imports:       imports,
+		SchemaVersion: r.SchemaVersion,
 	})
 	if err != nil {
 		log.Fatal(fmt.Errorf("error executing resource template: %w", err))
@@ -1057,7 +1104,7 @@ func displayType(attrType string) string {
 		return "String"
 	case "schema.BoolAttribute":
 		return "Boolean"
-	case "schema.NumberAttribute", "schema.Int64Attribute":
+	case "schema.NumberAttribute", "schema.Int64Attribute", "schema.Float64Attribute":
 		return "Number"
 	case "schema.SetAttribute":
 		return "Set of String"
@@ -333,3 +333,25 @@ func TestAttributesForJSONSchemaFiltersInternalHidden(t *testing.T) {
 		assert.Contains(t, names, "scope")
 	})
 }
+
+func TestAttributesForJSONSchemaNumbers(t *testing.T) {
+	props := jsonschema.NewProperties()
+	props.Set("port", &jsonschema.Schema{Type: "integer"})
+	props.Set("batch_size", &jsonschema.Schema{Type: "number", Format: "int64"})
+	props.Set("sample_rate", &jsonschema.Schema{Type: "number"})
+	props.Set("ports", &jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: "integer"}})
+
+	attrs, err := attributesForJSONSchema(&jsonschema.Schema{Type: "object", Properties: props})
+	require.NoError(t, err)
+	require.Len(t, attrs, 4)
+
+	types := map[string][3]string{}
+	for _, a := range attrs {
+		types[a.Name] = [3]string{a.AttrType, a.AttrReadType, a.Type}
+	}
+	assert.Equal(t, [3]string{"schema.Int64Attribute", "types.Int64Type", "int64"}, types["port"])
+	assert.Equal(t, [3]string{"schema.Int64Attribute", "types.Int64Type", "int64"}, types["batch_size"], "numbers with an integer format are integers")
+	assert.Equal(t, [3]string{"schema.Float64Attribute", "types.Float64Type", "float64"}, types["sample_rate"])
+	require.NotNil(t, attrs[3].Elem)
+	assert.Equal(t, "types.Int64Type", attrs[3].Elem.AttrReadType)
+}
@@ -0,0 +1,120 @@
+package connections
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/polytomic/terraform-provider-polytomic/internal/schemasnapshot"
+)
+
+// schemaVersionsPath records the schema version of each generated connection
+// resource, and the configuration attributes it was generated with.
+const schemaVersionsPath = "./provider/gen/connections/schema_versions.json"
+
+// SchemaVersion is the schema version of a generated connection resource.
+type SchemaVersion struct {
+	Version int64 `json:"version"`
+	// Attributes are the resource's configuration attributes, keyed by
+	// their path.
+	Attributes map[string]schemasnapshot.Attribute `json:"attributes"`
+}
+
+// readSchemaVersions reads the schema versions recorded by the previous
+// generation. A missing file is treated as empty, so every connection starts
+// at version 0.
+func readSchemaVersions(path string) (map[string]SchemaVersion, error) {
+	versions := map[string]SchemaVersion{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return versions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return versions, nil
+}
+
+func writeSchemaVersions(path string, versions map[string]SchemaVersion) error {
+	data, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// nextSchemaVersion returns the schema version of a connection generated with
+// attrs. The version is incremented from prev when an attribute is removed,
+// retyped or otherwise changed in a way that existing state or configuration
+// can't be decoded with; the generated resource then upgrades state from each
+// earlier version.
+func nextSchemaVersion(prev *SchemaVersion, attrs []Attribute) SchemaVersion {
+	next := SchemaVersion{Attributes: schemaAttributes(attrs)}
+	if prev == nil {
+		return next
+	}
+
+	next.Version = prev.Version
+	changes := schemasnapshot.BreakingChanges(
+		&schemasnapshot.Snapshot{Resources: map[string]schemasnapshot.Schema{"": {Attributes: prev.Attributes}}},
+		&schemasnapshot.Snapshot{Resources: map[string]schemasnapshot.Schema{"": {Attributes: next.Attributes}}},
+	)
+	if len(changes) > 0 {
+		next.Version++
+	}
+	return next
+}
+
+// schemaAttributes flattens attrs into the form recorded by schema snapshots.
+func schemaAttributes(attrs []Attribute) map[string]schemasnapshot.Attribute {
+	out := map[string]schemasnapshot.Attribute{}
+	addSchemaAttributes(out, "", attrs)
+	return out
+}
+
+func addSchemaAttributes(out map[string]schemasnapshot.Attribute, prefix string, attrs []Attribute) {
+	for _, a := range attrs {
+		path := prefix + a.AttrName
+		out[path] = schemasnapshot.Attribute{
+			Type:      schemaType(a),
+			Required:  a.Required,
+			Optional:  a.Optional,
+			Computed:  a.Computed,
+			Sensitive: a.Sensitive,
+		}
+		addSchemaAttributes(out, path+".", a.Attributes)
+		if a.Elem != nil {
+			addSchemaAttributes(out, path+".", a.Elem.Attributes)
+		}
+	}
+}
+
+// schemaType returns the snapshot type of an attribute: its Terraform type,
+// or the nesting mode of a nested attribute. It mirrors the schema emitted by
+// resource.go.tmpl. Int64 and Float64 attributes are stored in state as
+// numbers, so switching between them doesn't change the schema version.
+func schemaType(a Attribute) string {
+	switch a.AttrType {
+	case "schema.StringAttribute":
+		return "tftypes.String"
+	case "schema.BoolAttribute":
+		return "tftypes.Bool"
+	case "schema.NumberAttribute", "schema.Int64Attribute", "schema.Float64Attribute":
+		return "tftypes.Number"
+	case "schema.MapAttribute":
+		return "tftypes.Map[tftypes.String]"
+	case "schema.SingleNestedAttribute":
+		return "nested_single"
+	case "schema.SetNestedAttribute":
+		return "nested_set"
+	case "schema.SetAttribute":
+		if a.Elem == nil {
+			return "tftypes.Set[tftypes.String]"
+		}
+		return "tftypes.Set[" + schemaType(*a.Elem) + "]"
+	}
+	return a.AttrType
+}
@@ -0,0 +1,90 @@
+package connections
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/invopop/jsonschema"
+	"github.com/polytomic/terraform-provider-polytomic/internal/schemasnapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureAttributes(t *testing.T, name string) []Attribute {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "schema_versions", name+".json"))
+	require.NoError(t, err)
+	input := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(data, &input))
+	schema, err := unmarshalJSONSchema(input)
+	require.NoError(t, err)
+	attrs, err := attributesForJSONSchema(schema)
+	require.NoError(t, err)
+	return attrs
+}
+
+func TestSchemaAttributes(t *testing.T) {
+	assert.Equal(t, map[string]schemasnapshot.Attribute{
+		"hostname": {Type: "tftypes.String", Required: true},
+		"port":     {Type: "tftypes.Number", Optional: true, Computed: true},
+		"schema":   {Type: "tftypes.Set[tftypes.String]", Optional: true, Computed: true},
+		"ssh":      {Type: "nested_single", Optional: true, Computed: true},
+		"ssh.host": {Type: "tftypes.String", Optional: true, Computed: true},
+	}, schemaAttributes(fixtureAttributes(t, "after_breaking")))
+}
+
+func TestNextSchemaVersion(t *testing.T) {
+	before := nextSchemaVersion(nil, fixtureAttributes(t, "before"))
+	assert.Equal(t, int64(0), before.Version, "new connections start at version 0")
+
+	tests := map[string]struct {
+		prev    int64
+		after   string
+		version int64
+	}{
+		"unchanged":                  {prev: 0, after: "before", version: 0},
+		"optional attributes added":  {prev: 0, after: "after_compatible", version: 0},
+		"attributes removed/retyped": {prev: 0, after: "after_breaking", version: 1},
+		"later version":              {prev: 2, after: "after_breaking", version: 3},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			prev := before
+			prev.Version = test.prev
+			next := nextSchemaVersion(&prev, fixtureAttributes(t, test.after))
+			assert.Equal(t, test.version, next.Version)
+			assert.Equal(t, schemaAttributes(fixtureAttributes(t, test.after)), next.Attributes)
+		})
+	}
+}
+
+func TestNextSchemaVersionNumbers(t *testing.T) {
+	attrs := func(portType string) []Attribute {
+		props := jsonschema.NewProperties()
+		props.Set("port", &jsonschema.Schema{Type: portType})
+		attrs, err := attributesForJSONSchema(&jsonschema.Schema{Type: "object", Properties: props})
+		require.NoError(t, err)
+		return attrs
+	}
+
+	// integers and floating point numbers are both stored as numbers
+	prev := nextSchemaVersion(nil, attrs("integer"))
+	assert.Equal(t, int64(0), nextSchemaVersion(&prev, attrs("number")).Version)
+	assert.Equal(t, int64(0), nextSchemaVersion(&prev, attrs("integer")).Version)
+}
+
+func TestSchemaVersionsReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema_versions.json")
+	versions, err := readSchemaVersions(path)
+	require.NoError(t, err)
+	assert.Empty(t, versions, "a missing file has no versions")
+
+	versions["postgresql"] = nextSchemaVersion(nil, fixtureAttributes(t, "before"))
+	require.NoError(t, writeSchemaVersions(path, versions))
+	read, err := readSchemaVersions(path)
+	require.NoError(t, err)
+	assert.Equal(t, versions, read)
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"fmt"
 	"log"
+	"math/big"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -110,7 +111,17 @@ func attrValue(ctx context.Context, val attr.Value) (interface{}, error) {
 	case types.Float64:
 		return tv.ValueFloat64(), nil
 	case types.Number:
-		return tv.ValueBigFloat(), nil
+		// Connections model numbers as Int64 or Float64; convert any other
+		// number the same way so the API receives a JSON number.
+		if tv.IsNull() {
+			return nil, nil
+		}
+		f := tv.ValueBigFloat()
+		if i, acc := f.Int64(); acc == big.Exact {
+			return i, nil
+		}
+		v, _ := f.Float64()
+		return v, nil
 	case types.String:
 		return tv.ValueString(), nil
 	case types.Object:
@@ -1,12 +1,16 @@
 package connections
 
 import (
+	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAttrValue(t *testing.T) {
@@ -35,6 +39,18 @@ func TestAttrValue(t *testing.T) {
 				"age":  int64(42),
 			},
 		},
+		"integer number": {
+			val:      types.NumberValue(big.NewFloat(5432)),
+			expected: int64(5432),
+		},
+		"floating point number": {
+			val:      types.NumberValue(big.NewFloat(0.25)),
+			expected: 0.25,
+		},
+		"float64": {
+			val:      types.Float64Value(0.25),
+			expected: 0.25,
+		},
 		"sets": {
 			val: types.SetValueMust(types.StringType, []attr.Value{
 				types.StringValue("foo"),
@@ -56,6 +72,79 @@ func TestAttrValue(t *testing.T) {
 		})
 	}
 }
+
+// TestConfigurationRoundTrip decodes API responses the way generated
+// connection resources do, and checks the configuration is sent back to the
+// API with the same values and numeric types.
+func TestConfigurationRoundTrip(t *testing.T) {
+	type sshConf struct {
+		Host string `mapstructure:"host" tfsdk:"host"`
+		Port int64  `mapstructure:"port" tfsdk:"port"`
+	}
+	type conf struct {
+		Hostname   string  `mapstructure:"hostname" tfsdk:"hostname"`
+		Port       int64   `mapstructure:"port" tfsdk:"port"`
+		SampleRate float64 `mapstructure:"sample_rate" tfsdk:"sample_rate"`
+		Ports      []int64 `mapstructure:"ports" tfsdk:"ports"`
+		Ssh        sshConf `mapstructure:"ssh" tfsdk:"ssh"`
+	}
+	attrTypes := map[string]attr.Type{
+		"hostname":    types.StringType,
+		"port":        types.Int64Type,
+		"sample_rate": types.Float64Type,
+		"ports":       types.SetType{ElemType: types.Int64Type},
+		"ssh": types.ObjectType{AttrTypes: map[string]attr.Type{
+			"host": types.StringType,
+			"port": types.Int64Type,
+		}},
+	}
+	expected := map[string]any{
+		"hostname":    "db.example.com",
+		"port":        int64(5432),
+		"sample_rate": 0.25,
+		"ports":       []any{int64(80), int64(443)},
+		"ssh":         map[string]any{"host": "bastion", "port": int64(22)},
+	}
+
+	tests := map[string]map[string]any{
+		// encoding/json decodes numbers as float64
+		"float64 numbers": {
+			"hostname":    "db.example.com",
+			"port":        float64(5432),
+			"sample_rate": 0.25,
+			"ports":       []any{float64(80), float64(443)},
+			"ssh":         map[string]any{"host": "bastion", "port": float64(22)},
+		},
+		"json numbers": {
+			"hostname":    "db.example.com",
+			"port":        json.Number("5432"),
+			"sample_rate": json.Number("0.25"),
+			"ports":       []any{json.Number("80"), json.Number("443")},
+			"ssh":         map[string]any{"host": "bastion", "port": json.Number("22")},
+		},
+		"integer numbers": {
+			"hostname":    "db.example.com",
+			"port":        5432,
+			"sample_rate": 0.25,
+			"ports":       []any{80, 443},
+			"ssh":         map[string]any{"host": "bastion", "port": 22},
+		},
+	}
+	for name, payload := range tests {
+		t.Run(name, func(t *testing.T) {
+			var c conf
+			require.NoError(t, mapstructure.Decode(payload, &c))
+
+			value, diags := types.ObjectValueFrom(t.Context(), attrTypes, c)
+			require.False(t, diags.HasError(), diags)
+
+			actual, err := objectMapValue(t.Context(), value)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
 func TestHandleSensitiveValues(t *testing.T) {
 	tests := map[string]struct {
 		attrs      map[string]schema.Attribute
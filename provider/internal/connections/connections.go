@@ -5,6 +5,7 @@ package connections
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
@@ -237,6 +238,234 @@ var (
 		func() resource.Resource { return &ZoominfoConnectionResource{} },
 	}
 
+	ListResources = []func() list.ListResource{
+		func() list.ListResource { return &AffinityConnectionResource{} },
+		func() list.ListResource { return &AirtableConnectionResource{} },
+		func() list.ListResource { return &Amazon_keyspacesConnectionResource{} },
+		func() list.ListResource { return &Amazon_selling_partnerConnectionResource{} },
+		func() list.ListResource { return &AmplemarketConnectionResource{} },
+		func() list.ListResource { return &AmplitudeConnectionResource{} },
+		func() list.ListResource { return &ApiConnectionResource{} },
+		func() list.ListResource { return &ApolloConnectionResource{} },
+		func() list.ListResource { return &AppcuesConnectionResource{} },
+		func() list.ListResource { return &Apple_adsConnectionResource{} },
+		func() list.ListResource { return &AppsflyerConnectionResource{} },
+		func() list.ListResource { return &AppstoreconnectConnectionResource{} },
+		func() list.ListResource { return &AsanaConnectionResource{} },
+		func() list.ListResource { return &AscendConnectionResource{} },
+		func() list.ListResource { return &AshbyConnectionResource{} },
+		func() list.ListResource { return &AttioConnectionResource{} },
+		func() list.ListResource { return &Auth0ConnectionResource{} },
+		func() list.ListResource { return &AutumnConnectionResource{} },
+		func() list.ListResource { return &AuturaConnectionResource{} },
+		func() list.ListResource { return &AwsathenaConnectionResource{} },
+		func() list.ListResource { return &AwsopensearchConnectionResource{} },
+		func() list.ListResource { return &AzureblobConnectionResource{} },
+		func() list.ListResource { return &AzuresqlConnectionResource{} },
+		func() list.ListResource { return &BarbourabiConnectionResource{} },
+		func() list.ListResource { return &BasetenConnectionResource{} },
+		func() list.ListResource { return &BigqueryConnectionResource{} },
+		func() list.ListResource { return &BotpressConnectionResource{} },
+		func() list.ListResource { return &BrevoConnectionResource{} },
+		func() list.ListResource { return &CalendlyConnectionResource{} },
+		func() list.ListResource { return &CallrailConnectionResource{} },
+		func() list.ListResource { return &CampfireConnectionResource{} },
+		func() list.ListResource { return &ChameleonConnectionResource{} },
+		func() list.ListResource { return &ChargebeeConnectionResource{} },
+		func() list.ListResource { return &Chili_piperConnectionResource{} },
+		func() list.ListResource { return &ChorusConnectionResource{} },
+		func() list.ListResource { return &CircleConnectionResource{} },
+		func() list.ListResource { return &ClariConnectionResource{} },
+		func() list.ListResource { return &ClazarConnectionResource{} },
+		func() list.ListResource { return &ClerkConnectionResource{} },
+		func() list.ListResource { return &ClickhouseConnectionResource{} },
+		func() list.ListResource { return &Cloudflare_logsConnectionResource{} },
+		func() list.ListResource { return &Cloudflare_r2ConnectionResource{} },
+		func() list.ListResource { return &CloudtalkConnectionResource{} },
+		func() list.ListResource { return &Construct_connectConnectionResource{} },
+		func() list.ListResource { return &ConstructionwireConnectionResource{} },
+		func() list.ListResource { return &CosmosdbConnectionResource{} },
+		func() list.ListResource { return &CsvConnectionResource{} },
+		func() list.ListResource { return &CustomerioConnectionResource{} },
+		func() list.ListResource { return &CustomeriowarehouseexportsConnectionResource{} },
+		func() list.ListResource { return &DatabricksConnectionResource{} },
+		func() list.ListResource { return &DatadogConnectionResource{} },
+		func() list.ListResource { return &DayforceConnectionResource{} },
+		func() list.ListResource { return &DbtcloudConnectionResource{} },
+		func() list.ListResource { return &DbtprojectrepositoryConnectionResource{} },
+		func() list.ListResource { return &DealcloudConnectionResource{} },
+		func() list.ListResource { return &DelightedConnectionResource{} },
+		func() list.ListResource { return &DialpadConnectionResource{} },
+		func() list.ListResource { return &DittofeedConnectionResource{} },
+		func() list.ListResource { return &Docker_hubConnectionResource{} },
+		func() list.ListResource { return &DropboxConnectionResource{} },
+		func() list.ListResource { return &DubConnectionResource{} },
+		func() list.ListResource { return &DynamodbConnectionResource{} },
+		func() list.ListResource { return &Factors_aiConnectionResource{} },
+		func() list.ListResource { return &FathomConnectionResource{} },
+		func() list.ListResource { return &FbaudienceConnectionResource{} },
+		func() list.ListResource { return &Fireflies_aiConnectionResource{} },
+		func() list.ListResource { return &FreshdeskConnectionResource{} },
+		func() list.ListResource { return &FreshserviceConnectionResource{} },
+		func() list.ListResource { return &FrontConnectionResource{} },
+		func() list.ListResource { return &FullstoryConnectionResource{} },
+		func() list.ListResource { return &G2ConnectionResource{} },
+		func() list.ListResource { return &Gainsight_csConnectionResource{} },
+		func() list.ListResource { return &GatsbyConnectionResource{} },
+		func() list.ListResource { return &GcsConnectionResource{} },
+		func() list.ListResource { return &GithubConnectionResource{} },
+		func() list.ListResource { return &GladlyConnectionResource{} },
+		func() list.ListResource { return &GleanConnectionResource{} },
+		func() list.ListResource { return &GmailConnectionResource{} },
+		func() list.ListResource { return &GongConnectionResource{} },
+		func() list.ListResource { return &Google_search_ads_360ConnectionResource{} },
+		func() list.ListResource { return &GoogleadsConnectionResource{} },
+		func() list.ListResource { return &GoogleanalyticsConnectionResource{} },
+		func() list.ListResource { return &GooglecloudmysqlConnectionResource{} },
+		func() list.ListResource { return &GooglecloudsqlConnectionResource{} },
+		func() list.ListResource { return &GooglesearchconsoleConnectionResource{} },
+		func() list.ListResource { return &GoogleslidesConnectionResource{} },
+		func() list.ListResource { return &GoogleworkspaceConnectionResource{} },
+		func() list.ListResource { return &GorgiasConnectionResource{} },
+		func() list.ListResource { return &GreenhouseConnectionResource{} },
+		func() list.ListResource { return &GsheetsConnectionResource{} },
+		func() list.ListResource { return &HarmonicConnectionResource{} },
+		func() list.ListResource { return &HeapConnectionResource{} },
+		func() list.ListResource { return &HerondataConnectionResource{} },
+		func() list.ListResource { return &HeyreachConnectionResource{} },
+		func() list.ListResource { return &HighlevelConnectionResource{} },
+		func() list.ListResource { return &HighspotConnectionResource{} },
+		func() list.ListResource { return &HoneycombConnectionResource{} },
+		func() list.ListResource { return &HttpenrichmentConnectionResource{} },
+		func() list.ListResource { return &HubspotConnectionResource{} },
+		func() list.ListResource { return &HyperlineConnectionResource{} },
+		func() list.ListResource { return &Ibm_db2ConnectionResource{} },
+		func() list.ListResource { return &InstantlyConnectionResource{} },
+		func() list.ListResource { return &IntellimizeConnectionResource{} },
+		func() list.ListResource { return &IroncladConnectionResource{} },
+		func() list.ListResource { return &IterableConnectionResource{} },
+		func() list.ListResource { return &JiraConnectionResource{} },
+		func() list.ListResource { return &JuroConnectionResource{} },
+		func() list.ListResource { return &KlaviyoConnectionResource{} },
+		func() list.ListResource { return &KnockConnectionResource{} },
+		func() list.ListResource { return &KustomerConnectionResource{} },
+		func() list.ListResource { return &LagoConnectionResource{} },
+		func() list.ListResource { return &LearnworldsConnectionResource{} },
+		func() list.ListResource { return &LinearConnectionResource{} },
+		func() list.ListResource { return &LinkedinadsConnectionResource{} },
+		func() list.ListResource { return &LobConnectionResource{} },
+		func() list.ListResource { return &LoopConnectionResource{} },
+		func() list.ListResource { return &LoopsConnectionResource{} },
+		func() list.ListResource { return &LumaConnectionResource{} },
+		func() list.ListResource { return &M3terConnectionResource{} },
+		func() list.ListResource { return &MailercheckConnectionResource{} },
+		func() list.ListResource { return &MarketoConnectionResource{} },
+		func() list.ListResource { return &MixpanelConnectionResource{} },
+		func() list.ListResource { return &MondayConnectionResource{} },
+		func() list.ListResource { return &MongodbConnectionResource{} },
+		func() list.ListResource { return &MotherduckConnectionResource{} },
+		func() list.ListResource { return &MsadsConnectionResource{} },
+		func() list.ListResource { return &MsdynamicsConnectionResource{} },
+		func() list.ListResource { return &MssqlConnectionResource{} },
+		func() list.ListResource { return &MysqlConnectionResource{} },
+		func() list.ListResource { return &N8nConnectionResource{} },
+		func() list.ListResource { return &NetsuiteConnectionResource{} },
+		func() list.ListResource { return &NetsuiteopenairConnectionResource{} },
+		func() list.ListResource { return &NetsuitesaconnectConnectionResource{} },
+		func() list.ListResource { return &NorthbeamConnectionResource{} },
+		func() list.ListResource { return &NotionConnectionResource{} },
+		func() list.ListResource { return &Openai_adsConnectionResource{} },
+		func() list.ListResource { return &OutreachConnectionResource{} },
+		func() list.ListResource { return &PardotConnectionResource{} },
+		func() list.ListResource { return &PartnerpageConnectionResource{} },
+		func() list.ListResource { return &PaycorConnectionResource{} },
+		func() list.ListResource { return &Pinterest_adsConnectionResource{} },
+		func() list.ListResource { return &PipedriveConnectionResource{} },
+		func() list.ListResource { return &PitchbookConnectionResource{} },
+		func() list.ListResource { return &PlainConnectionResource{} },
+		func() list.ListResource { return &PlusvibeConnectionResource{} },
+		func() list.ListResource { return &Polytomic_metadataConnectionResource{} },
+		func() list.ListResource { return &PostgresqlConnectionResource{} },
+		func() list.ListResource { return &PosthogConnectionResource{} },
+		func() list.ListResource { return &PredictleadsConnectionResource{} },
+		func() list.ListResource { return &ProductboardConnectionResource{} },
+		func() list.ListResource { return &ProfoundConnectionResource{} },
+		func() list.ListResource { return &PylonConnectionResource{} },
+		func() list.ListResource { return &Qtanium_connectConnectionResource{} },
+		func() list.ListResource { return &QualtricsConnectionResource{} },
+		func() list.ListResource { return &QuickbooksConnectionResource{} },
+		func() list.ListResource { return &RampConnectionResource{} },
+		func() list.ListResource { return &RechargeConnectionResource{} },
+		func() list.ListResource { return &RedditadsConnectionResource{} },
+		func() list.ListResource { return &RedshiftConnectionResource{} },
+		func() list.ListResource { return &RedshiftserverlessConnectionResource{} },
+		func() list.ListResource { return &Reo_devConnectionResource{} },
+		func() list.ListResource { return &ReplyioConnectionResource{} },
+		func() list.ListResource { return &RewardfulConnectionResource{} },
+		func() list.ListResource { return &RilletConnectionResource{} },
+		func() list.ListResource { return &RipplingConnectionResource{} },
+		func() list.ListResource { return &RocketlaneConnectionResource{} },
+		func() list.ListResource { return &S3ConnectionResource{} },
+		func() list.ListResource { return &SageintacctConnectionResource{} },
+		func() list.ListResource { return &SalesbricksConnectionResource{} },
+		func() list.ListResource { return &SalesforceConnectionResource{} },
+		func() list.ListResource { return &SalesloftConnectionResource{} },
+		func() list.ListResource { return &ScamalyticsConnectionResource{} },
+		func() list.ListResource { return &ScylladbConnectionResource{} },
+		func() list.ListResource { return &Seal_subscriptionsConnectionResource{} },
+		func() list.ListResource { return &SeamaiConnectionResource{} },
+		func() list.ListResource { return &SegmentConnectionResource{} },
+		func() list.ListResource { return &SeismicConnectionResource{} },
+		func() list.ListResource { return &SftpConnectionResource{} },
+		func() list.ListResource { return &Sharepoint_excelConnectionResource{} },
+		func() list.ListResource { return &ShipbobConnectionResource{} },
+		func() list.ListResource { return &ShippoConnectionResource{} },
+		func() list.ListResource { return &ShopifyConnectionResource{} },
+		func() list.ListResource { return &ShortioConnectionResource{} },
+		func() list.ListResource { return &ShowpadConnectionResource{} },
+		func() list.ListResource { return &SlackConnectionResource{} },
+		func() list.ListResource { return &SmartleadConnectionResource{} },
+		func() list.ListResource { return &SmartsheetConnectionResource{} },
+		func() list.ListResource { return &SnowflakeConnectionResource{} },
+		func() list.ListResource { return &SprigConnectionResource{} },
+		func() list.ListResource { return &SproutsocialConnectionResource{} },
+		func() list.ListResource { return &Standard_metricsConnectionResource{} },
+		func() list.ListResource { return &StatsigConnectionResource{} },
+		func() list.ListResource { return &StordConnectionResource{} },
+		func() list.ListResource { return &StrackrConnectionResource{} },
+		func() list.ListResource { return &StripeConnectionResource{} },
+		func() list.ListResource { return &SurveymonkeyConnectionResource{} },
+		func() list.ListResource { return &SurvicateConnectionResource{} },
+		func() list.ListResource { return &SynapseConnectionResource{} },
+		func() list.ListResource { return &TabsConnectionResource{} },
+		func() list.ListResource { return &TestrailConnectionResource{} },
+		func() list.ListResource { return &ThriveConnectionResource{} },
+		func() list.ListResource { return &TigrisConnectionResource{} },
+		func() list.ListResource { return &Tiktok_adsConnectionResource{} },
+		func() list.ListResource { return &TixrConnectionResource{} },
+		func() list.ListResource { return &TowbookConnectionResource{} },
+		func() list.ListResource { return &Twilio_sendgridConnectionResource{} },
+		func() list.ListResource { return &TypeformConnectionResource{} },
+		func() list.ListResource { return &UnbounceConnectionResource{} },
+		func() list.ListResource { return &UpfluenceConnectionResource{} },
+		func() list.ListResource { return &UppromoteConnectionResource{} },
+		func() list.ListResource { return &UservoiceConnectionResource{} },
+		func() list.ListResource { return &VanillaConnectionResource{} },
+		func() list.ListResource { return &Walmart_marketplaceConnectionResource{} },
+		func() list.ListResource { return &Ware2goConnectionResource{} },
+		func() list.ListResource { return &WavelengthConnectionResource{} },
+		func() list.ListResource { return &WebhookConnectionResource{} },
+		func() list.ListResource { return &Work_osConnectionResource{} },
+		func() list.ListResource { return &XeroConnectionResource{} },
+		func() list.ListResource { return &YotpoConnectionResource{} },
+		func() list.ListResource { return &YoutubeanalyticsConnectionResource{} },
+		func() list.ListResource { return &Zendesk_chatConnectionResource{} },
+		func() list.ListResource { return &Zendesk_supportConnectionResource{} },
+		func() list.ListResource { return &Zoho_crmConnectionResource{} },
+		func() list.ListResource { return &Zoho_deskConnectionResource{} },
+		func() list.ListResource { return &ZoominfoConnectionResource{} },
+	}
+
 	Datasources = []func() datasource.DataSource{
 		func() datasource.DataSource { return &AffinityConnectionDataSource{} },
 		func() datasource.DataSource { return &AirtableConnectionDataSource{} },
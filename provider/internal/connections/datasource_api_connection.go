@@ -285,7 +285,7 @@ func (d *ApiConnectionDataSource) Read(ctx context.Context, req datasource.ReadR
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
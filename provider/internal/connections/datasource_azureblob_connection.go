@@ -183,7 +183,7 @@ func (d *AzureblobConnectionDataSource) Read(ctx context.Context, req datasource
 			ElemType: types.StringType,
 		},
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 		"tenant_id":         types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -161,10 +161,10 @@ func (d *AzuresqlConnectionDataSource) Read(ctx context.Context, req datasource.
 		"container_name": types.StringType,
 		"database":       types.StringType,
 		"hostname":       types.StringType,
-		"port":           types.NumberType,
+		"port":           types.Int64Type,
 		"ssh":            types.BoolType,
 		"ssh_host":       types.StringType,
-		"ssh_port":       types.NumberType,
+		"ssh_port":       types.Int64Type,
 		"ssh_user":       types.StringType,
 		"ssl":            types.BoolType,
 		"username":       types.StringType,
@@ -116,7 +116,7 @@ func (d *ChargebeeConnectionDataSource) Read(ctx context.Context, req datasource
 	var diags diag.Diagnostics
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"product_catalog": types.StringType,
-		"ratelimit_rpm":   types.NumberType,
+		"ratelimit_rpm":   types.Int64Type,
 		"site":            types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -214,13 +214,13 @@ func (d *ClickhouseConnectionDataSource) Read(ctx context.Context, req datasourc
 		"external_id":        types.StringType,
 		"hostname":           types.StringType,
 		"iam_role_arn":       types.StringType,
-		"port":               types.NumberType,
+		"port":               types.Int64Type,
 		"s3_bucket_name":     types.StringType,
 		"s3_bucket_region":   types.StringType,
 		"skip_verify":        types.BoolType,
 		"ssh":                types.BoolType,
 		"ssh_host":           types.StringType,
-		"ssh_port":           types.NumberType,
+		"ssh_port":           types.Int64Type,
 		"ssh_user":           types.StringType,
 		"ssl":                types.BoolType,
 		"username":           types.StringType,
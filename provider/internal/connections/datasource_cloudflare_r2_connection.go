@@ -176,7 +176,7 @@ func (d *Cloudflare_r2ConnectionDataSource) Read(ctx context.Context, req dataso
 			ElemType: types.StringType,
 		},
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -266,17 +266,17 @@ func (d *DatabricksConnectionDataSource) Read(ctx context.Context, req datasourc
 		"azure_account_name":           types.StringType,
 		"bulk_sync_staging_schema":     types.StringType,
 		"cloud_provider":               types.StringType,
-		"concurrent_queries":           types.NumberType,
+		"concurrent_queries":           types.Int64Type,
 		"container_name":               types.StringType,
 		"databricks_auth_mode":         types.StringType,
-		"deleted_file_retention_days":  types.NumberType,
+		"deleted_file_retention_days":  types.Int64Type,
 		"enable_delta_uniform":         types.BoolType,
 		"enforce_query_limit":          types.BoolType,
 		"external_id":                  types.StringType,
 		"http_path":                    types.StringType,
 		"iam_role_arn":                 types.StringType,
-		"log_file_retention_days":      types.NumberType,
-		"port":                         types.NumberType,
+		"log_file_retention_days":      types.Int64Type,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"server_hostname":              types.StringType,
@@ -285,7 +285,7 @@ func (d *DatabricksConnectionDataSource) Read(ctx context.Context, req datasourc
 		"ssh":                          types.BoolType,
 		"ssh_blob_storage":             types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_user":                     types.StringType,
 		"storage_credential_name":      types.StringType,
 		"unity_catalog_enabled":        types.BoolType,
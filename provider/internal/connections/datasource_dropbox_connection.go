@@ -160,7 +160,7 @@ func (d *DropboxConnectionDataSource) Read(ctx context.Context, req datasource.R
 			ElemType: types.StringType,
 		},
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -172,7 +172,7 @@ func (d *GcsConnectionDataSource) Read(ctx context.Context, req datasource.ReadR
 			ElemType: types.StringType,
 		},
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -357,7 +357,7 @@ func (d *HttpenrichmentConnectionDataSource) Read(ctx context.Context, req datas
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
@@ -127,8 +127,8 @@ func (d *MarketoConnectionDataSource) Read(ctx context.Context, req datasource.R
 	var diags diag.Diagnostics
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"client_id":            types.StringType,
-		"concurrent_imports":   types.NumberType,
-		"daily_api_calls":      types.NumberType,
+		"concurrent_imports":   types.Int64Type,
+		"daily_api_calls":      types.Int64Type,
 		"enforce_api_limits":   types.BoolType,
 		"include_static_lists": types.BoolType,
 		"rest_endpoint":        types.StringType,
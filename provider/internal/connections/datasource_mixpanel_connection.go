@@ -111,7 +111,7 @@ func (d *MixpanelConnectionDataSource) Read(ctx context.Context, req datasource.
 
 	var diags diag.Diagnostics
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
-		"project_id":               types.NumberType,
+		"project_id":               types.Int64Type,
 		"region":                   types.StringType,
 		"service_account_username": types.StringType,
 	}, conf)
@@ -149,10 +149,10 @@ func (d *MssqlConnectionDataSource) Read(ctx context.Context, req datasource.Rea
 		"change_detection": types.BoolType,
 		"database":         types.StringType,
 		"hostname":         types.StringType,
-		"port":             types.NumberType,
+		"port":             types.Int64Type,
 		"ssh":              types.BoolType,
 		"ssh_host":         types.StringType,
-		"ssh_port":         types.NumberType,
+		"ssh_port":         types.Int64Type,
 		"ssh_user":         types.StringType,
 		"ssl":              types.BoolType,
 		"username":         types.StringType,
@@ -150,10 +150,10 @@ func (d *MysqlConnectionDataSource) Read(ctx context.Context, req datasource.Rea
 		"change_detection": types.BoolType,
 		"dbname":           types.StringType,
 		"hostname":         types.StringType,
-		"port":             types.NumberType,
+		"port":             types.Int64Type,
 		"ssh":              types.BoolType,
 		"ssh_host":         types.StringType,
-		"ssh_port":         types.NumberType,
+		"ssh_port":         types.Int64Type,
 		"ssh_user":         types.StringType,
 		"ssl":              types.BoolType,
 	}, conf)
@@ -118,8 +118,8 @@ func (d *NetsuiteopenairConnectionDataSource) Read(ctx context.Context, req data
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"api_namespace":         types.StringType,
 		"company_id":            types.StringType,
-		"per_day_rate_limit":    types.NumberType,
-		"per_minute_rate_limit": types.NumberType,
+		"per_day_rate_limit":    types.Int64Type,
+		"per_minute_rate_limit": types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -123,7 +123,7 @@ func (d *PardotConnectionDataSource) Read(ctx context.Context, req datasource.Re
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"account_type":       types.StringType,
 		"business_unit_id":   types.StringType,
-		"daily_api_calls":    types.NumberType,
+		"daily_api_calls":    types.Int64Type,
 		"enforce_api_limits": types.BoolType,
 		"username":           types.StringType,
 	}, conf)
@@ -160,11 +160,11 @@ func (d *PostgresqlConnectionDataSource) Read(ctx context.Context, req datasourc
 		"client_certs":     types.BoolType,
 		"database":         types.StringType,
 		"hostname":         types.StringType,
-		"port":             types.NumberType,
+		"port":             types.Int64Type,
 		"publication":      types.StringType,
 		"ssh":              types.BoolType,
 		"ssh_host":         types.StringType,
-		"ssh_port":         types.NumberType,
+		"ssh_port":         types.Int64Type,
 		"ssh_user":         types.StringType,
 		"ssl":              types.BoolType,
 		"username":         types.StringType,
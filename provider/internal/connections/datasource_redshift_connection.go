@@ -199,12 +199,12 @@ func (d *RedshiftConnectionDataSource) Read(ctx context.Context, req datasource.
 		"external_id":                  types.StringType,
 		"hostname":                     types.StringType,
 		"iam_role_arn":                 types.StringType,
-		"port":                         types.NumberType,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"ssh":                          types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_user":                     types.StringType,
 		"use_bulk_sync_staging_schema": types.BoolType,
 		"username":                     types.StringType,
@@ -220,7 +220,7 @@ func (d *S3ConnectionDataSource) Read(ctx context.Context, req datasource.ReadRe
 			ElemType: types.StringType,
 		},
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -147,7 +147,7 @@ func (d *SalesforceConnectionDataSource) Read(ctx context.Context, req datasourc
 	var diags diag.Diagnostics
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"connect_mode":                types.StringType,
-		"daily_api_calls":             types.NumberType,
+		"daily_api_calls":             types.Int64Type,
 		"domain":                      types.StringType,
 		"enable_multicurrency_lookup": types.BoolType,
 		"enable_tooling":              types.BoolType,
@@ -154,7 +154,7 @@ func (d *ScylladbConnectionDataSource) Read(ctx context.Context, req datasource.
 		"skip_verify":  types.BoolType,
 		"ssh":          types.BoolType,
 		"ssh_host":     types.StringType,
-		"ssh_port":     types.NumberType,
+		"ssh_port":     types.Int64Type,
 		"ssh_user":     types.StringType,
 		"tls":          types.BoolType,
 		"username":     types.StringType,
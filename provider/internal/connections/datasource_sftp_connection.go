@@ -144,9 +144,9 @@ func (d *SftpConnectionDataSource) Read(ctx context.Context, req datasource.Read
 		"is_single_table":   types.BoolType,
 		"path":              types.StringType,
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 		"ssh_host":          types.StringType,
-		"ssh_port":          types.NumberType,
+		"ssh_port":          types.Int64Type,
 		"ssh_user":          types.StringType,
 	}, conf)
 	if diags.HasError() {
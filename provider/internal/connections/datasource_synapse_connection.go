@@ -118,7 +118,7 @@ func (d *SynapseConnectionDataSource) Read(ctx context.Context, req datasource.R
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"database": types.StringType,
 		"hostname": types.StringType,
-		"port":     types.NumberType,
+		"port":     types.Int64Type,
 		"username": types.StringType,
 	}, conf)
 	if diags.HasError() {
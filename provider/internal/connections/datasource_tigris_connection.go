@@ -168,7 +168,7 @@ func (d *TigrisConnectionDataSource) Read(ctx context.Context, req datasource.Re
 			ElemType: types.StringType,
 		},
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
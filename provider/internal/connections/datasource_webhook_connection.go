@@ -257,7 +257,7 @@ func (d *WebhookConnectionDataSource) Read(ctx context.Context, req datasource.R
 		},
 		"oauth": types.ObjectType{
 			AttrTypes: map[string]attr.Type{
-				"auth_style":    types.NumberType,
+				"auth_style":    types.Int64Type,
 				"client_id":     types.StringType,
 				"client_secret": types.StringType,
 				"extra_form_data": types.SetType{
@@ -115,7 +115,7 @@ func (d *Zendesk_chatConnectionDataSource) Read(ctx context.Context, req datasou
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"custom_api_limits": types.BoolType,
 		"domain":            types.StringType,
-		"ratelimit_rpm":     types.NumberType,
+		"ratelimit_rpm":     types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
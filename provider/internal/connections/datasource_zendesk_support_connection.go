@@ -127,7 +127,7 @@ func (d *Zendesk_supportConnectionDataSource) Read(ctx context.Context, req data
 		"custom_api_limits": types.BoolType,
 		"domain":            types.StringType,
 		"email":             types.StringType,
-		"ratelimit_rpm":     types.NumberType,
+		"ratelimit_rpm":     types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -0,0 +1,105 @@
+package connections
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &GenericConnectionDataSource{}
+
+type genericConnectionDataSourceData struct {
+	Organization  types.String         `tfsdk:"organization"`
+	Name          types.String         `tfsdk:"name"`
+	Id            types.String         `tfsdk:"id"`
+	Type          types.String         `tfsdk:"type"`
+	Configuration jsontypes.Normalized `tfsdk:"configuration"`
+}
+
+// GenericConnectionDataSource reads a connection of any type.
+type GenericConnectionDataSource struct {
+	provider *providerclient.Provider
+}
+
+func (d *GenericConnectionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		d.provider = provider
+	}
+}
+
+func (d *GenericConnectionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection"
+}
+
+func (d *GenericConnectionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: ":meta:subcategory:Connections: Connection\n\nA connection of any type.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "Organization ID",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Connection type ID, e.g. `postgresql`.",
+				Computed:            true,
+			},
+			"configuration": schema.StringAttribute{
+				MarkdownDescription: "Connection configuration, as a JSON object. Sensitive values are masked by Polytomic.",
+				CustomType:          jsontypes.NormalizedType{},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *GenericConnectionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data genericConnectionDataSourceData
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the connection
+	client, err := d.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	connection, err := client.Connections.Get(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting connection", err.Error())
+		return
+	}
+
+	data.Id = types.StringPointerValue(connection.Data.Id)
+	data.Name = types.StringPointerValue(connection.Data.Name)
+	data.Organization = types.StringPointerValue(connection.Data.OrganizationId)
+	data.Type = types.StringNull()
+	if connection.Data.Type != nil {
+		data.Type = types.StringPointerValue(connection.Data.Type.Id)
+	}
+	conf, diags := genericConfigurationValue(connection.Data.Configuration, nil, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Configuration = conf
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
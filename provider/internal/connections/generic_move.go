@@ -0,0 +1,102 @@
+package connections
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// genericConnectionTypeName is the type name of the generic connection
+// resource, which typed connections may be moved from.
+const genericConnectionTypeName = "polytomic_connection"
+
+// genericConnectionState is the state of a generic connection resource.
+type genericConnectionState struct {
+	Organization           *string `json:"organization"`
+	Name                   *string `json:"name"`
+	Id                     *string `json:"id"`
+	Type                   string  `json:"type"`
+	Configuration          *string `json:"configuration"`
+	SensitiveConfiguration *string `json:"sensitive_configuration"`
+	ForceDestroy           *bool   `json:"force_destroy"`
+}
+
+// moveFromGenericConnection returns a state mover which moves a
+// polytomic_connection of type connType to a typed connection resource with
+// schema target, e.g.
+//
+//	moved {
+//	  from = polytomic_connection.warehouse
+//	  to   = polytomic_postgresql_connection.warehouse
+//	}
+//
+// configuration and sensitive_configuration are merged into the typed
+// configuration and converted like upgraded state (see convertState), e.g.
+// "5432" to 5432; keys which the typed resource doesn't define are dropped.
+func moveFromGenericConnection(connType string, target schema.Schema) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if req.SourceTypeName != genericConnectionTypeName ||
+				!strings.HasSuffix(req.SourceProviderAddress, "polytomic/polytomic") {
+				// not ours; let Terraform try other movers
+				return
+			}
+			if req.SourceRawState == nil {
+				resp.Diagnostics.AddError("Unable to move connection", "Source state is empty.")
+				return
+			}
+
+			var source genericConnectionState
+			err := json.Unmarshal(req.SourceRawState.JSON, &source)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to move connection", fmt.Sprintf("Error decoding source state: %s", err))
+				return
+			}
+			if source.Type != connType {
+				resp.Diagnostics.AddError("Unable to move connection",
+					fmt.Sprintf("Cannot move a %q connection to a %q connection resource.", source.Type, connType))
+				return
+			}
+
+			conf := map[string]any{}
+			for _, raw := range []*string{source.Configuration, source.SensitiveConfiguration} {
+				if raw == nil {
+					continue
+				}
+				dec := json.NewDecoder(strings.NewReader(*raw))
+				dec.UseNumber()
+				var values map[string]any
+				err := dec.Decode(&values)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to move connection", fmt.Sprintf("Error decoding source configuration: %s", err))
+					return
+				}
+				for k, v := range values {
+					conf[k] = v
+				}
+			}
+
+			state, err := json.Marshal(map[string]any{
+				"id":            source.Id,
+				"name":          source.Name,
+				"organization":  source.Organization,
+				"force_destroy": source.ForceDestroy,
+				"configuration": conf,
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to move connection", fmt.Sprintf("Error encoding target state: %s", err))
+				return
+			}
+			value, err := convertState(ctx, target, state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to move connection", fmt.Sprintf("Error converting configuration: %s", err))
+				return
+			}
+			resp.TargetState.Raw = value
+		},
+	}
+}
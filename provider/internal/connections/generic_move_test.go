@@ -0,0 +1,85 @@
+package connections
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveFromGenericConnection(t *testing.T) {
+	ctx := context.Background()
+	mover := moveFromGenericConnection("postgresql", PostgresqlSchema)
+
+	move := func(typeName, source string) *resource.MoveStateResponse {
+		resp := &resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: PostgresqlSchema,
+				Raw:    tftypes.NewValue(PostgresqlSchema.Type().TerraformType(ctx), nil),
+			},
+		}
+		mover.StateMover(ctx, resource.MoveStateRequest{
+			SourceTypeName:        typeName,
+			SourceProviderAddress: "registry.terraform.io/polytomic/polytomic",
+			SourceRawState:        &tfprotov6.RawState{JSON: []byte(source)},
+		}, resp)
+		return resp
+	}
+
+	t.Run("moved", func(t *testing.T) {
+		resp := move(genericConnectionTypeName, `{
+			"id": "conn-id",
+			"name": "Warehouse",
+			"organization": "org-id",
+			"type": "postgresql",
+			"configuration": "{\"hostname\":\"db.example.com\",\"port\":5432,\"removed\":true}",
+			"sensitive_configuration": "{\"password\":\"secret\"}",
+			"force_destroy": false
+		}`)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var id, hostname, password types.String
+		var port types.Int64
+		resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("id"), &id)...)
+		resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("configuration").AtName("hostname"), &hostname)...)
+		resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("configuration").AtName("port"), &port)...)
+		resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("configuration").AtName("password"), &password)...)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, "conn-id", id.ValueString())
+		assert.Equal(t, "db.example.com", hostname.ValueString())
+		assert.Equal(t, int64(5432), port.ValueInt64())
+		assert.Equal(t, "secret", password.ValueString())
+	})
+
+	t.Run("string number", func(t *testing.T) {
+		resp := move(genericConnectionTypeName, `{
+			"id": "conn-id",
+			"type": "postgresql",
+			"configuration": "{\"hostname\":\"db.example.com\",\"port\":\"5432\"}"
+		}`)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var port types.Int64
+		resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("configuration").AtName("port"), &port)...)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, int64(5432), port.ValueInt64())
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		resp := move(genericConnectionTypeName, `{"id": "conn-id", "type": "mysql", "configuration": "{}"}`)
+		assert.True(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("other source", func(t *testing.T) {
+		resp := move("polytomic_mysql_connection", `{"id": "conn-id"}`)
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.True(t, resp.TargetState.Raw.IsNull())
+	})
+}
@@ -0,0 +1,464 @@
+package connections
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/polytomic/polytomic-go"
+	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &GenericConnectionResource{}
+var _ resource.ResourceWithImportState = &GenericConnectionResource{}
+var _ resource.ResourceWithIdentity = &GenericConnectionResource{}
+
+var GenericConnectionSchema = schema.Schema{
+	MarkdownDescription: ":meta:subcategory:Connections: Connection\n\n" +
+		"A connection of any type, configured with JSON. Use this resource for connection types " +
+		"which don't yet have a typed `polytomic_<type>_connection` resource in this version of the " +
+		"provider. Once a typed resource is available, a `moved` block migrates the connection to it " +
+		"without recreating it.",
+	Attributes: map[string]schema.Attribute{
+		"organization": schema.StringAttribute{
+			MarkdownDescription: "Organization ID",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required: true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Connection type ID, e.g. `postgresql`.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"configuration": schema.StringAttribute{
+			MarkdownDescription: "Connection configuration, as a JSON object. When set, only the keys present " +
+				"are refreshed from Polytomic; when unset, it is populated with the connection's non-sensitive " +
+				"configuration.",
+			CustomType: jsontypes.NormalizedType{},
+			Optional:   true,
+			Computed:   true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"sensitive_configuration": schema.StringAttribute{
+			MarkdownDescription: "Sensitive connection configuration, such as passwords and API keys, as a " +
+				"JSON object. Merged with `configuration` when sent to Polytomic; keys may not appear in both. " +
+				"Polytomic does not return these values, so changes made outside of Terraform are not detected.",
+			CustomType: jsontypes.NormalizedType{},
+			Optional:   true,
+			Sensitive:  true,
+		},
+		"force_destroy": schema.BoolAttribute{
+			MarkdownDescription: forceDestroyMessage,
+			Optional:            true,
+		},
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Connection identifier",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	},
+}
+
+type genericConnectionData struct {
+	Organization           types.String         `tfsdk:"organization"`
+	Name                   types.String         `tfsdk:"name"`
+	Id                     types.String         `tfsdk:"id"`
+	Type                   types.String         `tfsdk:"type"`
+	Configuration          jsontypes.Normalized `tfsdk:"configuration"`
+	SensitiveConfiguration jsontypes.Normalized `tfsdk:"sensitive_configuration"`
+	ForceDestroy           types.Bool           `tfsdk:"force_destroy"`
+}
+
+type GenericConnectionResource struct {
+	provider *providerclient.Provider
+}
+
+func (r *GenericConnectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		r.provider = provider
+	}
+}
+
+func (r *GenericConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection"
+}
+
+func (r *GenericConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = GenericConnectionSchema
+}
+
+func (r *GenericConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data genericConnectionData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+
+	conf, sensitive, diags := data.requestConfiguration()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := client.Connections.Create(ctx, &polytomic.CreateConnectionRequestSchema{
+		Name:           data.Name.ValueString(),
+		Type:           data.Type.ValueString(),
+		OrganizationId: data.Organization.ValueStringPointer(),
+		Configuration:  conf,
+		Validate:       pointer.ToBool(false),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error creating connection: %s", err))
+		return
+	}
+	data.Id = types.StringPointerValue(created.Data.Id)
+	data.Name = types.StringPointerValue(created.Data.Name)
+	data.Organization = types.StringPointerValue(created.Data.OrganizationId)
+
+	// a configured value is kept as planned, even if the API masks or
+	// normalizes it
+	if data.Configuration.IsUnknown() {
+		data.Configuration, diags = genericConfigurationValue(created.Data.Configuration, nil, sensitive)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "created a connection", map[string]interface{}{"type": data.Type.ValueString(), "id": created.Data.Id})
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
+}
+
+func (r *GenericConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data genericConnectionData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		if data.Organization.IsNull() || data.Organization.ValueString() == "" {
+			client, err = r.provider.PartnerClient()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting client", err.Error())
+			return
+		}
+	}
+	connection, err := client.Connections.Get(ctx, data.Id.ValueString())
+	if err != nil {
+		pErr := &ptcore.APIError{}
+		if errors.As(err, &pErr) {
+			if pErr.StatusCode == http.StatusNotFound {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading connection: %s", err))
+		return
+	}
+
+	data.Id = types.StringPointerValue(connection.Data.Id)
+	data.Name = types.StringPointerValue(connection.Data.Name)
+	data.Organization = types.StringPointerValue(connection.Data.OrganizationId)
+	if connection.Data.Type != nil && connection.Data.Type.Id != nil {
+		data.Type = types.StringPointerValue(connection.Data.Type.Id)
+	}
+
+	sensitive, diags := jsonObject(data.SensitiveConfiguration, path.Root("sensitive_configuration"))
+	resp.Diagnostics.Append(diags...)
+	prior, diags := jsonObject(data.Configuration, path.Root("configuration"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Configuration, diags = genericConfigurationValue(connection.Data.Configuration, prior, sensitive)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
+}
+
+func (r *GenericConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data genericConnectionData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+
+	conf, sensitive, diags := data.requestConfiguration()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prevData genericConnectionData
+	diags = req.State.Get(ctx, &prevData)
+	resp.Diagnostics.Append(diags...)
+	prevSensitive, diags := jsonObject(prevData.SensitiveConfiguration, path.Root("sensitive_configuration"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// don't resend sensitive values which haven't changed
+	conf = handleSensitiveValues(ctx, genericAttributes(conf, sensitive), conf, priorStateValues(prevSensitive))
+
+	updated, err := client.Connections.Update(ctx,
+		data.Id.ValueString(),
+		&polytomic.UpdateConnectionRequestSchema{
+			Name:           data.Name.ValueString(),
+			OrganizationId: data.Organization.ValueStringPointer(),
+			Configuration:  conf,
+			Validate:       pointer.ToBool(false),
+		})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error updating connection: %s", err))
+		return
+	}
+	data.Id = types.StringPointerValue(updated.Data.Id)
+	data.Name = types.StringPointerValue(updated.Data.Name)
+	data.Organization = types.StringPointerValue(updated.Data.OrganizationId)
+
+	if data.Configuration.IsUnknown() {
+		data.Configuration, diags = genericConfigurationValue(updated.Data.Configuration, nil, sensitive)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
+}
+
+func (r *GenericConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data genericConnectionData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+
+	err = client.Connections.Remove(ctx, data.Id.ValueString(), &polytomic.ConnectionsRemoveRequest{
+		Force: pointer.ToBool(data.ForceDestroy.ValueBool()),
+	})
+	if err == nil {
+		return
+	}
+	nfErr := &polytomic.NotFoundError{}
+	if errors.As(err, &nfErr) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	pErr := &polytomic.UnprocessableEntityError{}
+	if errors.As(err, &pErr) && !data.ForceDestroy.ValueBool() {
+		if strings.Contains(pointer.Get(pErr.Body.Message), "connection in use") {
+			if used_by, ok := pErr.Body.Metadata["used_by"].([]interface{}); ok {
+				for _, us := range used_by {
+					if user, ok := us.(map[string]interface{}); ok {
+						resp.Diagnostics.AddError("Connection in use",
+							fmt.Sprintf("Connection is used by %s \"%s\" (%s). Please remove before deleting this connection.",
+								user["type"], user["name"], user["id"]),
+						)
+					}
+				}
+				return
+			}
+		}
+	}
+
+	resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error deleting connection: %s", err))
+}
+
+func (r *GenericConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *GenericConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+// requestConfiguration returns the configuration to send to the API, and the
+// sensitive configuration it includes.
+func (d genericConnectionData) requestConfiguration() (map[string]any, map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	conf, confDiags := jsonObject(d.Configuration, path.Root("configuration"))
+	diags.Append(confDiags...)
+	sensitive, sensitiveDiags := jsonObject(d.SensitiveConfiguration, path.Root("sensitive_configuration"))
+	diags.Append(sensitiveDiags...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	if conf == nil {
+		conf = map[string]any{}
+	}
+	for k, v := range sensitive {
+		if _, ok := conf[k]; ok {
+			diags.AddAttributeError(path.Root("sensitive_configuration"),
+				"Duplicate configuration key",
+				fmt.Sprintf("%q is set in both configuration and sensitive_configuration.", k))
+			continue
+		}
+		conf[k] = v
+	}
+	return conf, sensitive, diags
+}
+
+// jsonObject decodes a JSON object attribute. Null and unknown values decode
+// to a nil map.
+func jsonObject(value jsontypes.Normalized, p path.Path) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+	var out map[string]any
+	err := json.Unmarshal([]byte(value.ValueString()), &out)
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid JSON object", err.Error())
+	}
+	return out, diags
+}
+
+// genericConfigurationValue returns the configuration attribute for a
+// configuration read from the API. Sensitive keys are excluded. If prior is
+// non-nil only its keys are included, and those the API masks, omits or
+// returns in another form (e.g. 5432 for "5432") keep their prior value;
+// otherwise values masked by the API are excluded.
+func genericConfigurationValue(read, prior, sensitive map[string]any) (jsontypes.Normalized, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// the API masks sensitive values in responses; restore them, and the
+	// prior values it masks or normalizes, before they're split out
+	restore := make(map[string]any, len(sensitive)+len(prior))
+	for k, v := range prior {
+		if r, ok := read[k]; !ok || maskedValue(r) || fmt.Sprint(r) == fmt.Sprint(v) {
+			restore[k] = v
+		}
+	}
+	for k, v := range sensitive {
+		restore[k] = v
+	}
+	read = resetSensitiveValues(genericAttributes(nil, restore), restore, maps.Clone(read))
+
+	conf := make(map[string]any, len(read))
+	for k, v := range read {
+		if _, ok := sensitive[k]; ok {
+			continue
+		}
+		if prior != nil {
+			if _, ok := prior[k]; !ok {
+				continue
+			}
+		} else if maskedValue(v) {
+			// masked values belong in sensitive_configuration
+			continue
+		}
+		conf[k] = v
+	}
+
+	encoded, err := json.Marshal(conf)
+	if err != nil {
+		diags.AddError("Error encoding connection configuration", err.Error())
+		return jsontypes.NewNormalizedNull(), diags
+	}
+	return jsontypes.NewNormalizedValue(string(encoded)), diags
+}
+
+// maskedValue reports whether v is a sensitive value masked by the API.
+func maskedValue(v any) bool {
+	s, ok := v.(string)
+	return ok && s != "" && strings.Trim(s, "*") == ""
+}
+
+// genericAttributes describes the keys of a generic connection's
+// configuration as schema attributes, so that they may be passed to the
+// sensitive value helpers shared with typed connections.
+func genericAttributes(conf, sensitive map[string]any) map[string]schema.Attribute {
+	attrs := make(map[string]schema.Attribute, len(conf)+len(sensitive))
+	for k := range conf {
+		attrs[k] = schema.StringAttribute{Optional: true}
+	}
+	for k := range sensitive {
+		attrs[k] = schema.StringAttribute{Optional: true, Sensitive: true}
+	}
+	return attrs
+}
+
+// priorStateValues converts prior sensitive string values to attribute values
+// for comparison by handleSensitiveValues.
+func priorStateValues(values map[string]any) map[string]attr.Value {
+	out := make(map[string]attr.Value, len(values))
+	for k, v := range values {
+		if s, ok := v.(string); ok {
+			out[k] = types.StringValue(s)
+		}
+	}
+	return out
+}
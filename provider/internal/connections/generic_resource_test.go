@@ -0,0 +1,130 @@
+package connections
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestConfiguration(t *testing.T) {
+	tests := map[string]struct {
+		data      genericConnectionData
+		expected  map[string]any
+		sensitive map[string]any
+		expectErr bool
+	}{
+		"merged": {
+			data: genericConnectionData{
+				Configuration:          jsontypes.NewNormalizedValue(`{"hostname": "db.example.com", "port": 5432}`),
+				SensitiveConfiguration: jsontypes.NewNormalizedValue(`{"password": "secret"}`),
+			},
+			expected: map[string]any{
+				"hostname": "db.example.com",
+				"port":     float64(5432),
+				"password": "secret",
+			},
+			sensitive: map[string]any{
+				"password": "secret",
+			},
+		},
+		"null configuration": {
+			data: genericConnectionData{
+				Configuration:          jsontypes.NewNormalizedNull(),
+				SensitiveConfiguration: jsontypes.NewNormalizedValue(`{"api_key": "secret"}`),
+			},
+			expected: map[string]any{
+				"api_key": "secret",
+			},
+			sensitive: map[string]any{
+				"api_key": "secret",
+			},
+		},
+		"duplicate key": {
+			data: genericConnectionData{
+				Configuration:          jsontypes.NewNormalizedValue(`{"password": "plain"}`),
+				SensitiveConfiguration: jsontypes.NewNormalizedValue(`{"password": "secret"}`),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf, sensitive, diags := test.data.requestConfiguration()
+			if test.expectErr {
+				assert.True(t, diags.HasError())
+				return
+			}
+			assert.False(t, diags.HasError())
+			assert.EqualValues(t, test.expected, conf)
+			assert.EqualValues(t, test.sensitive, sensitive)
+		})
+	}
+}
+
+func TestGenericConfigurationValue(t *testing.T) {
+	tests := map[string]struct {
+		read      map[string]any
+		prior     map[string]any
+		sensitive map[string]any
+		expected  string
+	}{
+		"all keys": {
+			read: map[string]any{
+				"hostname": "db.example.com",
+				"ssl":      true,
+			},
+			expected: `{"hostname":"db.example.com","ssl":true}`,
+		},
+		"sensitive keys excluded": {
+			read: map[string]any{
+				"hostname": "db.example.com",
+				"password": "********",
+			},
+			sensitive: map[string]any{
+				"password": "secret",
+			},
+			expected: `{"hostname":"db.example.com"}`,
+		},
+		"masked values excluded": {
+			read: map[string]any{
+				"hostname": "db.example.com",
+				"api_key":  "********",
+			},
+			expected: `{"hostname":"db.example.com"}`,
+		},
+		"only configured keys": {
+			read: map[string]any{
+				"hostname":   "db.example.com",
+				"created_at": "2024-01-01",
+			},
+			prior: map[string]any{
+				"hostname": "old.example.com",
+			},
+			expected: `{"hostname":"db.example.com"}`,
+		},
+		"configured values masked or normalized": {
+			read: map[string]any{
+				"hostname": "db.example.com",
+				"api_key":  "********",
+				"port":     float64(5432),
+			},
+			prior: map[string]any{
+				"hostname": "db.example.com",
+				"api_key":  "key",
+				"port":     "5432",
+				"region":   "us-east-1",
+			},
+			expected: `{"hostname":"db.example.com","api_key":"key","port":"5432","region":"us-east-1"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, diags := genericConfigurationValue(test.read, test.prior, test.sensitive)
+			assert.False(t, diags.HasError())
+			assert.JSONEq(t, test.expected, actual.ValueString())
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package connections
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// listConnections streams the connections of connType to a connection
+// resource's list results.
+func listConnections(ctx context.Context, r resource.Resource, provider *providerclient.Provider, connType string, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listresource.Config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := provider.Client(ctx, config.Organization.ValueString())
+	if err != nil {
+		stream.Results = listresource.Error("Error getting client", err.Error())
+		return
+	}
+	conns, err := client.Connections.List(ctx)
+	if err != nil {
+		stream.Results = listresource.Error(providerclient.ErrorSummary, fmt.Sprintf("Error listing connections: %s", err))
+		return
+	}
+
+	var items []listresource.Item
+	for _, conn := range conns.Data {
+		if conn.Type == nil || pointer.GetString(conn.Type.Id) != connType {
+			continue
+		}
+		items = append(items, listresource.Item{
+			Organization: pointer.GetString(conn.OrganizationId),
+			ID:           pointer.GetString(conn.Id),
+			DisplayName:  pointer.GetString(conn.Name),
+		})
+	}
+	stream.Results = listresource.Results(ctx, req, r, config, items)
+}
@@ -0,0 +1,552 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/polytomic/polytomic-go"
+	ptclient "github.com/polytomic/polytomic-go/client"
+	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
+)
+
+const (
+	// oauthAuthorizedStatus is the connection status once the OAuth flow has
+	// been completed and the connection's credentials are valid.
+	oauthAuthorizedStatus = "healthy"
+
+	// oauthPollInterval is how often the connection status is checked while
+	// waiting for authorization.
+	oauthPollInterval = 10 * time.Second
+
+	defaultOAuthWaitTimeout = "15m"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &OAuthConnectionResource{}
+var _ resource.ResourceWithImportState = &OAuthConnectionResource{}
+var _ resource.ResourceWithIdentity = &OAuthConnectionResource{}
+var _ resource.ResourceWithModifyPlan = &OAuthConnectionResource{}
+var _ resource.ResourceWithValidateConfig = &OAuthConnectionResource{}
+
+var OAuthConnectionSchema = schema.Schema{
+	MarkdownDescription: ":meta:subcategory:Connections: OAuth Connection\n\n" +
+		"A connection whose credentials are granted through an interactive OAuth flow, such as Airtable " +
+		"or HubSpot. Terraform creates the connection and returns a one-time `authorization_url`; a user " +
+		"with access to the third-party account visits it to authorize Polytomic. Until then the " +
+		"connection is not usable, and each plan reports that it is awaiting authorization.",
+	Attributes: map[string]schema.Attribute{
+		"organization": schema.StringAttribute{
+			MarkdownDescription: "Organization ID",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required: true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Connection type ID, e.g. `airtable`. Must be a connection type which is authorized with OAuth.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"configuration": schema.StringAttribute{
+			MarkdownDescription: "Non-secret connection configuration, as a JSON object. When set, only the keys present " +
+				"are refreshed from Polytomic; when unset, it is populated with the connection's configuration.",
+			CustomType: jsontypes.NormalizedType{},
+			Optional:   true,
+			Computed:   true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"redirect_url": schema.StringAttribute{
+			MarkdownDescription: "URL the user is sent to after completing authorization.",
+			Required:            true,
+		},
+		"wait_for_authorization": schema.BoolAttribute{
+			MarkdownDescription: "Wait for the connection to be authorized through the `authorization_url` of " +
+				"the previous apply. Until it is authorized, each plan updates the connection so that applying " +
+				"waits. Creating a connection doesn't wait, since its `authorization_url` can't be read until " +
+				"the apply completes. If it isn't authorized within `wait_timeout`, a warning is reported and " +
+				"the apply continues.",
+			Optional: true,
+		},
+		"wait_timeout": schema.StringAttribute{
+			MarkdownDescription: "How long to wait for authorization, as a Go duration, e.g. `30m`. Defaults to `15m`.",
+			CustomType:          timetypes.GoDurationType{},
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(defaultOAuthWaitTimeout),
+		},
+		"authorization_url": schema.StringAttribute{
+			MarkdownDescription: "One-time URL to visit to authorize the connection. A new URL is generated " +
+				"when the connection is updated before it has been authorized.",
+			Computed:  true,
+			Sensitive: true,
+		},
+		"authorized": schema.BoolAttribute{
+			MarkdownDescription: "Whether the OAuth flow has been completed.",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "Connection status reported by Polytomic.",
+			Computed:            true,
+		},
+		"status_error": schema.StringAttribute{
+			MarkdownDescription: "Error reported by Polytomic for an unhealthy connection.",
+			Computed:            true,
+		},
+		"force_destroy": schema.BoolAttribute{
+			MarkdownDescription: forceDestroyMessage,
+			Optional:            true,
+		},
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Connection identifier",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	},
+}
+
+type oauthConnectionData struct {
+	Organization         types.String         `tfsdk:"organization"`
+	Name                 types.String         `tfsdk:"name"`
+	Id                   types.String         `tfsdk:"id"`
+	Type                 types.String         `tfsdk:"type"`
+	Configuration        jsontypes.Normalized `tfsdk:"configuration"`
+	RedirectURL          types.String         `tfsdk:"redirect_url"`
+	WaitForAuthorization types.Bool           `tfsdk:"wait_for_authorization"`
+	WaitTimeout          timetypes.GoDuration `tfsdk:"wait_timeout"`
+	AuthorizationURL     types.String         `tfsdk:"authorization_url"`
+	Authorized           types.Bool           `tfsdk:"authorized"`
+	Status               types.String         `tfsdk:"status"`
+	StatusError          types.String         `tfsdk:"status_error"`
+	ForceDestroy         types.Bool           `tfsdk:"force_destroy"`
+}
+
+type OAuthConnectionResource struct {
+	provider *providerclient.Provider
+}
+
+func (r *OAuthConnectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		r.provider = provider
+	}
+}
+
+func (r *OAuthConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_connection"
+}
+
+func (r *OAuthConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = OAuthConnectionSchema
+}
+
+func (r *OAuthConnectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var connType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &connType)...)
+	if resp.Diagnostics.HasError() || connType.IsNull() || connType.IsUnknown() {
+		return
+	}
+	if !OAuthConnections[connType.ValueString()] {
+		resp.Diagnostics.AddAttributeError(path.Root("type"),
+			"Unsupported connection type",
+			fmt.Sprintf("%q connections are not authorized with OAuth; use the polytomic_%s_connection resource instead.",
+				connType.ValueString(), connType.ValueString()))
+	}
+}
+
+func (r *OAuthConnectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state oauthConnectionData
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.Authorized.ValueBool() {
+		// nothing to regenerate once the connection is authorized
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("authorization_url"), state.AuthorizationURL)...)
+		return
+	}
+
+	resp.Diagnostics.AddWarning("Connection awaiting authorization",
+		fmt.Sprintf("Connection %q (%s) has not been authorized. Visit its authorization_url to complete the OAuth flow.",
+			state.Name.ValueString(), state.Id.ValueString()))
+
+	var wait types.Bool
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("wait_for_authorization"), &wait)...)
+	if resp.Diagnostics.HasError() || !wait.ValueBool() {
+		return
+	}
+	// the status is refreshed by Update, which waits for authorization; a
+	// plan without other changes wouldn't call it
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("authorized"), types.BoolUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status_error"), types.StringUnknown())...)
+}
+
+func (r *OAuthConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data oauthConnectionData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+
+	conf, diags := jsonObject(data.Configuration, path.Root("configuration"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if conf == nil {
+		conf = map[string]any{}
+	}
+
+	created, err := client.Connections.Create(ctx, &polytomic.CreateConnectionRequestSchema{
+		Name:           data.Name.ValueString(),
+		Type:           data.Type.ValueString(),
+		OrganizationId: data.Organization.ValueStringPointer(),
+		Configuration:  conf,
+		Validate:       pointer.ToBool(false),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error creating connection: %s", err))
+		return
+	}
+	data.Id = types.StringPointerValue(created.Data.Id)
+	data.Name = types.StringPointerValue(created.Data.Name)
+	data.Organization = types.StringPointerValue(created.Data.OrganizationId)
+
+	// a configured value is kept as planned, even if the API normalizes it
+	if data.Configuration.IsUnknown() {
+		data.Configuration, diags = genericConfigurationValue(created.Data.Configuration, nil, nil)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "created an OAuth connection", map[string]interface{}{"type": data.Type.ValueString(), "id": created.Data.Id})
+
+	authURL, err := authorizationURL(ctx, client, data)
+	if err != nil {
+		// the connection exists; save it so that it isn't orphaned
+		data.AuthorizationURL = types.StringNull()
+		data.Authorized = types.BoolValue(false)
+		data.Status = types.StringNull()
+		data.StatusError = types.StringNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error creating authorization URL: %s", err))
+		return
+	}
+	data.AuthorizationURL = types.StringValue(authURL)
+
+	// the authorization URL can't be read until the apply completes, so
+	// there's nothing to wait for
+	resp.Diagnostics.Append(r.refreshStatus(ctx, client, &data, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
+}
+
+func (r *OAuthConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data oauthConnectionData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		if data.Organization.IsNull() || data.Organization.ValueString() == "" {
+			client, err = r.provider.PartnerClient()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting client", err.Error())
+			return
+		}
+	}
+	connection, err := client.Connections.Get(ctx, data.Id.ValueString())
+	if err != nil {
+		pErr := &ptcore.APIError{}
+		if errors.As(err, &pErr) {
+			if pErr.StatusCode == http.StatusNotFound {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading connection: %s", err))
+		return
+	}
+
+	data.Id = types.StringPointerValue(connection.Data.Id)
+	data.Name = types.StringPointerValue(connection.Data.Name)
+	data.Organization = types.StringPointerValue(connection.Data.OrganizationId)
+	if connection.Data.Type != nil && connection.Data.Type.Id != nil {
+		data.Type = types.StringPointerValue(connection.Data.Type.Id)
+	}
+	status := string(pointer.Get(connection.Data.Status))
+	data.Status = types.StringValue(status)
+	data.StatusError = types.StringPointerValue(connection.Data.StatusError)
+	data.Authorized = types.BoolValue(status == oauthAuthorizedStatus)
+	if data.WaitTimeout.IsNull() {
+		// imported
+		data.WaitTimeout = timetypes.NewGoDurationValueFromStringMust(defaultOAuthWaitTimeout)
+	}
+
+	prior, diags := jsonObject(data.Configuration, path.Root("configuration"))
+	resp.Diagnostics.Append(diags...)
+	data.Configuration, diags = genericConfigurationValue(connection.Data.Configuration, prior, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
+}
+
+func (r *OAuthConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data oauthConnectionData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var prevData oauthConnectionData
+	diags = req.State.Get(ctx, &prevData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+
+	conf, diags := jsonObject(data.Configuration, path.Root("configuration"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if conf == nil {
+		conf = map[string]any{}
+	}
+
+	updated, err := client.Connections.Update(ctx,
+		data.Id.ValueString(),
+		&polytomic.UpdateConnectionRequestSchema{
+			Name:           data.Name.ValueString(),
+			OrganizationId: data.Organization.ValueStringPointer(),
+			Configuration:  conf,
+			Validate:       pointer.ToBool(false),
+		})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error updating connection: %s", err))
+		return
+	}
+	data.Id = types.StringPointerValue(updated.Data.Id)
+	data.Name = types.StringPointerValue(updated.Data.Name)
+	data.Organization = types.StringPointerValue(updated.Data.OrganizationId)
+
+	// a configured value is kept as planned, even if the API normalizes it
+	if data.Configuration.IsUnknown() {
+		data.Configuration, diags = genericConfigurationValue(updated.Data.Configuration, nil, nil)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.AuthorizationURL = prevData.AuthorizationURL
+	// wait for authorization through the URL read from the previous apply
+	wait := data.WaitForAuthorization.ValueBool() && !prevData.AuthorizationURL.IsNull()
+	if !prevData.Authorized.ValueBool() && !wait {
+		// authorization URLs are single use, and may have expired
+		authURL, err := authorizationURL(ctx, client, data)
+		if err != nil {
+			resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error creating authorization URL: %s", err))
+			return
+		}
+		data.AuthorizationURL = types.StringValue(authURL)
+	}
+
+	resp.Diagnostics.Append(r.refreshStatus(ctx, client, &data, wait)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
+}
+
+func (r *OAuthConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data oauthConnectionData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+
+	err = client.Connections.Remove(ctx, data.Id.ValueString(), &polytomic.ConnectionsRemoveRequest{
+		Force: pointer.ToBool(data.ForceDestroy.ValueBool()),
+	})
+	if err == nil {
+		return
+	}
+	nfErr := &polytomic.NotFoundError{}
+	if errors.As(err, &nfErr) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	pErr := &polytomic.UnprocessableEntityError{}
+	if errors.As(err, &pErr) && !data.ForceDestroy.ValueBool() {
+		if strings.Contains(pointer.Get(pErr.Body.Message), "connection in use") {
+			if used_by, ok := pErr.Body.Metadata["used_by"].([]interface{}); ok {
+				for _, us := range used_by {
+					if user, ok := us.(map[string]interface{}); ok {
+						resp.Diagnostics.AddError("Connection in use",
+							fmt.Sprintf("Connection is used by %s \"%s\" (%s). Please remove before deleting this connection.",
+								user["type"], user["name"], user["id"]),
+						)
+					}
+				}
+				return
+			}
+		}
+	}
+
+	resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error deleting connection: %s", err))
+}
+
+func (r *OAuthConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *OAuthConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+// refreshStatus sets the status of the connection, waiting up to
+// wait_timeout for it to be authorized if wait is set.
+func (r *OAuthConnectionResource) refreshStatus(ctx context.Context, client *ptclient.Client, data *oauthConnectionData, wait bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var deadline time.Time
+	interval := oauthPollInterval
+	if wait {
+		timeout, d := data.WaitTimeout.ValueGoDuration()
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		deadline = time.Now().Add(timeout)
+		interval = min(interval, timeout)
+		tflog.Info(ctx, "waiting for connection authorization", map[string]any{
+			"id":      data.Id.ValueString(),
+			"timeout": timeout.String(),
+		})
+	}
+
+	for {
+		connection, err := client.Connections.Get(ctx, data.Id.ValueString())
+		if err != nil {
+			diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading connection: %s", err))
+			return diags
+		}
+		status := string(pointer.Get(connection.Data.Status))
+		data.Status = types.StringValue(status)
+		data.StatusError = types.StringPointerValue(connection.Data.StatusError)
+		data.Authorized = types.BoolValue(status == oauthAuthorizedStatus)
+
+		if data.Authorized.ValueBool() || deadline.IsZero() {
+			return diags
+		}
+		if time.Now().After(deadline) {
+			diags.AddWarning("Connection not authorized",
+				fmt.Sprintf("Connection %q (%s) was not authorized within %s. Visit its authorization_url to complete the OAuth flow.",
+					data.Name.ValueString(), data.Id.ValueString(), data.WaitTimeout.ValueString()))
+			return diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Error waiting for authorization", ctx.Err().Error())
+			return diags
+		case <-time.After(interval):
+		}
+	}
+}
+
+// authorizationURL creates a connect link which completes the OAuth flow for
+// an existing connection.
+func authorizationURL(ctx context.Context, client *ptclient.Client, data oauthConnectionData) (string, error) {
+	link, err := client.Connections.Connect(ctx, &polytomic.ConnectCardRequest{
+		ConnectionId:   data.Id.ValueStringPointer(),
+		Name:           data.Name.ValueString(),
+		Type:           data.Type.ValueStringPointer(),
+		OrganizationId: data.Organization.ValueStringPointer(),
+		RedirectUrl:    data.RedirectURL.ValueString(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if link.Data == nil || link.Data.RedirectUrl == nil {
+		return "", errors.New("no URL returned")
+	}
+	return *link.Data.RedirectUrl, nil
+}
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &AffinityConnectionResource{}
 var _ resource.ResourceWithImportState = &AffinityConnectionResource{}
+var _ resource.ResourceWithMoveState = &AffinityConnectionResource{}
+var _ resource.ResourceWithIdentity = &AffinityConnectionResource{}
+var _ list.ListResourceWithConfigure = &AffinityConnectionResource{}
 
 var AffinitySchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Affinity Connection",
@@ -37,6 +42,10 @@ var AffinitySchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -186,6 +195,7 @@ func (r *AffinityConnectionResource) Create(ctx context.Context, req resource.Cr
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AffinityConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -257,6 +267,7 @@ func (r *AffinityConnectionResource) Read(ctx context.Context, req resource.Read
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AffinityConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -339,6 +350,7 @@ func (r *AffinityConnectionResource) Update(ctx context.Context, req resource.Up
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AffinityConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -405,5 +417,23 @@ func (r *AffinityConnectionResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *AffinityConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *AffinityConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *AffinityConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *AffinityConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "affinity", req, stream)
+}
+
+func (r *AffinityConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("affinity", AffinitySchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &AirtableConnectionResource{}
 var _ resource.ResourceWithImportState = &AirtableConnectionResource{}
+var _ resource.ResourceWithMoveState = &AirtableConnectionResource{}
+var _ resource.ResourceWithIdentity = &AirtableConnectionResource{}
+var _ list.ListResourceWithConfigure = &AirtableConnectionResource{}
 
 var AirtableSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Airtable Connection",
@@ -37,6 +42,10 @@ var AirtableSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -234,6 +243,7 @@ func (r *AirtableConnectionResource) Create(ctx context.Context, req resource.Cr
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AirtableConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -309,6 +319,7 @@ func (r *AirtableConnectionResource) Read(ctx context.Context, req resource.Read
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AirtableConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -395,6 +406,7 @@ func (r *AirtableConnectionResource) Update(ctx context.Context, req resource.Up
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AirtableConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -461,5 +473,23 @@ func (r *AirtableConnectionResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *AirtableConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *AirtableConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *AirtableConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *AirtableConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "airtable", req, stream)
+}
+
+func (r *AirtableConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("airtable", AirtableSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &Amazon_keyspacesConnectionResource{}
 var _ resource.ResourceWithImportState = &Amazon_keyspacesConnectionResource{}
+var _ resource.ResourceWithMoveState = &Amazon_keyspacesConnectionResource{}
+var _ resource.ResourceWithIdentity = &Amazon_keyspacesConnectionResource{}
+var _ list.ListResourceWithConfigure = &Amazon_keyspacesConnectionResource{}
 
 var Amazon_keyspacesSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Amazon Keyspaces Connection",
@@ -40,6 +45,10 @@ var Amazon_keyspacesSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -260,6 +269,7 @@ func (r *Amazon_keyspacesConnectionResource) Create(ctx context.Context, req res
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Amazon_keyspacesConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -338,6 +348,7 @@ func (r *Amazon_keyspacesConnectionResource) Read(ctx context.Context, req resou
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Amazon_keyspacesConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -427,6 +438,7 @@ func (r *Amazon_keyspacesConnectionResource) Update(ctx context.Context, req res
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Amazon_keyspacesConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -493,5 +505,23 @@ func (r *Amazon_keyspacesConnectionResource) Delete(ctx context.Context, req res
 }
 
 func (r *Amazon_keyspacesConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *Amazon_keyspacesConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *Amazon_keyspacesConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *Amazon_keyspacesConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "amazon_keyspaces", req, stream)
+}
+
+func (r *Amazon_keyspacesConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("amazon_keyspaces", Amazon_keyspacesSchema),
+	}
 }
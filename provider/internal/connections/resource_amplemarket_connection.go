@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &AmplemarketConnectionResource{}
 var _ resource.ResourceWithImportState = &AmplemarketConnectionResource{}
+var _ resource.ResourceWithMoveState = &AmplemarketConnectionResource{}
+var _ resource.ResourceWithIdentity = &AmplemarketConnectionResource{}
+var _ list.ListResourceWithConfigure = &AmplemarketConnectionResource{}
 
 var AmplemarketSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Amplemarket Connection",
@@ -37,6 +42,10 @@ var AmplemarketSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -186,6 +195,7 @@ func (r *AmplemarketConnectionResource) Create(ctx context.Context, req resource
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AmplemarketConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -257,6 +267,7 @@ func (r *AmplemarketConnectionResource) Read(ctx context.Context, req resource.R
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AmplemarketConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -339,6 +350,7 @@ func (r *AmplemarketConnectionResource) Update(ctx context.Context, req resource
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AmplemarketConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -405,5 +417,23 @@ func (r *AmplemarketConnectionResource) Delete(ctx context.Context, req resource
 }
 
 func (r *AmplemarketConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *AmplemarketConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *AmplemarketConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *AmplemarketConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "amplemarket", req, stream)
+}
+
+func (r *AmplemarketConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("amplemarket", AmplemarketSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &ApiConnectionResource{}
 var _ resource.ResourceWithImportState = &ApiConnectionResource{}
+var _ resource.ResourceWithMoveState = &ApiConnectionResource{}
+var _ resource.ResourceWithIdentity = &ApiConnectionResource{}
+var _ list.ListResourceWithConfigure = &ApiConnectionResource{}
 
 var ApiSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: HTTP API Connection",
@@ -37,6 +42,10 @@ var ApiSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -420,7 +429,7 @@ func (r *ApiConnectionResource) Create(ctx context.Context, req resource.CreateR
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
@@ -474,6 +483,7 @@ func (r *ApiConnectionResource) Create(ctx context.Context, req resource.CreateR
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ApiConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -548,7 +558,7 @@ func (r *ApiConnectionResource) Read(ctx context.Context, req resource.ReadReque
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
@@ -600,6 +610,7 @@ func (r *ApiConnectionResource) Read(ctx context.Context, req resource.ReadReque
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ApiConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -686,7 +697,7 @@ func (r *ApiConnectionResource) Update(ctx context.Context, req resource.UpdateR
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
@@ -737,6 +748,7 @@ func (r *ApiConnectionResource) Update(ctx context.Context, req resource.UpdateR
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ApiConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -803,5 +815,23 @@ func (r *ApiConnectionResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *ApiConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *ApiConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *ApiConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *ApiConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "api", req, stream)
+}
+
+func (r *ApiConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("api", ApiSchema),
+	}
 }
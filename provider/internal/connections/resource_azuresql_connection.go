@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &AzuresqlConnectionResource{}
 var _ resource.ResourceWithImportState = &AzuresqlConnectionResource{}
+var _ resource.ResourceWithMoveState = &AzuresqlConnectionResource{}
+var _ resource.ResourceWithIdentity = &AzuresqlConnectionResource{}
+var _ list.ListResourceWithConfigure = &AzuresqlConnectionResource{}
 
 var AzuresqlSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Azure SQL Connection",
@@ -37,6 +42,10 @@ var AzuresqlSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -282,10 +291,10 @@ func (r *AzuresqlConnectionResource) Create(ctx context.Context, req resource.Cr
 		"database":        types.StringType,
 		"hostname":        types.StringType,
 		"password":        types.StringType,
-		"port":            types.NumberType,
+		"port":            types.Int64Type,
 		"ssh":             types.BoolType,
 		"ssh_host":        types.StringType,
-		"ssh_port":        types.NumberType,
+		"ssh_port":        types.Int64Type,
 		"ssh_private_key": types.StringType,
 		"ssh_user":        types.StringType,
 		"ssl":             types.BoolType,
@@ -300,6 +309,7 @@ func (r *AzuresqlConnectionResource) Create(ctx context.Context, req resource.Cr
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AzuresqlConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -367,10 +377,10 @@ func (r *AzuresqlConnectionResource) Read(ctx context.Context, req resource.Read
 		"database":        types.StringType,
 		"hostname":        types.StringType,
 		"password":        types.StringType,
-		"port":            types.NumberType,
+		"port":            types.Int64Type,
 		"ssh":             types.BoolType,
 		"ssh_host":        types.StringType,
-		"ssh_port":        types.NumberType,
+		"ssh_port":        types.Int64Type,
 		"ssh_private_key": types.StringType,
 		"ssh_user":        types.StringType,
 		"ssl":             types.BoolType,
@@ -383,6 +393,7 @@ func (r *AzuresqlConnectionResource) Read(ctx context.Context, req resource.Read
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AzuresqlConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -462,10 +473,10 @@ func (r *AzuresqlConnectionResource) Update(ctx context.Context, req resource.Up
 		"database":        types.StringType,
 		"hostname":        types.StringType,
 		"password":        types.StringType,
-		"port":            types.NumberType,
+		"port":            types.Int64Type,
 		"ssh":             types.BoolType,
 		"ssh_host":        types.StringType,
-		"ssh_port":        types.NumberType,
+		"ssh_port":        types.Int64Type,
 		"ssh_private_key": types.StringType,
 		"ssh_user":        types.StringType,
 		"ssl":             types.BoolType,
@@ -477,6 +488,7 @@ func (r *AzuresqlConnectionResource) Update(ctx context.Context, req resource.Up
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *AzuresqlConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -543,5 +555,23 @@ func (r *AzuresqlConnectionResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *AzuresqlConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *AzuresqlConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *AzuresqlConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *AzuresqlConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "azuresql", req, stream)
+}
+
+func (r *AzuresqlConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("azuresql", AzuresqlSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &ChargebeeConnectionResource{}
 var _ resource.ResourceWithImportState = &ChargebeeConnectionResource{}
+var _ resource.ResourceWithMoveState = &ChargebeeConnectionResource{}
+var _ resource.ResourceWithIdentity = &ChargebeeConnectionResource{}
+var _ list.ListResourceWithConfigure = &ChargebeeConnectionResource{}
 
 var ChargebeeSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Chargebee Connection",
@@ -40,6 +45,10 @@ var ChargebeeSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -193,7 +202,7 @@ func (r *ChargebeeConnectionResource) Create(ctx context.Context, req resource.C
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"api_key":         types.StringType,
 		"product_catalog": types.StringType,
-		"ratelimit_rpm":   types.NumberType,
+		"ratelimit_rpm":   types.Int64Type,
 		"site":            types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -205,6 +214,7 @@ func (r *ChargebeeConnectionResource) Create(ctx context.Context, req resource.C
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ChargebeeConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -267,7 +277,7 @@ func (r *ChargebeeConnectionResource) Read(ctx context.Context, req resource.Rea
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"api_key":         types.StringType,
 		"product_catalog": types.StringType,
-		"ratelimit_rpm":   types.NumberType,
+		"ratelimit_rpm":   types.Int64Type,
 		"site":            types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -277,6 +287,7 @@ func (r *ChargebeeConnectionResource) Read(ctx context.Context, req resource.Rea
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ChargebeeConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -351,7 +362,7 @@ func (r *ChargebeeConnectionResource) Update(ctx context.Context, req resource.U
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"api_key":         types.StringType,
 		"product_catalog": types.StringType,
-		"ratelimit_rpm":   types.NumberType,
+		"ratelimit_rpm":   types.Int64Type,
 		"site":            types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -360,6 +371,7 @@ func (r *ChargebeeConnectionResource) Update(ctx context.Context, req resource.U
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ChargebeeConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -426,5 +438,23 @@ func (r *ChargebeeConnectionResource) Delete(ctx context.Context, req resource.D
 }
 
 func (r *ChargebeeConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *ChargebeeConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *ChargebeeConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *ChargebeeConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "chargebee", req, stream)
+}
+
+func (r *ChargebeeConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("chargebee", ChargebeeSchema),
+	}
 }
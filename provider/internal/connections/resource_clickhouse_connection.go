@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &ClickhouseConnectionResource{}
 var _ resource.ResourceWithImportState = &ClickhouseConnectionResource{}
+var _ resource.ResourceWithMoveState = &ClickhouseConnectionResource{}
+var _ resource.ResourceWithIdentity = &ClickhouseConnectionResource{}
+var _ list.ListResourceWithConfigure = &ClickhouseConnectionResource{}
 
 var ClickhouseSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: ClickHouse Connection",
@@ -40,6 +45,10 @@ var ClickhouseSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -380,13 +389,13 @@ func (r *ClickhouseConnectionResource) Create(ctx context.Context, req resource.
 		"hostname":              types.StringType,
 		"iam_role_arn":          types.StringType,
 		"password":              types.StringType,
-		"port":                  types.NumberType,
+		"port":                  types.Int64Type,
 		"s3_bucket_name":        types.StringType,
 		"s3_bucket_region":      types.StringType,
 		"skip_verify":           types.BoolType,
 		"ssh":                   types.BoolType,
 		"ssh_host":              types.StringType,
-		"ssh_port":              types.NumberType,
+		"ssh_port":              types.Int64Type,
 		"ssh_private_key":       types.StringType,
 		"ssh_user":              types.StringType,
 		"ssl":                   types.BoolType,
@@ -401,6 +410,7 @@ func (r *ClickhouseConnectionResource) Create(ctx context.Context, req resource.
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ClickhouseConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -474,13 +484,13 @@ func (r *ClickhouseConnectionResource) Read(ctx context.Context, req resource.Re
 		"hostname":              types.StringType,
 		"iam_role_arn":          types.StringType,
 		"password":              types.StringType,
-		"port":                  types.NumberType,
+		"port":                  types.Int64Type,
 		"s3_bucket_name":        types.StringType,
 		"s3_bucket_region":      types.StringType,
 		"skip_verify":           types.BoolType,
 		"ssh":                   types.BoolType,
 		"ssh_host":              types.StringType,
-		"ssh_port":              types.NumberType,
+		"ssh_port":              types.Int64Type,
 		"ssh_private_key":       types.StringType,
 		"ssh_user":              types.StringType,
 		"ssl":                   types.BoolType,
@@ -493,6 +503,7 @@ func (r *ClickhouseConnectionResource) Read(ctx context.Context, req resource.Re
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ClickhouseConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -578,13 +589,13 @@ func (r *ClickhouseConnectionResource) Update(ctx context.Context, req resource.
 		"hostname":              types.StringType,
 		"iam_role_arn":          types.StringType,
 		"password":              types.StringType,
-		"port":                  types.NumberType,
+		"port":                  types.Int64Type,
 		"s3_bucket_name":        types.StringType,
 		"s3_bucket_region":      types.StringType,
 		"skip_verify":           types.BoolType,
 		"ssh":                   types.BoolType,
 		"ssh_host":              types.StringType,
-		"ssh_port":              types.NumberType,
+		"ssh_port":              types.Int64Type,
 		"ssh_private_key":       types.StringType,
 		"ssh_user":              types.StringType,
 		"ssl":                   types.BoolType,
@@ -596,6 +607,7 @@ func (r *ClickhouseConnectionResource) Update(ctx context.Context, req resource.
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ClickhouseConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -662,5 +674,23 @@ func (r *ClickhouseConnectionResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *ClickhouseConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *ClickhouseConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *ClickhouseConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *ClickhouseConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "clickhouse", req, stream)
+}
+
+func (r *ClickhouseConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("clickhouse", ClickhouseSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &CsvConnectionResource{}
 var _ resource.ResourceWithImportState = &CsvConnectionResource{}
+var _ resource.ResourceWithMoveState = &CsvConnectionResource{}
+var _ resource.ResourceWithIdentity = &CsvConnectionResource{}
+var _ list.ListResourceWithConfigure = &CsvConnectionResource{}
 
 var CsvSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: CSV URL Connection",
@@ -37,6 +42,10 @@ var CsvSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -404,7 +413,7 @@ func (r *CsvConnectionResource) Create(ctx context.Context, req resource.CreateR
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
@@ -456,6 +465,7 @@ func (r *CsvConnectionResource) Create(ctx context.Context, req resource.CreateR
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *CsvConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -530,7 +540,7 @@ func (r *CsvConnectionResource) Read(ctx context.Context, req resource.ReadReque
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
@@ -580,6 +590,7 @@ func (r *CsvConnectionResource) Read(ctx context.Context, req resource.ReadReque
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *CsvConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -666,7 +677,7 @@ func (r *CsvConnectionResource) Update(ctx context.Context, req resource.UpdateR
 					},
 				}, "oauth": types.ObjectType{
 					AttrTypes: map[string]attr.Type{
-						"auth_style":    types.NumberType,
+						"auth_style":    types.Int64Type,
 						"client_id":     types.StringType,
 						"client_secret": types.StringType,
 						"extra_form_data": types.SetType{
@@ -715,6 +726,7 @@ func (r *CsvConnectionResource) Update(ctx context.Context, req resource.UpdateR
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *CsvConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -781,5 +793,23 @@ func (r *CsvConnectionResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *CsvConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *CsvConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *CsvConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *CsvConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "csv", req, stream)
+}
+
+func (r *CsvConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("csv", CsvSchema),
+	}
 }
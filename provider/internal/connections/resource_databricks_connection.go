@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &DatabricksConnectionResource{}
 var _ resource.ResourceWithImportState = &DatabricksConnectionResource{}
+var _ resource.ResourceWithMoveState = &DatabricksConnectionResource{}
+var _ resource.ResourceWithIdentity = &DatabricksConnectionResource{}
+var _ list.ListResourceWithConfigure = &DatabricksConnectionResource{}
 
 var DatabricksSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Databricks Connection",
@@ -40,6 +45,10 @@ var DatabricksSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -478,17 +487,17 @@ func (r *DatabricksConnectionResource) Create(ctx context.Context, req resource.
 		"azure_account_name":           types.StringType,
 		"bulk_sync_staging_schema":     types.StringType,
 		"cloud_provider":               types.StringType,
-		"concurrent_queries":           types.NumberType,
+		"concurrent_queries":           types.Int64Type,
 		"container_name":               types.StringType,
 		"databricks_auth_mode":         types.StringType,
-		"deleted_file_retention_days":  types.NumberType,
+		"deleted_file_retention_days":  types.Int64Type,
 		"enable_delta_uniform":         types.BoolType,
 		"enforce_query_limit":          types.BoolType,
 		"external_id":                  types.StringType,
 		"http_path":                    types.StringType,
 		"iam_role_arn":                 types.StringType,
-		"log_file_retention_days":      types.NumberType,
-		"port":                         types.NumberType,
+		"log_file_retention_days":      types.Int64Type,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"server_hostname":              types.StringType,
@@ -498,7 +507,7 @@ func (r *DatabricksConnectionResource) Create(ctx context.Context, req resource.
 		"ssh":                          types.BoolType,
 		"ssh_blob_storage":             types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_private_key":              types.StringType,
 		"ssh_user":                     types.StringType,
 		"storage_credential_name":      types.StringType,
@@ -514,6 +523,7 @@ func (r *DatabricksConnectionResource) Create(ctx context.Context, req resource.
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *DatabricksConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -583,17 +593,17 @@ func (r *DatabricksConnectionResource) Read(ctx context.Context, req resource.Re
 		"azure_account_name":           types.StringType,
 		"bulk_sync_staging_schema":     types.StringType,
 		"cloud_provider":               types.StringType,
-		"concurrent_queries":           types.NumberType,
+		"concurrent_queries":           types.Int64Type,
 		"container_name":               types.StringType,
 		"databricks_auth_mode":         types.StringType,
-		"deleted_file_retention_days":  types.NumberType,
+		"deleted_file_retention_days":  types.Int64Type,
 		"enable_delta_uniform":         types.BoolType,
 		"enforce_query_limit":          types.BoolType,
 		"external_id":                  types.StringType,
 		"http_path":                    types.StringType,
 		"iam_role_arn":                 types.StringType,
-		"log_file_retention_days":      types.NumberType,
-		"port":                         types.NumberType,
+		"log_file_retention_days":      types.Int64Type,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"server_hostname":              types.StringType,
@@ -603,7 +613,7 @@ func (r *DatabricksConnectionResource) Read(ctx context.Context, req resource.Re
 		"ssh":                          types.BoolType,
 		"ssh_blob_storage":             types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_private_key":              types.StringType,
 		"ssh_user":                     types.StringType,
 		"storage_credential_name":      types.StringType,
@@ -617,6 +627,7 @@ func (r *DatabricksConnectionResource) Read(ctx context.Context, req resource.Re
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *DatabricksConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -698,17 +709,17 @@ func (r *DatabricksConnectionResource) Update(ctx context.Context, req resource.
 		"azure_account_name":           types.StringType,
 		"bulk_sync_staging_schema":     types.StringType,
 		"cloud_provider":               types.StringType,
-		"concurrent_queries":           types.NumberType,
+		"concurrent_queries":           types.Int64Type,
 		"container_name":               types.StringType,
 		"databricks_auth_mode":         types.StringType,
-		"deleted_file_retention_days":  types.NumberType,
+		"deleted_file_retention_days":  types.Int64Type,
 		"enable_delta_uniform":         types.BoolType,
 		"enforce_query_limit":          types.BoolType,
 		"external_id":                  types.StringType,
 		"http_path":                    types.StringType,
 		"iam_role_arn":                 types.StringType,
-		"log_file_retention_days":      types.NumberType,
-		"port":                         types.NumberType,
+		"log_file_retention_days":      types.Int64Type,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"server_hostname":              types.StringType,
@@ -718,7 +729,7 @@ func (r *DatabricksConnectionResource) Update(ctx context.Context, req resource.
 		"ssh":                          types.BoolType,
 		"ssh_blob_storage":             types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_private_key":              types.StringType,
 		"ssh_user":                     types.StringType,
 		"storage_credential_name":      types.StringType,
@@ -731,6 +742,7 @@ func (r *DatabricksConnectionResource) Update(ctx context.Context, req resource.
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *DatabricksConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -797,5 +809,23 @@ func (r *DatabricksConnectionResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *DatabricksConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *DatabricksConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *DatabricksConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *DatabricksConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "databricks", req, stream)
+}
+
+func (r *DatabricksConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("databricks", DatabricksSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &MarketoConnectionResource{}
 var _ resource.ResourceWithImportState = &MarketoConnectionResource{}
+var _ resource.ResourceWithMoveState = &MarketoConnectionResource{}
+var _ resource.ResourceWithIdentity = &MarketoConnectionResource{}
+var _ list.ListResourceWithConfigure = &MarketoConnectionResource{}
 
 var MarketoSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Marketo Connection",
@@ -37,6 +42,10 @@ var MarketoSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -207,8 +216,8 @@ func (r *MarketoConnectionResource) Create(ctx context.Context, req resource.Cre
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"client_id":            types.StringType,
 		"client_secret":        types.StringType,
-		"concurrent_imports":   types.NumberType,
-		"daily_api_calls":      types.NumberType,
+		"concurrent_imports":   types.Int64Type,
+		"daily_api_calls":      types.Int64Type,
 		"enforce_api_limits":   types.BoolType,
 		"include_static_lists": types.BoolType,
 		"rest_endpoint":        types.StringType,
@@ -222,6 +231,7 @@ func (r *MarketoConnectionResource) Create(ctx context.Context, req resource.Cre
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *MarketoConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -284,8 +294,8 @@ func (r *MarketoConnectionResource) Read(ctx context.Context, req resource.ReadR
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"client_id":            types.StringType,
 		"client_secret":        types.StringType,
-		"concurrent_imports":   types.NumberType,
-		"daily_api_calls":      types.NumberType,
+		"concurrent_imports":   types.Int64Type,
+		"daily_api_calls":      types.Int64Type,
 		"enforce_api_limits":   types.BoolType,
 		"include_static_lists": types.BoolType,
 		"rest_endpoint":        types.StringType,
@@ -297,6 +307,7 @@ func (r *MarketoConnectionResource) Read(ctx context.Context, req resource.ReadR
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *MarketoConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -371,8 +382,8 @@ func (r *MarketoConnectionResource) Update(ctx context.Context, req resource.Upd
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"client_id":            types.StringType,
 		"client_secret":        types.StringType,
-		"concurrent_imports":   types.NumberType,
-		"daily_api_calls":      types.NumberType,
+		"concurrent_imports":   types.Int64Type,
+		"daily_api_calls":      types.Int64Type,
 		"enforce_api_limits":   types.BoolType,
 		"include_static_lists": types.BoolType,
 		"rest_endpoint":        types.StringType,
@@ -383,6 +394,7 @@ func (r *MarketoConnectionResource) Update(ctx context.Context, req resource.Upd
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *MarketoConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -449,5 +461,23 @@ func (r *MarketoConnectionResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *MarketoConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *MarketoConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *MarketoConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *MarketoConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "marketo", req, stream)
+}
+
+func (r *MarketoConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("marketo", MarketoSchema),
+	}
 }
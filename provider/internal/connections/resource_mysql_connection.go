@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &MysqlConnectionResource{}
 var _ resource.ResourceWithImportState = &MysqlConnectionResource{}
+var _ resource.ResourceWithMoveState = &MysqlConnectionResource{}
+var _ resource.ResourceWithIdentity = &MysqlConnectionResource{}
+var _ list.ListResourceWithConfigure = &MysqlConnectionResource{}
 
 var MysqlSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: MySQL Connection",
@@ -37,6 +42,10 @@ var MysqlSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -253,10 +262,10 @@ func (r *MysqlConnectionResource) Create(ctx context.Context, req resource.Creat
 		"dbname":           types.StringType,
 		"hostname":         types.StringType,
 		"passwd":           types.StringType,
-		"port":             types.NumberType,
+		"port":             types.Int64Type,
 		"ssh":              types.BoolType,
 		"ssh_host":         types.StringType,
-		"ssh_port":         types.NumberType,
+		"ssh_port":         types.Int64Type,
 		"ssh_private_key":  types.StringType,
 		"ssh_user":         types.StringType,
 		"ssl":              types.BoolType,
@@ -270,6 +279,7 @@ func (r *MysqlConnectionResource) Create(ctx context.Context, req resource.Creat
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *MysqlConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -335,10 +345,10 @@ func (r *MysqlConnectionResource) Read(ctx context.Context, req resource.ReadReq
 		"dbname":           types.StringType,
 		"hostname":         types.StringType,
 		"passwd":           types.StringType,
-		"port":             types.NumberType,
+		"port":             types.Int64Type,
 		"ssh":              types.BoolType,
 		"ssh_host":         types.StringType,
-		"ssh_port":         types.NumberType,
+		"ssh_port":         types.Int64Type,
 		"ssh_private_key":  types.StringType,
 		"ssh_user":         types.StringType,
 		"ssl":              types.BoolType,
@@ -350,6 +360,7 @@ func (r *MysqlConnectionResource) Read(ctx context.Context, req resource.ReadReq
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *MysqlConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -427,10 +438,10 @@ func (r *MysqlConnectionResource) Update(ctx context.Context, req resource.Updat
 		"dbname":           types.StringType,
 		"hostname":         types.StringType,
 		"passwd":           types.StringType,
-		"port":             types.NumberType,
+		"port":             types.Int64Type,
 		"ssh":              types.BoolType,
 		"ssh_host":         types.StringType,
-		"ssh_port":         types.NumberType,
+		"ssh_port":         types.Int64Type,
 		"ssh_private_key":  types.StringType,
 		"ssh_user":         types.StringType,
 		"ssl":              types.BoolType,
@@ -441,6 +452,7 @@ func (r *MysqlConnectionResource) Update(ctx context.Context, req resource.Updat
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *MysqlConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -507,5 +519,23 @@ func (r *MysqlConnectionResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *MysqlConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *MysqlConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *MysqlConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *MysqlConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "mysql", req, stream)
+}
+
+func (r *MysqlConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("mysql", MysqlSchema),
+	}
 }
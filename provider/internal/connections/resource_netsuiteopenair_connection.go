@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &NetsuiteopenairConnectionResource{}
 var _ resource.ResourceWithImportState = &NetsuiteopenairConnectionResource{}
+var _ resource.ResourceWithMoveState = &NetsuiteopenairConnectionResource{}
+var _ resource.ResourceWithIdentity = &NetsuiteopenairConnectionResource{}
+var _ list.ListResourceWithConfigure = &NetsuiteopenairConnectionResource{}
 
 var NetsuiteopenairSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: NetSuite OpenAir Connection",
@@ -37,6 +42,10 @@ var NetsuiteopenairSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -216,8 +225,8 @@ func (r *NetsuiteopenairConnectionResource) Create(ctx context.Context, req reso
 		"client_id":             types.StringType,
 		"client_secret":         types.StringType,
 		"company_id":            types.StringType,
-		"per_day_rate_limit":    types.NumberType,
-		"per_minute_rate_limit": types.NumberType,
+		"per_day_rate_limit":    types.Int64Type,
+		"per_minute_rate_limit": types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -228,6 +237,7 @@ func (r *NetsuiteopenairConnectionResource) Create(ctx context.Context, req reso
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *NetsuiteopenairConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -293,8 +303,8 @@ func (r *NetsuiteopenairConnectionResource) Read(ctx context.Context, req resour
 		"client_id":             types.StringType,
 		"client_secret":         types.StringType,
 		"company_id":            types.StringType,
-		"per_day_rate_limit":    types.NumberType,
-		"per_minute_rate_limit": types.NumberType,
+		"per_day_rate_limit":    types.Int64Type,
+		"per_minute_rate_limit": types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -303,6 +313,7 @@ func (r *NetsuiteopenairConnectionResource) Read(ctx context.Context, req resour
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *NetsuiteopenairConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -380,8 +391,8 @@ func (r *NetsuiteopenairConnectionResource) Update(ctx context.Context, req reso
 		"client_id":             types.StringType,
 		"client_secret":         types.StringType,
 		"company_id":            types.StringType,
-		"per_day_rate_limit":    types.NumberType,
-		"per_minute_rate_limit": types.NumberType,
+		"per_day_rate_limit":    types.Int64Type,
+		"per_minute_rate_limit": types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -389,6 +400,7 @@ func (r *NetsuiteopenairConnectionResource) Update(ctx context.Context, req reso
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *NetsuiteopenairConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -455,5 +467,23 @@ func (r *NetsuiteopenairConnectionResource) Delete(ctx context.Context, req reso
 }
 
 func (r *NetsuiteopenairConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *NetsuiteopenairConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *NetsuiteopenairConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *NetsuiteopenairConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "netsuiteopenair", req, stream)
+}
+
+func (r *NetsuiteopenairConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("netsuiteopenair", NetsuiteopenairSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &PardotConnectionResource{}
 var _ resource.ResourceWithImportState = &PardotConnectionResource{}
+var _ resource.ResourceWithMoveState = &PardotConnectionResource{}
+var _ resource.ResourceWithIdentity = &PardotConnectionResource{}
+var _ list.ListResourceWithConfigure = &PardotConnectionResource{}
 
 var PardotSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Pardot Connection",
@@ -40,6 +45,10 @@ var PardotSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -194,7 +203,7 @@ func (r *PardotConnectionResource) Create(ctx context.Context, req resource.Crea
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"account_type":       types.StringType,
 		"business_unit_id":   types.StringType,
-		"daily_api_calls":    types.NumberType,
+		"daily_api_calls":    types.Int64Type,
 		"enforce_api_limits": types.BoolType,
 		"username":           types.StringType,
 	}, conf)
@@ -207,6 +216,7 @@ func (r *PardotConnectionResource) Create(ctx context.Context, req resource.Crea
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *PardotConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -269,7 +279,7 @@ func (r *PardotConnectionResource) Read(ctx context.Context, req resource.ReadRe
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"account_type":       types.StringType,
 		"business_unit_id":   types.StringType,
-		"daily_api_calls":    types.NumberType,
+		"daily_api_calls":    types.Int64Type,
 		"enforce_api_limits": types.BoolType,
 		"username":           types.StringType,
 	}, conf)
@@ -280,6 +290,7 @@ func (r *PardotConnectionResource) Read(ctx context.Context, req resource.ReadRe
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *PardotConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -354,7 +365,7 @@ func (r *PardotConnectionResource) Update(ctx context.Context, req resource.Upda
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"account_type":       types.StringType,
 		"business_unit_id":   types.StringType,
-		"daily_api_calls":    types.NumberType,
+		"daily_api_calls":    types.Int64Type,
 		"enforce_api_limits": types.BoolType,
 		"username":           types.StringType,
 	}, conf)
@@ -364,6 +375,7 @@ func (r *PardotConnectionResource) Update(ctx context.Context, req resource.Upda
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *PardotConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -430,5 +442,23 @@ func (r *PardotConnectionResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *PardotConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *PardotConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *PardotConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *PardotConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "pardot", req, stream)
+}
+
+func (r *PardotConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("pardot", PardotSchema),
+	}
 }
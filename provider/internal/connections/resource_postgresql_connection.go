@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &PostgresqlConnectionResource{}
 var _ resource.ResourceWithImportState = &PostgresqlConnectionResource{}
+var _ resource.ResourceWithMoveState = &PostgresqlConnectionResource{}
+var _ resource.ResourceWithIdentity = &PostgresqlConnectionResource{}
+var _ list.ListResourceWithConfigure = &PostgresqlConnectionResource{}
 
 var PostgresqlSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: PostgreSQL Connection",
@@ -37,6 +42,10 @@ var PostgresqlSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -305,11 +314,11 @@ func (r *PostgresqlConnectionResource) Create(ctx context.Context, req resource.
 		"database":           types.StringType,
 		"hostname":           types.StringType,
 		"password":           types.StringType,
-		"port":               types.NumberType,
+		"port":               types.Int64Type,
 		"publication":        types.StringType,
 		"ssh":                types.BoolType,
 		"ssh_host":           types.StringType,
-		"ssh_port":           types.NumberType,
+		"ssh_port":           types.Int64Type,
 		"ssh_private_key":    types.StringType,
 		"ssh_user":           types.StringType,
 		"ssl":                types.BoolType,
@@ -324,6 +333,7 @@ func (r *PostgresqlConnectionResource) Create(ctx context.Context, req resource.
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *PostgresqlConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -392,11 +402,11 @@ func (r *PostgresqlConnectionResource) Read(ctx context.Context, req resource.Re
 		"database":           types.StringType,
 		"hostname":           types.StringType,
 		"password":           types.StringType,
-		"port":               types.NumberType,
+		"port":               types.Int64Type,
 		"publication":        types.StringType,
 		"ssh":                types.BoolType,
 		"ssh_host":           types.StringType,
-		"ssh_port":           types.NumberType,
+		"ssh_port":           types.Int64Type,
 		"ssh_private_key":    types.StringType,
 		"ssh_user":           types.StringType,
 		"ssl":                types.BoolType,
@@ -409,6 +419,7 @@ func (r *PostgresqlConnectionResource) Read(ctx context.Context, req resource.Re
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *PostgresqlConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -489,11 +500,11 @@ func (r *PostgresqlConnectionResource) Update(ctx context.Context, req resource.
 		"database":           types.StringType,
 		"hostname":           types.StringType,
 		"password":           types.StringType,
-		"port":               types.NumberType,
+		"port":               types.Int64Type,
 		"publication":        types.StringType,
 		"ssh":                types.BoolType,
 		"ssh_host":           types.StringType,
-		"ssh_port":           types.NumberType,
+		"ssh_port":           types.Int64Type,
 		"ssh_private_key":    types.StringType,
 		"ssh_user":           types.StringType,
 		"ssl":                types.BoolType,
@@ -505,6 +516,7 @@ func (r *PostgresqlConnectionResource) Update(ctx context.Context, req resource.
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *PostgresqlConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -571,5 +583,23 @@ func (r *PostgresqlConnectionResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *PostgresqlConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *PostgresqlConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *PostgresqlConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *PostgresqlConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "postgresql", req, stream)
+}
+
+func (r *PostgresqlConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("postgresql", PostgresqlSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &RedshiftConnectionResource{}
 var _ resource.ResourceWithImportState = &RedshiftConnectionResource{}
+var _ resource.ResourceWithMoveState = &RedshiftConnectionResource{}
+var _ resource.ResourceWithIdentity = &RedshiftConnectionResource{}
+var _ list.ListResourceWithConfigure = &RedshiftConnectionResource{}
 
 var RedshiftSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Redshift Connection",
@@ -40,6 +45,10 @@ var RedshiftSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -341,12 +350,12 @@ func (r *RedshiftConnectionResource) Create(ctx context.Context, req resource.Cr
 		"hostname":                     types.StringType,
 		"iam_role_arn":                 types.StringType,
 		"password":                     types.StringType,
-		"port":                         types.NumberType,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"ssh":                          types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_private_key":              types.StringType,
 		"ssh_user":                     types.StringType,
 		"use_bulk_sync_staging_schema": types.BoolType,
@@ -361,6 +370,7 @@ func (r *RedshiftConnectionResource) Create(ctx context.Context, req resource.Cr
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *RedshiftConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -431,12 +441,12 @@ func (r *RedshiftConnectionResource) Read(ctx context.Context, req resource.Read
 		"hostname":                     types.StringType,
 		"iam_role_arn":                 types.StringType,
 		"password":                     types.StringType,
-		"port":                         types.NumberType,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"ssh":                          types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_private_key":              types.StringType,
 		"ssh_user":                     types.StringType,
 		"use_bulk_sync_staging_schema": types.BoolType,
@@ -449,6 +459,7 @@ func (r *RedshiftConnectionResource) Read(ctx context.Context, req resource.Read
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *RedshiftConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -531,12 +542,12 @@ func (r *RedshiftConnectionResource) Update(ctx context.Context, req resource.Up
 		"hostname":                     types.StringType,
 		"iam_role_arn":                 types.StringType,
 		"password":                     types.StringType,
-		"port":                         types.NumberType,
+		"port":                         types.Int64Type,
 		"s3_bucket_name":               types.StringType,
 		"s3_bucket_region":             types.StringType,
 		"ssh":                          types.BoolType,
 		"ssh_host":                     types.StringType,
-		"ssh_port":                     types.NumberType,
+		"ssh_port":                     types.Int64Type,
 		"ssh_private_key":              types.StringType,
 		"ssh_user":                     types.StringType,
 		"use_bulk_sync_staging_schema": types.BoolType,
@@ -548,6 +559,7 @@ func (r *RedshiftConnectionResource) Update(ctx context.Context, req resource.Up
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *RedshiftConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -614,5 +626,23 @@ func (r *RedshiftConnectionResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *RedshiftConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *RedshiftConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *RedshiftConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *RedshiftConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "redshift", req, stream)
+}
+
+func (r *RedshiftConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("redshift", RedshiftSchema),
+	}
 }
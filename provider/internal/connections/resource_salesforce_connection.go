@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &SalesforceConnectionResource{}
 var _ resource.ResourceWithImportState = &SalesforceConnectionResource{}
+var _ resource.ResourceWithMoveState = &SalesforceConnectionResource{}
+var _ resource.ResourceWithIdentity = &SalesforceConnectionResource{}
+var _ list.ListResourceWithConfigure = &SalesforceConnectionResource{}
 
 var SalesforceSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Salesforce Connection",
@@ -40,6 +45,10 @@ var SalesforceSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -262,7 +271,7 @@ func (r *SalesforceConnectionResource) Create(ctx context.Context, req resource.
 		"client_id":                   types.StringType,
 		"client_secret":               types.StringType,
 		"connect_mode":                types.StringType,
-		"daily_api_calls":             types.NumberType,
+		"daily_api_calls":             types.Int64Type,
 		"domain":                      types.StringType,
 		"enable_multicurrency_lookup": types.BoolType,
 		"enable_tooling":              types.BoolType,
@@ -280,6 +289,7 @@ func (r *SalesforceConnectionResource) Create(ctx context.Context, req resource.
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SalesforceConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -343,7 +353,7 @@ func (r *SalesforceConnectionResource) Read(ctx context.Context, req resource.Re
 		"client_id":                   types.StringType,
 		"client_secret":               types.StringType,
 		"connect_mode":                types.StringType,
-		"daily_api_calls":             types.NumberType,
+		"daily_api_calls":             types.Int64Type,
 		"domain":                      types.StringType,
 		"enable_multicurrency_lookup": types.BoolType,
 		"enable_tooling":              types.BoolType,
@@ -359,6 +369,7 @@ func (r *SalesforceConnectionResource) Read(ctx context.Context, req resource.Re
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SalesforceConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -434,7 +445,7 @@ func (r *SalesforceConnectionResource) Update(ctx context.Context, req resource.
 		"client_id":                   types.StringType,
 		"client_secret":               types.StringType,
 		"connect_mode":                types.StringType,
-		"daily_api_calls":             types.NumberType,
+		"daily_api_calls":             types.Int64Type,
 		"domain":                      types.StringType,
 		"enable_multicurrency_lookup": types.BoolType,
 		"enable_tooling":              types.BoolType,
@@ -449,6 +460,7 @@ func (r *SalesforceConnectionResource) Update(ctx context.Context, req resource.
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SalesforceConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -515,5 +527,23 @@ func (r *SalesforceConnectionResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *SalesforceConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *SalesforceConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *SalesforceConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *SalesforceConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "salesforce", req, stream)
+}
+
+func (r *SalesforceConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("salesforce", SalesforceSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &ScylladbConnectionResource{}
 var _ resource.ResourceWithImportState = &ScylladbConnectionResource{}
+var _ resource.ResourceWithMoveState = &ScylladbConnectionResource{}
+var _ resource.ResourceWithIdentity = &ScylladbConnectionResource{}
+var _ list.ListResourceWithConfigure = &ScylladbConnectionResource{}
 
 var ScylladbSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: ScyllaDB Connection",
@@ -37,6 +42,10 @@ var ScylladbSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -281,7 +290,7 @@ func (r *ScylladbConnectionResource) Create(ctx context.Context, req resource.Cr
 		"skip_verify":        types.BoolType,
 		"ssh":                types.BoolType,
 		"ssh_host":           types.StringType,
-		"ssh_port":           types.NumberType,
+		"ssh_port":           types.Int64Type,
 		"ssh_private_key":    types.StringType,
 		"ssh_user":           types.StringType,
 		"tls":                types.BoolType,
@@ -296,6 +305,7 @@ func (r *ScylladbConnectionResource) Create(ctx context.Context, req resource.Cr
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ScylladbConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -365,7 +375,7 @@ func (r *ScylladbConnectionResource) Read(ctx context.Context, req resource.Read
 		"skip_verify":        types.BoolType,
 		"ssh":                types.BoolType,
 		"ssh_host":           types.StringType,
-		"ssh_port":           types.NumberType,
+		"ssh_port":           types.Int64Type,
 		"ssh_private_key":    types.StringType,
 		"ssh_user":           types.StringType,
 		"tls":                types.BoolType,
@@ -378,6 +388,7 @@ func (r *ScylladbConnectionResource) Read(ctx context.Context, req resource.Read
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ScylladbConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -459,7 +470,7 @@ func (r *ScylladbConnectionResource) Update(ctx context.Context, req resource.Up
 		"skip_verify":        types.BoolType,
 		"ssh":                types.BoolType,
 		"ssh_host":           types.StringType,
-		"ssh_port":           types.NumberType,
+		"ssh_port":           types.Int64Type,
 		"ssh_private_key":    types.StringType,
 		"ssh_user":           types.StringType,
 		"tls":                types.BoolType,
@@ -471,6 +482,7 @@ func (r *ScylladbConnectionResource) Update(ctx context.Context, req resource.Up
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *ScylladbConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -537,5 +549,23 @@ func (r *ScylladbConnectionResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *ScylladbConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *ScylladbConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *ScylladbConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *ScylladbConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "scylladb", req, stream)
+}
+
+func (r *ScylladbConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("scylladb", ScylladbSchema),
+	}
 }
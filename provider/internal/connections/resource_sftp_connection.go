@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &SftpConnectionResource{}
 var _ resource.ResourceWithImportState = &SftpConnectionResource{}
+var _ resource.ResourceWithMoveState = &SftpConnectionResource{}
+var _ resource.ResourceWithIdentity = &SftpConnectionResource{}
+var _ list.ListResourceWithConfigure = &SftpConnectionResource{}
 
 var SftpSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: SFTP Connection",
@@ -40,6 +45,10 @@ var SftpSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -246,10 +255,10 @@ func (r *SftpConnectionResource) Create(ctx context.Context, req resource.Create
 		"is_single_table":   types.BoolType,
 		"path":              types.StringType,
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 		"ssh_host":          types.StringType,
 		"ssh_password":      types.StringType,
-		"ssh_port":          types.NumberType,
+		"ssh_port":          types.Int64Type,
 		"ssh_private_key":   types.StringType,
 		"ssh_user":          types.StringType,
 	}, conf)
@@ -262,6 +271,7 @@ func (r *SftpConnectionResource) Create(ctx context.Context, req resource.Create
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SftpConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -326,10 +336,10 @@ func (r *SftpConnectionResource) Read(ctx context.Context, req resource.ReadRequ
 		"is_single_table":   types.BoolType,
 		"path":              types.StringType,
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 		"ssh_host":          types.StringType,
 		"ssh_password":      types.StringType,
-		"ssh_port":          types.NumberType,
+		"ssh_port":          types.Int64Type,
 		"ssh_private_key":   types.StringType,
 		"ssh_user":          types.StringType,
 	}, conf)
@@ -340,6 +350,7 @@ func (r *SftpConnectionResource) Read(ctx context.Context, req resource.ReadRequ
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SftpConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -416,10 +427,10 @@ func (r *SftpConnectionResource) Update(ctx context.Context, req resource.Update
 		"is_single_table":   types.BoolType,
 		"path":              types.StringType,
 		"single_table_name": types.StringType,
-		"skip_lines":        types.NumberType,
+		"skip_lines":        types.Int64Type,
 		"ssh_host":          types.StringType,
 		"ssh_password":      types.StringType,
-		"ssh_port":          types.NumberType,
+		"ssh_port":          types.Int64Type,
 		"ssh_private_key":   types.StringType,
 		"ssh_user":          types.StringType,
 	}, conf)
@@ -429,6 +440,7 @@ func (r *SftpConnectionResource) Update(ctx context.Context, req resource.Update
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SftpConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -495,5 +507,23 @@ func (r *SftpConnectionResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *SftpConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *SftpConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *SftpConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *SftpConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "sftp", req, stream)
+}
+
+func (r *SftpConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("sftp", SftpSchema),
+	}
 }
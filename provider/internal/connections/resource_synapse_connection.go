@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &SynapseConnectionResource{}
 var _ resource.ResourceWithImportState = &SynapseConnectionResource{}
+var _ resource.ResourceWithMoveState = &SynapseConnectionResource{}
+var _ resource.ResourceWithIdentity = &SynapseConnectionResource{}
+var _ list.ListResourceWithConfigure = &SynapseConnectionResource{}
 
 var SynapseSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Azure Synapse Connection",
@@ -37,6 +42,10 @@ var SynapseSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -192,7 +201,7 @@ func (r *SynapseConnectionResource) Create(ctx context.Context, req resource.Cre
 		"database": types.StringType,
 		"hostname": types.StringType,
 		"password": types.StringType,
-		"port":     types.NumberType,
+		"port":     types.Int64Type,
 		"username": types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -204,6 +213,7 @@ func (r *SynapseConnectionResource) Create(ctx context.Context, req resource.Cre
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SynapseConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -267,7 +277,7 @@ func (r *SynapseConnectionResource) Read(ctx context.Context, req resource.ReadR
 		"database": types.StringType,
 		"hostname": types.StringType,
 		"password": types.StringType,
-		"port":     types.NumberType,
+		"port":     types.Int64Type,
 		"username": types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -277,6 +287,7 @@ func (r *SynapseConnectionResource) Read(ctx context.Context, req resource.ReadR
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SynapseConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -352,7 +363,7 @@ func (r *SynapseConnectionResource) Update(ctx context.Context, req resource.Upd
 		"database": types.StringType,
 		"hostname": types.StringType,
 		"password": types.StringType,
-		"port":     types.NumberType,
+		"port":     types.Int64Type,
 		"username": types.StringType,
 	}, conf)
 	if diags.HasError() {
@@ -361,6 +372,7 @@ func (r *SynapseConnectionResource) Update(ctx context.Context, req resource.Upd
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *SynapseConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -427,5 +439,23 @@ func (r *SynapseConnectionResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *SynapseConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *SynapseConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *SynapseConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *SynapseConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "synapse", req, stream)
+}
+
+func (r *SynapseConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("synapse", SynapseSchema),
+	}
 }
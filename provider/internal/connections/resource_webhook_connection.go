@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &WebhookConnectionResource{}
 var _ resource.ResourceWithImportState = &WebhookConnectionResource{}
+var _ resource.ResourceWithMoveState = &WebhookConnectionResource{}
+var _ resource.ResourceWithIdentity = &WebhookConnectionResource{}
+var _ list.ListResourceWithConfigure = &WebhookConnectionResource{}
 
 var WebhookSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Webhook Connection",
@@ -40,6 +45,10 @@ var WebhookSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -394,7 +403,7 @@ func (r *WebhookConnectionResource) Create(ctx context.Context, req resource.Cre
 		},
 		"oauth": types.ObjectType{
 			AttrTypes: map[string]attr.Type{
-				"auth_style":    types.NumberType,
+				"auth_style":    types.Int64Type,
 				"client_id":     types.StringType,
 				"client_secret": types.StringType,
 				"extra_form_data": types.SetType{
@@ -430,6 +439,7 @@ func (r *WebhookConnectionResource) Create(ctx context.Context, req resource.Cre
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *WebhookConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -511,7 +521,7 @@ func (r *WebhookConnectionResource) Read(ctx context.Context, req resource.ReadR
 		},
 		"oauth": types.ObjectType{
 			AttrTypes: map[string]attr.Type{
-				"auth_style":    types.NumberType,
+				"auth_style":    types.Int64Type,
 				"client_id":     types.StringType,
 				"client_secret": types.StringType,
 				"extra_form_data": types.SetType{
@@ -545,6 +555,7 @@ func (r *WebhookConnectionResource) Read(ctx context.Context, req resource.ReadR
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *WebhookConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -638,7 +649,7 @@ func (r *WebhookConnectionResource) Update(ctx context.Context, req resource.Upd
 		},
 		"oauth": types.ObjectType{
 			AttrTypes: map[string]attr.Type{
-				"auth_style":    types.NumberType,
+				"auth_style":    types.Int64Type,
 				"client_id":     types.StringType,
 				"client_secret": types.StringType,
 				"extra_form_data": types.SetType{
@@ -671,6 +682,7 @@ func (r *WebhookConnectionResource) Update(ctx context.Context, req resource.Upd
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *WebhookConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -737,5 +749,23 @@ func (r *WebhookConnectionResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *WebhookConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *WebhookConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *WebhookConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *WebhookConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "webhook", req, stream)
+}
+
+func (r *WebhookConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("webhook", WebhookSchema),
+	}
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,12 +23,17 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &Zendesk_chatConnectionResource{}
 var _ resource.ResourceWithImportState = &Zendesk_chatConnectionResource{}
+var _ resource.ResourceWithMoveState = &Zendesk_chatConnectionResource{}
+var _ resource.ResourceWithIdentity = &Zendesk_chatConnectionResource{}
+var _ list.ListResourceWithConfigure = &Zendesk_chatConnectionResource{}
 
 var Zendesk_chatSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Zendesk Chat Connection",
@@ -37,6 +42,10 @@ var Zendesk_chatSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -174,7 +183,7 @@ func (r *Zendesk_chatConnectionResource) Create(ctx context.Context, req resourc
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"custom_api_limits": types.BoolType,
 		"domain":            types.StringType,
-		"ratelimit_rpm":     types.NumberType,
+		"ratelimit_rpm":     types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -185,6 +194,7 @@ func (r *Zendesk_chatConnectionResource) Create(ctx context.Context, req resourc
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Zendesk_chatConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -247,7 +257,7 @@ func (r *Zendesk_chatConnectionResource) Read(ctx context.Context, req resource.
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"custom_api_limits": types.BoolType,
 		"domain":            types.StringType,
-		"ratelimit_rpm":     types.NumberType,
+		"ratelimit_rpm":     types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -256,6 +266,7 @@ func (r *Zendesk_chatConnectionResource) Read(ctx context.Context, req resource.
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Zendesk_chatConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -330,7 +341,7 @@ func (r *Zendesk_chatConnectionResource) Update(ctx context.Context, req resourc
 	data.Configuration, diags = types.ObjectValueFrom(ctx, map[string]attr.Type{
 		"custom_api_limits": types.BoolType,
 		"domain":            types.StringType,
-		"ratelimit_rpm":     types.NumberType,
+		"ratelimit_rpm":     types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -338,6 +349,7 @@ func (r *Zendesk_chatConnectionResource) Update(ctx context.Context, req resourc
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Zendesk_chatConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -404,5 +416,23 @@ func (r *Zendesk_chatConnectionResource) Delete(ctx context.Context, req resourc
 }
 
 func (r *Zendesk_chatConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *Zendesk_chatConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *Zendesk_chatConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *Zendesk_chatConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "zendesk_chat", req, stream)
+}
+
+func (r *Zendesk_chatConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("zendesk_chat", Zendesk_chatSchema),
+	}
 }
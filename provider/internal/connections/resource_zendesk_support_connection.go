@@ -12,7 +12,7 @@ import (
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -23,7 +23,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,6 +34,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &Zendesk_supportConnectionResource{}
 var _ resource.ResourceWithImportState = &Zendesk_supportConnectionResource{}
+var _ resource.ResourceWithMoveState = &Zendesk_supportConnectionResource{}
+var _ resource.ResourceWithIdentity = &Zendesk_supportConnectionResource{}
+var _ list.ListResourceWithConfigure = &Zendesk_supportConnectionResource{}
 
 var Zendesk_supportSchema = schema.Schema{
 	MarkdownDescription: ":meta:subcategory:Connections: Zendesk Support Connection",
@@ -40,6 +45,10 @@ var Zendesk_supportSchema = schema.Schema{
 			MarkdownDescription: "Organization ID",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
 		},
 		"name": schema.StringAttribute{
 			Required: true,
@@ -246,7 +255,7 @@ func (r *Zendesk_supportConnectionResource) Create(ctx context.Context, req reso
 		"domain":              types.StringType,
 		"email":               types.StringType,
 		"oauth_refresh_token": types.StringType,
-		"ratelimit_rpm":       types.NumberType,
+		"ratelimit_rpm":       types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -257,6 +266,7 @@ func (r *Zendesk_supportConnectionResource) Create(ctx context.Context, req reso
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Zendesk_supportConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -325,7 +335,7 @@ func (r *Zendesk_supportConnectionResource) Read(ctx context.Context, req resour
 		"domain":              types.StringType,
 		"email":               types.StringType,
 		"oauth_refresh_token": types.StringType,
-		"ratelimit_rpm":       types.NumberType,
+		"ratelimit_rpm":       types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -334,6 +344,7 @@ func (r *Zendesk_supportConnectionResource) Read(ctx context.Context, req resour
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Zendesk_supportConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -414,7 +425,7 @@ func (r *Zendesk_supportConnectionResource) Update(ctx context.Context, req reso
 		"domain":              types.StringType,
 		"email":               types.StringType,
 		"oauth_refresh_token": types.StringType,
-		"ratelimit_rpm":       types.NumberType,
+		"ratelimit_rpm":       types.Int64Type,
 	}, conf)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -422,6 +433,7 @@ func (r *Zendesk_supportConnectionResource) Update(ctx context.Context, req reso
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *Zendesk_supportConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -488,5 +500,23 @@ func (r *Zendesk_supportConnectionResource) Delete(ctx context.Context, req reso
 }
 
 func (r *Zendesk_supportConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *Zendesk_supportConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *Zendesk_supportConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *Zendesk_supportConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	listConnections(ctx, r, r.provider, "zendesk_support", req, stream)
+}
+
+func (r *Zendesk_supportConnectionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveFromGenericConnection("zendesk_support", Zendesk_supportSchema),
+	}
 }
@@ -0,0 +1,244 @@
+package connections
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// connectionStateUpgraders returns state upgraders from each earlier version
+// of a generated connection resource's schema to target.
+//
+// The generator only changes a connection's configuration attributes between
+// versions, so every version is upgraded the same way: values of unchanged
+// attributes are carried over, attributes target doesn't define are dropped,
+// and values whose type changed are converted where possible (e.g. "5432" to
+// 5432, or a string to a set of one string). Values which can't be converted
+// are dropped, and are refreshed from the API on the next read.
+func connectionStateUpgraders(target schema.Schema) map[int64]resource.StateUpgrader {
+	upgraders := map[int64]resource.StateUpgrader{}
+	for v := int64(0); v < target.Version; v++ {
+		upgraders[v] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil {
+					resp.Diagnostics.AddError("Unable to upgrade connection", "Prior state is empty.")
+					return
+				}
+
+				value, err := convertState(ctx, target, req.RawState.JSON)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade connection", fmt.Sprintf("Error converting state: %s", err))
+					return
+				}
+				resp.State.Raw = value
+			},
+		}
+	}
+	return upgraders
+}
+
+// convertState decodes a connection's JSON state and converts its values to
+// the types of target, as described by connectionStateUpgraders.
+func convertState(ctx context.Context, target schema.Schema, state []byte) (tftypes.Value, error) {
+	dec := json.NewDecoder(bytes.NewReader(state))
+	dec.UseNumber()
+	var prior any
+	if err := dec.Decode(&prior); err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding state: %w", err)
+	}
+
+	typ := target.Type().TerraformType(ctx)
+	c := stateConverter{ctx: ctx, integers: map[string]bool{}}
+	addIntegerPaths(c.integers, "", target.Attributes)
+	converted, err := json.Marshal(c.value("", prior, typ))
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("encoding state: %w", err)
+	}
+	raw := tfprotov6.RawState{JSON: converted}
+	return raw.UnmarshalWithOpts(typ, tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+			IgnoreUndefinedAttributes: true,
+		},
+	})
+}
+
+// addIntegerPaths adds the paths of attrs which hold integers to paths.
+// Elements of a collection share the collection's path.
+func addIntegerPaths(paths map[string]bool, prefix string, attrs map[string]schema.Attribute) {
+	for name, a := range attrs {
+		path := joinStatePath(prefix, name)
+		switch a := a.(type) {
+		case schema.Int64Attribute:
+			paths[path] = true
+		case schema.SetAttribute:
+			paths[path] = a.ElementType == types.Int64Type
+		case schema.ListAttribute:
+			paths[path] = a.ElementType == types.Int64Type
+		case schema.SingleNestedAttribute:
+			addIntegerPaths(paths, path, a.Attributes)
+		case schema.SetNestedAttribute:
+			addIntegerPaths(paths, path, a.NestedObject.Attributes)
+		case schema.ListNestedAttribute:
+			addIntegerPaths(paths, path, a.NestedObject.Attributes)
+		}
+	}
+}
+
+// stateConverter converts values decoded from JSON state to the types of a
+// connection's current schema.
+type stateConverter struct {
+	ctx context.Context
+	// integers are the paths of number attributes which hold integers.
+	integers map[string]bool
+}
+
+// value converts v to typ. Values which can't be converted are dropped with
+// a warning.
+func (c stateConverter) value(path string, v any, typ tftypes.Type) any {
+	converted, ok := c.convert(path, v, typ)
+	if !ok {
+		tflog.Warn(c.ctx, "dropping state value which can't be converted to the connection's schema", map[string]any{
+			"path": path,
+			"type": typ.String(),
+		})
+		return nil
+	}
+	return converted
+}
+
+func (c stateConverter) convert(path string, v any, typ tftypes.Type) (any, bool) {
+	if v == nil {
+		return nil, true
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		values, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		out := make(map[string]any, len(values))
+		for k, val := range values {
+			attrType, ok := typ.AttributeTypes[k]
+			if !ok {
+				tflog.Warn(c.ctx, "dropping state attribute not defined by the connection resource", map[string]any{
+					"path": joinStatePath(path, k),
+				})
+				continue
+			}
+			out[k] = c.value(joinStatePath(path, k), val, attrType)
+		}
+		return out, true
+	case tftypes.Set:
+		return c.elements(path, v, typ.ElementType)
+	case tftypes.List:
+		return c.elements(path, v, typ.ElementType)
+	case tftypes.Map:
+		values, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		out := make(map[string]any, len(values))
+		for k, val := range values {
+			out[k] = c.value(joinStatePath(path, k), val, typ.ElementType)
+		}
+		return out, true
+	}
+
+	// A single element collection becomes its element.
+	if elems, ok := v.([]any); ok {
+		if len(elems) != 1 {
+			return nil, false
+		}
+		return c.convert(path, elems[0], typ)
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		switch v := v.(type) {
+		case string:
+			return v, true
+		case json.Number:
+			return v.String(), true
+		case bool:
+			return strconv.FormatBool(v), true
+		}
+	case typ.Is(tftypes.Number):
+		var n json.Number
+		switch v := v.(type) {
+		case json.Number:
+			n = v
+		case string:
+			n = json.Number(strings.TrimSpace(v))
+		default:
+			return nil, false
+		}
+		f, err := n.Float64()
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, false
+		}
+		if !c.integers[path] {
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), true
+		}
+		if i, err := n.Int64(); err == nil {
+			return json.Number(strconv.FormatInt(i, 10)), true
+		}
+		// Integers written by a floating point attribute, e.g. 5432.0.
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return nil, false
+		}
+		return json.Number(strconv.FormatInt(int64(f), 10)), true
+	case typ.Is(tftypes.Bool):
+		switch v := v.(type) {
+		case bool:
+			return v, true
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, true
+			}
+		}
+	case typ.Is(tftypes.DynamicPseudoType):
+		return v, true
+	}
+	return nil, false
+}
+
+// elements converts v to a collection of elemType. A scalar becomes a
+// collection of one element.
+func (c stateConverter) elements(path string, v any, elemType tftypes.Type) (any, bool) {
+	elems, ok := v.([]any)
+	if !ok {
+		elem, ok := c.convert(path, v, elemType)
+		if !ok || elem == nil {
+			return nil, ok
+		}
+		return []any{elem}, true
+	}
+	out := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		converted, ok := c.convert(path, elem, elemType)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, converted)
+	}
+	return out, true
+}
+
+func joinStatePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
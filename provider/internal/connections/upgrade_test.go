@@ -0,0 +1,110 @@
+package connections
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateConverter(t *testing.T) {
+	configuration := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"hostname":    tftypes.String,
+		"port":        tftypes.Number,
+		"ssl":         tftypes.Bool,
+		"schemas":     tftypes.Set{ElementType: tftypes.String},
+		"headers":     tftypes.Map{ElementType: tftypes.String},
+		"ssh":         tftypes.Object{AttributeTypes: map[string]tftypes.Type{"host": tftypes.String, "port": tftypes.Number}},
+		"database":    tftypes.String,
+		"oauth_token": tftypes.String,
+		"timeout":     tftypes.Number,
+		"ports":       tftypes.Set{ElementType: tftypes.Number},
+	}}
+	c := stateConverter{ctx: context.Background(), integers: map[string]bool{
+		"port":     true,
+		"ssh.port": true,
+		"ports":    true,
+	}}
+
+	prior := map[string]any{
+		"hostname":    "db.example.com",
+		"port":        "5432",
+		"ssl":         "true",
+		"schemas":     "public",
+		"headers":     map[string]any{"x-trace": "1"},
+		"ssh":         map[string]any{"host": "bastion", "port": json.Number("22"), "user": "removed"},
+		"database":    []any{"analytics"},
+		"oauth_token": nil,
+		"timeout":     "2.5",
+		"ports":       []any{json.Number("80"), json.Number("443.0")},
+		"removed":     "value",
+	}
+
+	got := c.value("", prior, configuration)
+	assert.Equal(t, map[string]any{
+		"hostname":    "db.example.com",
+		"port":        json.Number("5432"),
+		"ssl":         true,
+		"schemas":     []any{"public"},
+		"headers":     map[string]any{"x-trace": "1"},
+		"ssh":         map[string]any{"host": "bastion", "port": json.Number("22")},
+		"database":    "analytics",
+		"oauth_token": nil,
+		"timeout":     json.Number("2.5"),
+		"ports":       []any{json.Number("80"), json.Number("443")},
+	}, got)
+
+	t.Run("unconvertible values are dropped", func(t *testing.T) {
+		got := c.value("", map[string]any{
+			"port":     "not a number",
+			"ssh":      map[string]any{"port": json.Number("22.5")},
+			"ssl":      json.Number("1"),
+			"database": []any{"a", "b"},
+			"schemas":  []any{"public", map[string]any{}},
+		}, configuration)
+		assert.Equal(t, map[string]any{
+			"port":     nil,
+			"ssh":      map[string]any{"port": nil},
+			"ssl":      nil,
+			"database": nil,
+			"schemas":  nil,
+		}, got)
+	})
+}
+
+// TestNumberStateDecodes checks that state written when connection numbers
+// were NumberAttributes decodes with their Float64 and Int64 attributes,
+// without a schema version bump.
+func TestNumberStateDecodes(t *testing.T) {
+	ctx := t.Context()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"configuration": schema.SingleNestedAttribute{
+			Attributes: map[string]schema.Attribute{
+				"port":        schema.Int64Attribute{Optional: true},
+				"sample_rate": schema.Float64Attribute{Optional: true},
+			},
+			Required: true,
+		},
+	}}
+	raw := tfprotov6.RawState{JSON: []byte(`{"configuration":{"port":5432,"sample_rate":0.25}}`)}
+	v, err := raw.Unmarshal(s.Type().TerraformType(ctx))
+	require.NoError(t, err)
+
+	var data struct {
+		Configuration struct {
+			Port       types.Int64   `tfsdk:"port"`
+			SampleRate types.Float64 `tfsdk:"sample_rate"`
+		} `tfsdk:"configuration"`
+	}
+	diags := tfsdk.State{Raw: v, Schema: s}.Get(ctx, &data)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.Int64Value(5432), data.Configuration.Port)
+	assert.Equal(t, types.Float64Value(0.25), data.Configuration.SampleRate)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+)
+
+func TestListResource(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "polytomic_role" "test" {
+  name = "Analysts"
+}
+`,
+			},
+			{
+				// Gone is listed before Engineers and Sales, but deleted by
+				// the time it's read
+				PreConfig: func() {
+					server.DeleteAfterList(server.Put(fakeapi.Roles, fakeapi.Object{"name": "Gone"}))
+					server.Put(fakeapi.Roles, fakeapi.Object{"name": "Engineers"})
+					server.Put(fakeapi.Roles, fakeapi.Object{"name": "Sales"})
+				},
+				Query: true,
+				Config: `
+list "polytomic_role" "ids" {
+  provider = polytomic
+}
+
+list "polytomic_role" "all" {
+  provider         = polytomic
+  include_resource = true
+}
+
+list "polytomic_role" "limited" {
+  provider         = polytomic
+  include_resource = true
+  limit            = 2
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					// deleted roles are only omitted when they're read
+					querycheck.ExpectLength("polytomic_role.ids", 4),
+					querycheck.ExpectLength("polytomic_role.all", 3),
+					// deleted roles don't count toward the limit
+					querycheck.ExpectLength("polytomic_role.limited", 2),
+				},
+			},
+			{
+				Query: true,
+				Config: `
+list "polytomic_role" "other" {
+  provider = polytomic
+
+  config {
+    organization = "` + uuid.NewString() + `"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`API key does not have access to organization`),
+			},
+		},
+	})
+}
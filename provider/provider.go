@@ -7,6 +7,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -25,6 +27,8 @@ const (
 
 var (
 	_ provider.ProviderWithConfigValidators = (*Provider)(nil)
+	_ provider.ProviderWithFunctions        = (*Provider)(nil)
+	_ provider.ProviderWithListResources    = (*Provider)(nil)
 )
 
 // ProviderData holds the provider configuration, which is used to construct
@@ -99,6 +103,7 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 
 	resp.DataSourceData = clientProvider
 	resp.ResourceData = clientProvider
+	resp.ListResourceData = clientProvider
 }
 
 func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
@@ -111,7 +116,13 @@ func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 		func() resource.Resource { return &modelResource{} },
 		func() resource.Resource { return &bulkSyncResource{} },
 		func() resource.Resource { return &syncResource{} },
+		func() resource.Resource { return &connections.GenericConnectionResource{} },
+		func() resource.Resource { return &connections.OAuthConnectionResource{} },
 		NewConnectionSchemaPrimaryKeysResource,
+		NewConnectionSchemaRefreshResource,
+		NewBulkSyncSchemaResyncResource,
+		NewBulkSyncSchemaResource,
+		NewModelRelationResource,
 	}
 	all := append(connections.Resources, resourceList...)
 	return all
@@ -122,13 +133,38 @@ func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSour
 		func() datasource.DataSource { return &bulkSourceDatasource{} },
 		func() datasource.DataSource { return &bulkDestinationDatasource{} },
 		func() datasource.DataSource { return &identityDatasource{} },
+		func() datasource.DataSource { return &modelPreviewDatasource{} },
 		func() datasource.DataSource { return &roleDatasource{} },
+		func() datasource.DataSource { return &syncDependencyGraphDatasource{} },
+		func() datasource.DataSource { return &connections.GenericConnectionDataSource{} },
 		NewConnectionSchemaDataSource,
 	}
 	all := append(connections.Datasources, datasources...)
 	return all
 }
 
+func (p *Provider) ListResources(ctx context.Context) []func() list.ListResource {
+	listResources := []func() list.ListResource{
+		func() list.ListResource { return &userResource{} },
+		func() list.ListResource { return &roleResource{} },
+		func() list.ListResource { return &policyResource{} },
+		func() list.ListResource { return &modelResource{} },
+		func() list.ListResource { return &bulkSyncResource{} },
+		func() list.ListResource { return &syncResource{} },
+	}
+	all := append(connections.ListResources, listResources...)
+	return all
+}
+
+func (p *Provider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewSchemaIDFunction,
+		NewParseSchemaIDFunction,
+		NewCronToScheduleFunction,
+		NewFieldMappingFunction,
+	}
+}
+
 func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -3,8 +3,10 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	ptclient "github.com/polytomic/polytomic-go/client"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,3 +37,21 @@ func testPartnerClient(t *testing.T) *ptclient.Client {
 	require.NoError(t, err)
 	return c
 }
+
+func TestListResourcesHaveResources(t *testing.T) {
+	ctx := t.Context()
+	p := &Provider{}
+
+	resources := map[string]bool{}
+	for _, r := range p.Resources(ctx) {
+		resp := &resource.MetadataResponse{}
+		r().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: Name}, resp)
+		resources[resp.TypeName] = true
+	}
+
+	for _, l := range p.ListResources(ctx) {
+		resp := &resource.MetadataResponse{}
+		l().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: Name}, resp)
+		assert.True(t, resources[resp.TypeName], "list resource %s has no managed resource", resp.TypeName)
+	}
+}
@@ -16,9 +16,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -29,14 +31,17 @@ import (
 	"github.com/polytomic/polytomic-go"
 	"github.com/polytomic/polytomic-go/bulksync"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // retryOnCacheRefresh retries an operation that may fail because the source
 // connection's schema cache is not yet populated. This manifests as either a
 // 422 "cache refresh in progress" error or a 400 "did not provide any schemas"
 // error. Newly-created connections need time for the server to discover and
-// cache their schemas.
+// cache their schemas. To discover schemas added to an existing connection,
+// use the polytomic_connection_schema_refresh resource or refresh_schemas.
 func retryOnCacheRefresh[T any](ctx context.Context, label string, fn func() (T, error)) (T, error) {
 	const maxAttempts = 10
 	const baseDelay = 5 * time.Second
@@ -88,6 +93,9 @@ func isCacheNotReadyError(err error) bool {
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &bulkSyncResource{}
 var _ resource.ResourceWithImportState = &bulkSyncResource{}
+var _ resource.ResourceWithIdentity = &bulkSyncResource{}
+var _ list.ListResourceWithConfigure = &bulkSyncResource{}
+var _ resource.ResourceWithModifyPlan = &bulkSyncResource{}
 
 // NewBulkSyncResourceForSchemaIntrospection returns a bulk sync resource instance
 // for schema introspection. This is used by the importer to validate field mappings.
@@ -112,6 +120,7 @@ func (r *bulkSyncResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -123,7 +132,7 @@ func (r *bulkSyncResource) Schema(ctx context.Context, req resource.SchemaReques
 				Required:            true,
 			},
 			"mode": schema.StringAttribute{
-				MarkdownDescription: "",
+				MarkdownDescription: "One of the destination connection's `modes`, as reported by the `polytomic_bulk_destination` data source. The mode and the destination's required configuration are validated when planning.",
 				Required:            true,
 			},
 			"source": schema.SingleNestedAttribute{
@@ -218,6 +227,43 @@ func (r *bulkSyncResource) Schema(ctx context.Context, req resource.SchemaReques
 					setplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"manage_schemas": schema.BoolAttribute{
+				MarkdownDescription: "Whether the bulk sync configures its schemas. Set to `false` to configure each schema with a `polytomic_bulk_sync_schema` resource instead; `schemas`, `schema_selection` and `field_rules` can't be set, and the bulk sync is created with all of the source's schemas disabled. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"schema_status": schema.MapNestedAttribute{
+				MarkdownDescription: "Sync status of each of the bulk sync's schemas, by schema ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: bulkSyncSchemaStatus{}.SchemaAttributes(),
+				},
+			},
+			"schema_selection": schema.SingleNestedAttribute{
+				MarkdownDescription: "Select the schemas to sync by matching the source's schema IDs against patterns. Schemas which aren't selected are disabled; `schemas` may only configure selected schemas.",
+				Optional:            true,
+				Attributes:          bulkSyncSchemaSelection{}.SchemaAttributes(),
+			},
+			"selected_schemas": schema.SetAttribute{
+				MarkdownDescription: "IDs of the schemas the bulk sync syncs.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"field_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Obfuscate or exclude fields across the bulk sync's schemas by matching their names and types. The first rule a field matches applies to it, and fields configured in `schemas` can't contradict it. Removing a rule doesn't revert the fields it configured.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: bulkSyncFieldRule{}.SchemaAttributes(),
+				},
+			},
+			"field_rule_matches": schema.ListNestedAttribute{
+				MarkdownDescription: "Fields which `field_rules` apply to.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: bulkSyncFieldRuleMatch{}.SchemaAttributes(),
+				},
+			},
 			"policies": schema.SetAttribute{
 				MarkdownDescription: "",
 				ElementType:         types.StringType,
@@ -549,6 +595,74 @@ func (bulkSyncSchema) AttrTypes() map[string]attr.Type {
 	}
 }
 
+// newBulkSyncSchema returns the configuration of a schema which is only
+// enabled or disabled.
+func newBulkSyncSchema(id string, enabled bool) bulkSyncSchema {
+	return bulkSyncSchema{
+		Id:                  types.StringValue(id),
+		Enabled:             types.BoolValue(enabled),
+		PartitionKey:        types.StringNull(),
+		TrackingField:       types.StringNull(),
+		OutputName:          types.StringNull(),
+		UserOutputName:      types.StringNull(),
+		Fields:              types.SetNull(types.ObjectType{AttrTypes: bulkSyncSchemaField{}.AttrTypes()}),
+		Filters:             types.SetNull(types.ObjectType{AttrTypes: bulkSyncFilter{}.AttrTypes()}),
+		DataCutoffTimestamp: timetypes.NewRFC3339Null(),
+		DisableDataCutoff:   types.BoolNull(),
+	}
+}
+
+// bulkSyncSchemaStatus is the sync status of a bulk sync's schema.
+type bulkSyncSchemaStatus struct {
+	LastSyncedAt timetypes.RFC3339 `tfsdk:"last_synced_at"`
+	Resyncing    types.Bool        `tfsdk:"resyncing"`
+	Error        types.String      `tfsdk:"error"`
+}
+
+func (bulkSyncSchemaStatus) SchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"last_synced_at": schema.StringAttribute{
+			MarkdownDescription: "Timestamp when the schema was last synced",
+			CustomType:          timetypes.RFC3339Type{},
+			Computed:            true,
+		},
+		"resyncing": schema.BoolAttribute{
+			MarkdownDescription: "Whether the schema is being resynced",
+			Computed:            true,
+		},
+		"error": schema.StringAttribute{
+			MarkdownDescription: "Error from the schema's last sync, if it failed",
+			Computed:            true,
+		},
+	}
+}
+
+func (bulkSyncSchemaStatus) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"last_synced_at": timetypes.RFC3339Type{},
+		"resyncing":      types.BoolType,
+		"error":          types.StringType,
+	}
+}
+
+// bulkSyncSchemaStatusFromSDK returns the sync status of each schema, by
+// schema ID.
+func bulkSyncSchemaStatusFromSDK(ctx context.Context, schemas []*polytomic.BulkSchema) (types.Map, diag.Diagnostics) {
+	statuses := make(map[string]bulkSyncSchemaStatus, len(schemas))
+	for _, s := range schemas {
+		status := bulkSyncSchemaStatus{
+			LastSyncedAt: timetypes.NewRFC3339Null(),
+			Resyncing:    types.BoolValue(pointer.Get(s.Resyncing)),
+			Error:        types.StringPointerValue(s.Error),
+		}
+		if s.LastSyncedAt != nil {
+			status.LastSyncedAt = timetypes.NewRFC3339TimeValue(*s.LastSyncedAt)
+		}
+		statuses[pointer.Get(s.Id)] = status
+	}
+	return types.MapValueFrom(ctx, types.ObjectType{AttrTypes: bulkSyncSchemaStatus{}.AttrTypes()}, statuses)
+}
+
 type bulkSyncResourceData struct {
 	Id                         types.String      `tfsdk:"id"`
 	Organization               types.String      `tfsdk:"organization"`
@@ -562,6 +676,12 @@ type bulkSyncResourceData struct {
 	DisableRecordTimestamps    types.Bool        `tfsdk:"disable_record_timestamps"`
 	Schedule                   types.Object      `tfsdk:"schedule"`
 	Schemas                    types.Set         `tfsdk:"schemas"`
+	ManageSchemas              types.Bool        `tfsdk:"manage_schemas"`
+	SchemaSelection            types.Object      `tfsdk:"schema_selection"`
+	SelectedSchemas            types.Set         `tfsdk:"selected_schemas"`
+	FieldRules                 types.List        `tfsdk:"field_rules"`
+	FieldRuleMatches           types.List        `tfsdk:"field_rule_matches"`
+	SchemaStatus               types.Map         `tfsdk:"schema_status"`
 	Policies                   types.Set         `tfsdk:"policies"`
 	DataCutoffTimestamp        timetypes.RFC3339 `tfsdk:"data_cutoff_timestamp"`
 	ConcurrencyLimit           types.Int64       `tfsdk:"concurrency_limit"`
@@ -616,6 +736,27 @@ func (r *bulkSyncResource) Create(ctx context.Context, req resource.CreateReques
 			return cmp.Compare(a.Id.String(), b.Id.String())
 		})
 	}
+	if !data.SchemaSelection.IsNull() {
+		schemaData, diags = r.applySchemaSelection(ctx, data, schemaData)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !data.FieldRules.IsNull() {
+		schemaData, diags = r.applyFieldRules(ctx, &data, schemaData)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !data.ManageSchemas.ValueBool() {
+		schemaData, diags = r.disabledSchemas(ctx, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Send nil (not empty slice) when no schemas are specified, so the
 	// server applies its default behavior (select all schemas).
@@ -837,6 +978,7 @@ func (r *bulkSyncResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *bulkSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -880,6 +1022,7 @@ func (r *bulkSyncResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 
 }
 
@@ -903,6 +1046,28 @@ func (r *bulkSyncResource) Update(ctx context.Context, req resource.UpdateReques
 			return cmp.Compare(a.Id.String(), b.Id.String())
 		})
 	}
+	if !data.SchemaSelection.IsNull() {
+		schemaData, diags = r.applySchemaSelection(ctx, data, schemaData)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !data.FieldRules.IsNull() {
+		schemaData, diags = r.applyFieldRules(ctx, &data, schemaData)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.ManageSchemas.ValueBool() {
+		schemaData, diags = r.currentSchemas(ctx, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Send nil (not empty slice) when no schemas are specified, so the
 	// server applies its default behavior (select all schemas).
@@ -1127,6 +1292,7 @@ func (r *bulkSyncResource) Update(ctx context.Context, req resource.UpdateReques
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 
 }
 
@@ -1153,7 +1319,156 @@ func (r *bulkSyncResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *bulkSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *bulkSyncResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+// ModifyPlan validates the bulk sync's configuration against its
+// destination, resolves its schemas and field rules, and warns when syncs
+// that run after it would no longer be triggered.
+func (r *bulkSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.provider == nil {
+		return
+	}
+	if !req.Plan.Raw.IsNull() {
+		planUnmanaged(ctx, req, resp, "manage_schemas", "schemas",
+			types.SetNull(types.ObjectType{AttrTypes: bulkSyncSchema{}.AttrTypes()}),
+			[]string{"schema_selection", "field_rules"},
+			"Conflicting schema configuration",
+			"configure the bulk sync's schemas with polytomic_bulk_sync_schema resources.")
+		r.planDestinationValidation(ctx, req, resp)
+		r.planSchemaSelection(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.planFieldRules(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state bulkSyncResourceData
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// an inactive bulk sync doesn't trigger its dependents anyway
+	if !state.Active.ValueBool() {
+		return
+	}
+	action := "deleted"
+	if !req.Plan.Raw.IsNull() {
+		var active types.Bool
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("active"), &active)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if active.IsUnknown() || active.ValueBool() {
+			return
+		}
+		action = "deactivated"
+	}
+
+	client, err := r.provider.Client(ctx, state.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	graph, err := loadSyncGraph(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading sync dependency graph: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(graph.validateDependents(state.Id.ValueString(), action)...)
+}
+
+// mergeBulkSyncSchema merges a planned schema with the schema returned by the
+// API, preserving the configured values and populating computed values.
+func mergeBulkSyncSchema(ctx context.Context, plan bulkSyncSchema, api *polytomic.BulkSchema) (bulkSyncSchema, diag.Diagnostics) {
+	filterType := types.ObjectType{AttrTypes: bulkSyncFilter{}.AttrTypes()}
+	fieldType := types.ObjectType{AttrTypes: bulkSyncSchemaField{}.AttrTypes()}
+
+	var mergeDiags diag.Diagnostics
+	merged := plan
+
+	// Populate computed string fields
+	merged.OutputName = PopulateUnknownString(merged.OutputName, api.OutputName)
+	merged.UserOutputName = PopulateUnknownString(merged.UserOutputName, api.UserOutputName)
+	merged.PartitionKey = PopulateUnknownString(merged.PartitionKey, api.PartitionKey)
+	merged.TrackingField = PopulateUnknownString(merged.TrackingField, api.TrackingField)
+
+	// Populate computed bool fields
+	merged.Enabled = PopulateUnknownBool(merged.Enabled, api.Enabled)
+	merged.DisableDataCutoff = PopulateUnknownBool(merged.DisableDataCutoff, api.DisableDataCutoff)
+
+	// Merge fields: when user specifies fields, populate computed
+	// values (output_name, user_output_name) from the API response.
+	if merged.Fields.IsUnknown() {
+		merged.Fields, mergeDiags = PopulateUnknownSet(ctx, merged.Fields, api.Fields, fieldType)
+	} else if !merged.Fields.IsNull() {
+		merged.Fields, mergeDiags = MergeSetElements(
+			ctx,
+			merged.Fields,
+			api.Fields,
+			types.ObjectType{AttrTypes: bulkSyncSchemaField{}.AttrTypes()},
+			func(f bulkSyncSchemaField) string { return f.Id.ValueString() },
+			func(f *polytomic.BulkField) string { return pointer.GetString(f.Id) },
+			func(_ context.Context, planField bulkSyncSchemaField, apiField *polytomic.BulkField) (bulkSyncSchemaField, diag.Diagnostics) {
+				m := planField
+				m.OutputName = PopulateUnknownString(m.OutputName, apiField.OutputName)
+				m.UserOutputName = PopulateUnknownString(m.UserOutputName, apiField.UserOutputName)
+				m.Enabled = PopulateUnknownBool(m.Enabled, apiField.Enabled)
+				m.Obfuscate = PopulateUnknownBool(m.Obfuscate, apiField.Obfuscated)
+				return m, nil
+			},
+		)
+	}
+	if mergeDiags.HasError() {
+		return merged, mergeDiags
+	}
+
+	// Ensure no unknown values remain on fields that weren't matched
+	// by the API (e.g., the API hadn't discovered the field yet).
+	if !merged.Fields.IsNull() && !merged.Fields.IsUnknown() {
+		var fields []bulkSyncSchemaField
+		mergeDiags = merged.Fields.ElementsAs(ctx, &fields, false)
+		if mergeDiags.HasError() {
+			return merged, mergeDiags
+		}
+		for i := range fields {
+			if fields[i].OutputName.IsUnknown() {
+				fields[i].OutputName = types.StringNull()
+			}
+			if fields[i].UserOutputName.IsUnknown() {
+				fields[i].UserOutputName = types.StringNull()
+			}
+		}
+		merged.Fields, mergeDiags = types.SetValueFrom(ctx, fieldType, fields)
+		if mergeDiags.HasError() {
+			return merged, mergeDiags
+		}
+	}
+
+	if merged.Filters.IsUnknown() {
+		if len(api.Filters) > 0 {
+			tfFilters, err := bulkSyncFiltersFromSDK(api.Filters)
+			if err != nil {
+				mergeDiags.AddError("Error reading filters", err.Error())
+				return merged, mergeDiags
+			}
+			merged.Filters, mergeDiags = types.SetValueFrom(ctx, filterType, tfFilters)
+		} else {
+			merged.Filters = types.SetNull(filterType)
+		}
+	}
+	return merged, mergeDiags
 }
 
 // bulkSyncDataFromResponse returns the Terraform data for the response from the
@@ -1187,9 +1502,6 @@ func bulkSyncDataFromResponse(ctx context.Context, response *polytomic.BulkSyncR
 	// 2. Populate computed fields with API values (output_name, fields, etc.)
 	var schemaVal basetypes.SetValue
 	if planData != nil && !planData.Schemas.IsNull() {
-		filterType := types.ObjectType{AttrTypes: bulkSyncFilter{}.AttrTypes()}
-		fieldType := types.ObjectType{AttrTypes: bulkSyncSchemaField{}.AttrTypes()}
-
 		schemaVal, diags = MergeSetElements(
 			ctx,
 			planData.Schemas,
@@ -1197,81 +1509,7 @@ func bulkSyncDataFromResponse(ctx context.Context, response *polytomic.BulkSyncR
 			types.ObjectType{AttrTypes: bulkSyncSchema{}.AttrTypes()},
 			func(s bulkSyncSchema) string { return s.Id.ValueString() },
 			func(s *polytomic.BulkSchema) string { return pointer.GetString(s.Id) },
-			func(ctx context.Context, plan bulkSyncSchema, api *polytomic.BulkSchema) (bulkSyncSchema, diag.Diagnostics) {
-				var mergeDiags diag.Diagnostics
-				merged := plan
-
-				// Populate computed string fields
-				merged.OutputName = PopulateUnknownString(merged.OutputName, api.OutputName)
-				merged.UserOutputName = PopulateUnknownString(merged.UserOutputName, api.UserOutputName)
-				merged.PartitionKey = PopulateUnknownString(merged.PartitionKey, api.PartitionKey)
-				merged.TrackingField = PopulateUnknownString(merged.TrackingField, api.TrackingField)
-
-				// Populate computed bool fields
-				merged.Enabled = PopulateUnknownBool(merged.Enabled, api.Enabled)
-				merged.DisableDataCutoff = PopulateUnknownBool(merged.DisableDataCutoff, api.DisableDataCutoff)
-
-				// Merge fields: when user specifies fields, populate computed
-				// values (output_name, user_output_name) from the API response.
-				if merged.Fields.IsUnknown() {
-					merged.Fields, mergeDiags = PopulateUnknownSet(ctx, merged.Fields, api.Fields, fieldType)
-				} else if !merged.Fields.IsNull() {
-					merged.Fields, mergeDiags = MergeSetElements(
-						ctx,
-						merged.Fields,
-						api.Fields,
-						types.ObjectType{AttrTypes: bulkSyncSchemaField{}.AttrTypes()},
-						func(f bulkSyncSchemaField) string { return f.Id.ValueString() },
-						func(f *polytomic.BulkField) string { return pointer.GetString(f.Id) },
-						func(_ context.Context, planField bulkSyncSchemaField, apiField *polytomic.BulkField) (bulkSyncSchemaField, diag.Diagnostics) {
-							m := planField
-							m.OutputName = PopulateUnknownString(m.OutputName, apiField.OutputName)
-							m.UserOutputName = PopulateUnknownString(m.UserOutputName, apiField.UserOutputName)
-							m.Enabled = PopulateUnknownBool(m.Enabled, apiField.Enabled)
-							return m, nil
-						},
-					)
-				}
-				if mergeDiags.HasError() {
-					return merged, mergeDiags
-				}
-
-				// Ensure no unknown values remain on fields that weren't matched
-				// by the API (e.g., the API hadn't discovered the field yet).
-				if !merged.Fields.IsNull() && !merged.Fields.IsUnknown() {
-					var fields []bulkSyncSchemaField
-					mergeDiags = merged.Fields.ElementsAs(ctx, &fields, false)
-					if mergeDiags.HasError() {
-						return merged, mergeDiags
-					}
-					for i := range fields {
-						if fields[i].OutputName.IsUnknown() {
-							fields[i].OutputName = types.StringNull()
-						}
-						if fields[i].UserOutputName.IsUnknown() {
-							fields[i].UserOutputName = types.StringNull()
-						}
-					}
-					merged.Fields, mergeDiags = types.SetValueFrom(ctx, fieldType, fields)
-					if mergeDiags.HasError() {
-						return merged, mergeDiags
-					}
-				}
-
-				if merged.Filters.IsUnknown() {
-					if len(api.Filters) > 0 {
-						tfFilters, err := bulkSyncFiltersFromSDK(api.Filters)
-						if err != nil {
-							mergeDiags.AddError("Error reading filters", err.Error())
-							return merged, mergeDiags
-						}
-						merged.Filters, mergeDiags = types.SetValueFrom(ctx, filterType, tfFilters)
-					} else {
-						merged.Filters = types.SetNull(filterType)
-					}
-				}
-				return merged, mergeDiags
-			},
+			mergeBulkSyncSchema,
 		)
 		if diags.HasError() {
 			return data, diags
@@ -1367,6 +1605,31 @@ func bulkSyncDataFromResponse(ctx context.Context, response *polytomic.BulkSyncR
 	data.Source = sourceVal
 	data.Schedule = sch
 	data.Schemas = schemaVal
+	data.ManageSchemas = types.BoolValue(true)
+	if planData != nil && !planData.ManageSchemas.IsUnknown() && !planData.ManageSchemas.IsNull() {
+		data.ManageSchemas = planData.ManageSchemas
+	}
+	if !data.ManageSchemas.ValueBool() {
+		data.Schemas = types.SetNull(types.ObjectType{AttrTypes: bulkSyncSchema{}.AttrTypes()})
+	}
+	data.SelectedSchemas, diags = selectedSchemaIDs(ctx, schemas)
+	if diags.HasError() {
+		return data, diags
+	}
+	data.SchemaStatus, diags = bulkSyncSchemaStatusFromSDK(ctx, schemas)
+	if diags.HasError() {
+		return data, diags
+	}
+	data.SchemaSelection = types.ObjectNull(bulkSyncSchemaSelection{}.AttrTypes())
+	if planData != nil && !planData.SchemaSelection.IsUnknown() && !planData.SchemaSelection.IsNull() {
+		data.SchemaSelection = planData.SchemaSelection
+	}
+	data.FieldRules = types.ListNull(types.ObjectType{AttrTypes: bulkSyncFieldRule{}.AttrTypes()})
+	data.FieldRuleMatches = types.ListNull(types.ObjectType{AttrTypes: bulkSyncFieldRuleMatch{}.AttrTypes()})
+	if planData != nil && !planData.FieldRules.IsUnknown() && !planData.FieldRules.IsNull() {
+		data.FieldRules = planData.FieldRules
+		data.FieldRuleMatches = planData.FieldRuleMatches
+	}
 	data.Policies, _ = types.SetValueFrom(ctx, types.StringType, response.Policies)
 	data.DisableRecordTimestamps = types.BoolPointerValue(response.DisableRecordTimestamps)
 
@@ -1412,3 +1675,37 @@ func bulkSyncDataFromResponse(ctx context.Context, response *polytomic.BulkSyncR
 
 	return data, diags
 }
+
+func (r *bulkSyncResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *bulkSyncResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listresource.Config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := r.provider.Client(ctx, config.Organization.ValueString())
+	if err != nil {
+		stream.Results = listresource.Error("Error getting client", err.Error())
+		return
+	}
+	bulkSyncs, err := client.BulkSync.List(ctx, &polytomic.BulkSyncListRequest{})
+	if err != nil {
+		stream.Results = listresource.Error(providerclient.ErrorSummary, fmt.Sprintf("Error listing bulk syncs: %s", err))
+		return
+	}
+
+	items := make([]listresource.Item, 0, len(bulkSyncs.Data))
+	for _, v := range bulkSyncs.Data {
+		items = append(items, listresource.Item{
+			Organization: pointer.GetString(v.OrganizationId),
+			ID:           pointer.GetString(v.Id),
+			DisplayName:  pointer.GetString(v.Name),
+		})
+	}
+	stream.Results = listresource.Results(ctx, req, r, config, items)
+}
@@ -0,0 +1,473 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/polytomic/polytomic-go"
+	"github.com/polytomic/polytomic-go/bulksync"
+	ptclient "github.com/polytomic/polytomic-go/client"
+	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &bulkSyncSchemaResource{}
+var _ resource.ResourceWithImportState = &bulkSyncSchemaResource{}
+var _ resource.ResourceWithIdentity = &bulkSyncSchemaResource{}
+
+func NewBulkSyncSchemaResource() resource.Resource {
+	return &bulkSyncSchemaResource{}
+}
+
+type bulkSyncSchemaResource struct {
+	provider *providerclient.Provider
+}
+
+type bulkSyncSchemaResourceModel struct {
+	ID                  types.String      `tfsdk:"id"`
+	Organization        types.String      `tfsdk:"organization"`
+	BulkSyncID          types.String      `tfsdk:"bulk_sync_id"`
+	SchemaID            types.String      `tfsdk:"schema_id"`
+	Enabled             types.Bool        `tfsdk:"enabled"`
+	PartitionKey        types.String      `tfsdk:"partition_key"`
+	TrackingField       types.String      `tfsdk:"tracking_field"`
+	OutputName          types.String      `tfsdk:"output_name"`
+	UserOutputName      types.String      `tfsdk:"user_output_name"`
+	Fields              types.Set         `tfsdk:"fields"`
+	Filters             types.Set         `tfsdk:"filters"`
+	DataCutoffTimestamp timetypes.RFC3339 `tfsdk:"data_cutoff_timestamp"`
+	DisableDataCutoff   types.Bool        `tfsdk:"disable_data_cutoff"`
+}
+
+// schema returns the schema's configuration as it's configured on
+// polytomic_bulk_sync.
+func (m bulkSyncSchemaResourceModel) schema() bulkSyncSchema {
+	return bulkSyncSchema{
+		Id:                  m.SchemaID,
+		Enabled:             m.Enabled,
+		PartitionKey:        m.PartitionKey,
+		TrackingField:       m.TrackingField,
+		OutputName:          m.OutputName,
+		UserOutputName:      m.UserOutputName,
+		Fields:              m.Fields,
+		Filters:             m.Filters,
+		DataCutoffTimestamp: m.DataCutoffTimestamp,
+		DisableDataCutoff:   m.DisableDataCutoff,
+	}
+}
+
+func (m *bulkSyncSchemaResourceModel) setSchema(s bulkSyncSchema) {
+	m.Enabled = s.Enabled
+	m.PartitionKey = s.PartitionKey
+	m.TrackingField = s.TrackingField
+	m.OutputName = s.OutputName
+	m.UserOutputName = s.UserOutputName
+	m.Fields = s.Fields
+	m.Filters = s.Filters
+	m.DataCutoffTimestamp = s.DataCutoffTimestamp
+	m.DisableDataCutoff = s.DisableDataCutoff
+}
+
+func (r *bulkSyncSchemaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bulk_sync_schema"
+}
+
+func (r *bulkSyncSchemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := bulkSyncSchema{}.SchemaAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Resource identifier in the format: organization/bulk_sync_id/schema_id",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["organization"] = schema.StringAttribute{
+		MarkdownDescription: "Organization ID",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+	}
+	attributes["bulk_sync_id"] = schema.StringAttribute{
+		MarkdownDescription: "Bulk sync ID",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["schema_id"] = schema.StringAttribute{
+		MarkdownDescription: "Schema ID, as reported by the `polytomic_bulk_source` data source",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["enabled"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether the schema is synced. Defaults to `true`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: ":meta:subcategory:Bulk Syncs: Bulk Sync Schema\n\n" +
+			"Configures one schema of a bulk sync, so the schemas of a bulk sync with many tables can be " +
+			"configured, and owned, individually. The bulk sync must have `manage_schemas` set to `false`. " +
+			"Deleting this resource disables the schema.",
+		Attributes: attributes,
+	}
+}
+
+func (r *bulkSyncSchemaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		r.provider = provider
+	}
+}
+
+func (r *bulkSyncSchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data bulkSyncSchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.update(ctx, client, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Organization.IsNull() || data.Organization.IsUnknown() {
+		bulkSync, err := client.BulkSync.Get(ctx, data.BulkSyncID.ValueString(), &polytomic.BulkSyncGetRequest{})
+		if err != nil {
+			resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading bulk sync: %s", err))
+			return
+		}
+		data.Organization = types.StringPointerValue(bulkSync.Data.OrganizationId)
+	}
+	data.ID = types.StringValue(schemaID(
+		data.Organization.ValueString(),
+		data.BulkSyncID.ValueString(),
+		data.SchemaID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setBulkSyncSchemaIdentity(ctx, resp.Identity, data)...)
+}
+
+// update configures the schema as planned, and populates data's computed
+// values from the schema returned by the API.
+func (r *bulkSyncSchemaResource) update(ctx context.Context, client *ptclient.Client, data *bulkSyncSchemaResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	planned := data.schema()
+
+	request := &bulksync.UpdateBulkSchema{
+		Enabled:           knownBool(planned.Enabled),
+		PartitionKey:      knownString(planned.PartitionKey),
+		TrackingField:     knownString(planned.TrackingField),
+		UserOutputName:    knownString(planned.UserOutputName),
+		DisableDataCutoff: knownBool(planned.DisableDataCutoff),
+	}
+	if !planned.DataCutoffTimestamp.IsNull() && !planned.DataCutoffTimestamp.IsUnknown() {
+		cutoff, d := planned.DataCutoffTimestamp.ValueRFC3339Time()
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		request.DataCutoffTimestamp = &cutoff
+	}
+	if !planned.Fields.IsNull() && !planned.Fields.IsUnknown() {
+		var fields []bulkSyncSchemaField
+		diags.Append(planned.Fields.ElementsAs(ctx, &fields, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		slices.SortFunc(fields, func(a, b bulkSyncSchemaField) int {
+			return cmp.Compare(a.Id.ValueString(), b.Id.ValueString())
+		})
+		for _, f := range fields {
+			request.Fields = append(request.Fields, &polytomic.UpdateBulkField{
+				Id:         f.Id.ValueString(),
+				Enabled:    knownBool(f.Enabled),
+				Obfuscated: knownBool(f.Obfuscate),
+			})
+		}
+	}
+	if !planned.Filters.IsNull() && !planned.Filters.IsUnknown() {
+		var filters []bulkSyncFilter
+		diags.Append(planned.Filters.ElementsAs(ctx, &filters, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		var d diag.Diagnostics
+		request.Filters, d = bulkSyncFiltersToSDK(filters)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	updated, err := client.BulkSync.Schemas.Update(ctx, data.BulkSyncID.ValueString(), data.SchemaID.ValueString(), request)
+	if err != nil {
+		diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error updating bulk sync schema: %s", err))
+		return diags
+	}
+	merged, d := mergeBulkSyncSchema(ctx, planned, updated.Data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	data.setSchema(merged)
+	return diags
+}
+
+func (r *bulkSyncSchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data bulkSyncSchemaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	bulkSchema, err := client.BulkSync.Schemas.Get(ctx, data.BulkSyncID.ValueString(), data.SchemaID.ValueString())
+	if err != nil {
+		pErr := &ptcore.APIError{}
+		if errors.As(err, &pErr) && pErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading bulk sync schema: %s", err))
+		return
+	}
+
+	var s bulkSyncSchema
+	if data.Enabled.IsNull() {
+		// imported; there's no configuration to preserve
+		schemas, diags := bulkSyncSchemasFromSDK(ctx, []*polytomic.BulkSchema{bulkSchema.Data})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		s = schemas[0]
+		s.Enabled = types.BoolValue(pointer.Get(bulkSchema.Data.Enabled))
+	} else {
+		var diags diag.Diagnostics
+		s, diags = mergeBulkSyncSchema(ctx, data.schema(), bulkSchema.Data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	data.setSchema(s)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setBulkSyncSchemaIdentity(ctx, resp.Identity, data)...)
+}
+
+func (r *bulkSyncSchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data bulkSyncSchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.update(ctx, client, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setBulkSyncSchemaIdentity(ctx, resp.Identity, data)...)
+}
+
+// Delete disables the schema; the bulk sync's schemas are those of its
+// source, so a schema can't be removed.
+func (r *bulkSyncSchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data bulkSyncSchemaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	_, err = client.BulkSync.Schemas.Update(ctx, data.BulkSyncID.ValueString(), data.SchemaID.ValueString(), &bulksync.UpdateBulkSchema{
+		Enabled: pointer.To(false),
+	})
+	if err != nil {
+		pErr := &ptcore.APIError{}
+		if errors.As(err, &pErr) && pErr.StatusCode == http.StatusNotFound {
+			// the bulk sync, or the schema, is already gone
+			return
+		}
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error disabling bulk sync schema: %s", err))
+	}
+}
+
+func (r *bulkSyncSchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity bulkSyncSchemaIdentityModel
+	if req.ID != "" {
+		// ID format: organization/bulk_sync_id/schema_id
+		organization, bulkSyncID, schema, err := parseSchemaID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected import ID in format: organization/bulk_sync_id/schema_id, got: %s", req.ID))
+			return
+		}
+		identity.Organization = types.StringValue(organization)
+		identity.BulkSyncID = types.StringValue(bulkSyncID)
+		identity.SchemaID = types.StringValue(schema)
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), identity.Organization)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bulk_sync_id"), identity.BulkSyncID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema_id"), identity.SchemaID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), schemaID(
+		identity.Organization.ValueString(),
+		identity.BulkSyncID.ValueString(),
+		identity.SchemaID.ValueString()))...)
+}
+
+type bulkSyncSchemaIdentityModel struct {
+	Organization types.String `tfsdk:"organization"`
+	BulkSyncID   types.String `tfsdk:"bulk_sync_id"`
+	SchemaID     types.String `tfsdk:"schema_id"`
+}
+
+func (r *bulkSyncSchemaResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization": identityschema.StringAttribute{
+				Description:       "Organization ID",
+				RequiredForImport: true,
+			},
+			"bulk_sync_id": identityschema.StringAttribute{
+				Description:       "Bulk sync ID",
+				RequiredForImport: true,
+			},
+			"schema_id": identityschema.StringAttribute{
+				Description:       "Schema ID",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func setBulkSyncSchemaIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data bulkSyncSchemaResourceModel) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, bulkSyncSchemaIdentityModel{
+		Organization: data.Organization,
+		BulkSyncID:   data.BulkSyncID,
+		SchemaID:     data.SchemaID,
+	})
+}
+
+// knownString returns a pointer to v's value, or nil if it's null or unknown,
+// so values computed by the API aren't overwritten.
+func knownString(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return v.ValueStringPointer()
+}
+
+// knownBool returns a pointer to v's value, or nil if it's null or unknown.
+func knownBool(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return v.ValueBoolPointer()
+}
+
+// disabledSchemas returns the schemas to create a bulk sync with when its
+// schemas are configured by polytomic_bulk_sync_schema resources: all of the
+// source's schemas, disabled, so only the schemas those resources enable are
+// synced.
+func (r *bulkSyncResource) disabledSchemas(ctx context.Context, data bulkSyncResourceData) ([]bulkSyncSchema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var source bulkSyncConnection
+	diags.Append(data.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		diags.AddError("Error getting client", err.Error())
+		return nil, diags
+	}
+	ids, err := bulkSourceSchemaIDs(ctx, client, source.ConnectionID.ValueString())
+	if err != nil {
+		diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading bulk source schemas: %s", err))
+		return nil, diags
+	}
+	slices.Sort(ids)
+	schemas := make([]bulkSyncSchema, len(ids))
+	for i, id := range ids {
+		schemas[i] = newBulkSyncSchema(id, false)
+	}
+	return schemas, diags
+}
+
+// currentSchemas returns the schemas to update a bulk sync with when its
+// schemas are configured by polytomic_bulk_sync_schema resources: the bulk
+// sync's schemas as the API has them, so the update leaves them unchanged.
+// They're sent rather than omitted, because the API selects all of the
+// source's schemas when an update has none.
+func (r *bulkSyncResource) currentSchemas(ctx context.Context, data bulkSyncResourceData) ([]bulkSyncSchema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		diags.AddError("Error getting client", err.Error())
+		return nil, diags
+	}
+	schemas, err := retryOnCacheRefresh(ctx, "list bulk sync schemas", func() (*polytomic.ListBulkSchema, error) {
+		return client.BulkSync.Schemas.List(ctx, data.Id.ValueString(), &bulksync.SchemasListRequest{})
+	})
+	if err != nil {
+		diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading bulk sync schemas: %s", err))
+		return nil, diags
+	}
+	return bulkSyncSchemasFromSDK(ctx, schemas.Data)
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/polytomic/polytomic-go"
+	ptclient "github.com/polytomic/polytomic-go/client"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+const defaultResyncWaitTimeout = "1h"
+
+// bulkSyncExecutionPollInterval is how often an execution's status is checked
+// while waiting for it to complete.
+var bulkSyncExecutionPollInterval = 10 * time.Second
+
+// bulkSyncExecutionDone are the statuses of executions which have finished.
+var bulkSyncExecutionDone = map[string]bool{
+	"completed": true,
+	"failed":    true,
+	"canceled":  true,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &bulkSyncSchemaResyncResource{}
+
+func NewBulkSyncSchemaResyncResource() resource.Resource {
+	return &bulkSyncSchemaResyncResource{}
+}
+
+type bulkSyncSchemaResyncResource struct {
+	provider *providerclient.Provider
+}
+
+type bulkSyncSchemaResyncResourceModel struct {
+	ID                types.String         `tfsdk:"id"`
+	Organization      types.String         `tfsdk:"organization"`
+	BulkSyncID        types.String         `tfsdk:"bulk_sync_id"`
+	Schemas           types.Set            `tfsdk:"schemas"`
+	Triggers          types.Map            `tfsdk:"triggers"`
+	WaitForCompletion types.Bool           `tfsdk:"wait_for_completion"`
+	WaitTimeout       timetypes.GoDuration `tfsdk:"wait_timeout"`
+	Status            types.String         `tfsdk:"status"`
+}
+
+func (r *bulkSyncSchemaResyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bulk_sync_schema_resync"
+}
+
+func (r *bulkSyncSchemaResyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: ":meta:subcategory:Bulk Syncs: Bulk Sync Schema Resync\n\n" +
+			"Resyncs schemas of a bulk sync, e.g. after a change to the destination table. The resync is " +
+			"requested when the resource is created, and again whenever `schemas` or `triggers` change. " +
+			"Destroying the resource doesn't cancel the resync.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the resync's execution",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "Organization ID",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bulk_sync_id": schema.StringAttribute{
+				MarkdownDescription: "Bulk sync ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schemas": schema.SetAttribute{
+				MarkdownDescription: "IDs of the schemas to resync",
+				ElementType:         types.StringType,
+				Required:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values which resync the schemas again when changed.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the resync to complete. A failed resync is an error; " +
+					"if it doesn't complete within `wait_timeout`, a warning is reported and the apply continues.",
+				Optional: true,
+			},
+			"wait_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the resync, as a Go duration, e.g. `30m`. Defaults to `1h`.",
+				CustomType:          timetypes.GoDurationType{},
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultResyncWaitTimeout),
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the resync's execution when it was last checked",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *bulkSyncSchemaResyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		r.provider = provider
+	}
+}
+
+func (r *bulkSyncSchemaResyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data bulkSyncSchemaResyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var schemas []string
+	resp.Diagnostics.Append(data.Schemas.ElementsAs(ctx, &schemas, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	execution, err := client.BulkSync.Start(ctx, data.BulkSyncID.ValueString(), &polytomic.StartBulkSyncRequest{
+		Resync:  pointer.To(true),
+		Schemas: schemas,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error starting resync: %s", err))
+		return
+	}
+	data.ID = types.StringPointerValue(execution.Data.Id)
+	data.Status = types.StringValue(string(pointer.Get(execution.Data.Status)))
+
+	if data.WaitForCompletion.ValueBool() {
+		resp.Diagnostics.Append(r.wait(ctx, client, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// wait waits for the resync's execution to finish, or for wait_timeout.
+func (r *bulkSyncSchemaResyncResource) wait(ctx context.Context, client *ptclient.Client, data *bulkSyncSchemaResyncResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	timeout, d := data.WaitTimeout.ValueGoDuration()
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	deadline := time.Now().Add(timeout)
+	tflog.Info(ctx, "waiting for bulk sync resync", map[string]any{
+		"bulk_sync_id": data.BulkSyncID.ValueString(),
+		"execution_id": data.ID.ValueString(),
+		"timeout":      timeout.String(),
+	})
+
+	for {
+		execution, err := client.BulkSync.Executions.Get(ctx, data.BulkSyncID.ValueString(), data.ID.ValueString())
+		if err != nil {
+			diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading resync execution: %s", err))
+			return diags
+		}
+		status := string(pointer.Get(execution.Data.Status))
+		data.Status = types.StringValue(status)
+		switch {
+		case status == "failed":
+			diags.AddError("Resync failed",
+				fmt.Sprintf("Resync %s of bulk sync %s failed.", data.ID.ValueString(), data.BulkSyncID.ValueString()))
+			return diags
+		case bulkSyncExecutionDone[status]:
+			return diags
+		case time.Now().After(deadline):
+			diags.AddWarning("Resync not complete",
+				fmt.Sprintf("Resync %s of bulk sync %s didn't complete within %s; its status is %q.",
+					data.ID.ValueString(), data.BulkSyncID.ValueString(), data.WaitTimeout.ValueString(), status))
+			return diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Error waiting for resync", ctx.Err().Error())
+			return diags
+		case <-time.After(bulkSyncExecutionPollInterval):
+		}
+	}
+}
+
+// Read keeps the resync in state; once requested, a resync isn't requested
+// again until it's replaced.
+func (r *bulkSyncSchemaResyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only changes wait_for_completion and wait_timeout, which apply to
+// the next resync.
+func (r *bulkSyncSchemaResyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data bulkSyncSchemaResyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resync from state.
+func (r *bulkSyncSchemaResyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+)
+
+func TestBulkSyncSchemaResyncResource(t *testing.T) {
+	pollInterval := bulkSyncExecutionPollInterval
+	bulkSyncExecutionPollInterval = time.Millisecond
+	t.Cleanup(func() { bulkSyncExecutionPollInterval = pollInterval })
+
+	factories, server := FakeProtoV6ProviderFactories(t)
+	bulkID := server.Put(fakeapi.BulkSyncs, fakeapi.Object{
+		"name":                      "Warehouse",
+		"source_connection_id":      "source",
+		"destination_connection_id": "destination",
+	})
+
+	config := func(wait bool, trigger string) string {
+		return fmt.Sprintf(`
+resource "polytomic_bulk_sync_schema_resync" "test" {
+  bulk_sync_id        = %q
+  schemas             = ["public.users", "public.orders"]
+  wait_for_completion = %t
+  triggers = {
+    migration = %q
+  }
+}
+`, bulkID, wait, trigger)
+	}
+	executions := func(n int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			executions := server.BulkSyncExecutions(bulkID)
+			if len(executions) != n {
+				return fmt.Errorf("expected %d executions, got %d", n, len(executions))
+			}
+			last := executions[n-1]
+			if last["resync"] != true {
+				return fmt.Errorf("expected a resync, got %v", last)
+			}
+			if len(last["schemas"].([]any)) != 2 {
+				return fmt.Errorf("expected two schemas to be resynced, got %v", last["schemas"])
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false, "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync_schema_resync.test",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("created"),
+					),
+				},
+				Check: executions(1),
+			},
+			{
+				// waiting alone doesn't resync again
+				Config: config(true, "1"),
+				Check:  executions(1),
+			},
+			{
+				Config: config(true, "2"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync_schema_resync.test",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("completed"),
+					),
+				},
+				Check: executions(2),
+			},
+		},
+	})
+}
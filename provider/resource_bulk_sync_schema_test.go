@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+)
+
+func TestBulkSyncSchemaResource(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	sourceID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	destID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "destination", "type": "postgresql"})
+	server.SetBulkSource(sourceID, fakeapi.Object{"schemas": []any{
+		fakeapi.Object{"id": "public.users", "name": "users"},
+		fakeapi.Object{"id": "public.orders", "name": "orders"},
+		fakeapi.Object{"id": "public.audit_log", "name": "audit_log"},
+	}})
+	server.SetBulkDestination(destID, fakeapi.Object{
+		"configuration": fakeapi.Object{},
+		"modes":         []any{fakeapi.Object{"id": "replicate", "label": "Replicate"}},
+	})
+
+	config := func(name, schemas string) string {
+		return fmt.Sprintf(`
+resource "polytomic_bulk_sync" "test" {
+  name           = %q
+  active         = true
+  mode           = "replicate"
+  manage_schemas = false
+
+  schedule = {
+    frequency = "manual"
+  }
+
+  source = {
+    connection_id = %q
+  }
+
+  destination = {
+    connection_id = %q
+  }
+}
+%s
+`, name, sourceID, destID, schemas)
+	}
+	users := func(trackingField string) string {
+		return fmt.Sprintf(`
+resource "polytomic_bulk_sync_schema" "users" {
+  bulk_sync_id   = polytomic_bulk_sync.test.id
+  schema_id      = "public.users"
+  tracking_field = %q
+}
+`, trackingField)
+	}
+	orders := `
+resource "polytomic_bulk_sync_schema" "orders" {
+  bulk_sync_id = polytomic_bulk_sync.test.id
+  schema_id    = "public.orders"
+}
+`
+	// enabled checks which of the bulk sync's schemas are enabled.
+	enabled := func(want map[string]bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			bulk, ok := server.Get(fakeapi.BulkSyncs, s.RootModule().Resources["polytomic_bulk_sync.test"].Primary.ID)
+			if !ok {
+				return fmt.Errorf("bulk sync not found")
+			}
+			got := map[string]bool{}
+			for _, item := range bulk["schemas"].([]any) {
+				schema := item.(fakeapi.Object)
+				got[schema["id"].(string)] = schema["enabled"] == true
+			}
+			for id, w := range want {
+				if got[id] != w {
+					return fmt.Errorf("expected schema %s enabled to be %t, got %v", id, w, got)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("TestBulkSyncSchemaResource", users("updated_at")+orders),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync.test",
+						tfjsonpath.New("schemas"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync_schema.users",
+						tfjsonpath.New("id"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^`+server.Organization+`/.+/public\.users$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync_schema.users",
+						tfjsonpath.New("tracking_field"),
+						knownvalue.StringExact("updated_at"),
+					),
+				},
+				Check: enabled(map[string]bool{
+					"public.users":     true,
+					"public.orders":    true,
+					"public.audit_log": false,
+				}),
+			},
+			{
+				// changing one schema doesn't change the bulk sync
+				Config: config("TestBulkSyncSchemaResource", users("modified_at")+orders),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("polytomic_bulk_sync.test", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("polytomic_bulk_sync_schema.users", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("polytomic_bulk_sync_schema.orders", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			{
+				// updating the bulk sync leaves its schemas unchanged
+				Config: config("TestBulkSyncSchemaResource-renamed", users("modified_at")+orders),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("polytomic_bulk_sync.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("polytomic_bulk_sync_schema.users", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: enabled(map[string]bool{
+					"public.users":     true,
+					"public.orders":    true,
+					"public.audit_log": false,
+				}),
+			},
+			{
+				ResourceName:      "polytomic_bulk_sync_schema.users",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// removing a schema disables it
+				Config: config("TestBulkSyncSchemaResource-renamed", users("modified_at")),
+				Check: enabled(map[string]bool{
+					"public.users":  true,
+					"public.orders": false,
+				}),
+			},
+			{
+				Config: config("TestBulkSyncSchemaResource-renamed", users("modified_at")) + fmt.Sprintf(`
+resource "polytomic_bulk_sync" "conflict" {
+  name           = "conflict"
+  active         = true
+  mode           = "replicate"
+  manage_schemas = false
+
+  schedule = {
+    frequency = "manual"
+  }
+
+  source = {
+    connection_id = %q
+  }
+
+  destination = {
+    connection_id = %q
+  }
+
+  schemas = [{
+    id = "public.users"
+  }]
+}
+`, sourceID, destID),
+				ExpectError: regexp.MustCompile(`schemas can't be set when manage_schemas is false`),
+			},
+		},
+	})
+}
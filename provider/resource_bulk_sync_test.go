@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	polytomic "github.com/polytomic/polytomic-go"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -43,9 +44,29 @@ var (
 // across all tests in the package. They are cleaned up via
 // POLYTOMIC_BULK_SYNC_TEST_SOURCE_ID / POLYTOMIC_BULK_SYNC_TEST_DEST_ID env
 // vars if pre-existing connections are preferred.
+//
+// The connection IDs are recorded in each test's cassette, so replayed tests
+// don't depend on which test created the connections.
 func getSharedBulkSyncConnections(t *testing.T) bulkSyncTestConnectionIDs {
 	t.Helper()
 
+	c := TestCassette(t)
+	if !c.Recording() {
+		return bulkSyncTestConnectionIDs{
+			SourceID: c.Value("bulk_sync_source_id", nil),
+			DestID:   c.Value("bulk_sync_dest_id", nil),
+		}
+	}
+
+	ids := createSharedBulkSyncConnections(t)
+	c.Value("bulk_sync_source_id", func() string { return ids.SourceID })
+	c.Value("bulk_sync_dest_id", func() string { return ids.DestID })
+	return ids
+}
+
+func createSharedBulkSyncConnections(t *testing.T) bulkSyncTestConnectionIDs {
+	t.Helper()
+
 	// Allow overriding with pre-existing connection IDs
 	if src := os.Getenv("POLYTOMIC_BULK_SYNC_TEST_SOURCE_ID"); src != "" {
 		dest := os.Getenv("POLYTOMIC_BULK_SYNC_TEST_DEST_ID")
@@ -469,6 +490,87 @@ func TestAccBulkSyncResource(t *testing.T) {
 	})
 }
 
+func TestBulkSyncResource(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	sourceID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	destID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "destination", "type": "postgresql"})
+	server.SetBulkSource(sourceID, fakeapi.Object{"schemas": []any{
+		fakeapi.Object{"id": "polytomic.sync_test_source", "name": "sync_test_source"},
+	}})
+	server.AddSchema(sourceID, fakeapi.Object{
+		"id":   "polytomic.sync_test_source",
+		"name": "sync_test_source",
+		"fields": []any{
+			fakeapi.Object{"id": "created_at", "name": "created_at", "type": "datetime"},
+		},
+	})
+	server.SetBulkDestination(destID, fakeapi.Object{
+		"configuration": fakeapi.Object{"schema": fakeapi.Object{"type": "string"}},
+		"modes":         []any{fakeapi.Object{"id": "replicate", "label": "Replicate"}},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: bulkSyncBasicTestConfig(t, bulkSyncBasicTestArgs{
+					Name:               "TestBulkSyncResource",
+					SourceConnectionID: sourceID,
+					DestConnectionID:   destID,
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync.test",
+						tfjsonpath.New("mode"),
+						knownvalue.StringExact("replicate"),
+					),
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync.test",
+						tfjsonpath.New("active"),
+						knownvalue.Bool(true),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccBulkSyncExists(t, "TestBulkSyncResource"),
+				),
+			},
+			{
+				Config: bulkSyncAdvancedTestConfig(t, bulkSyncAdvancedTestArgs{
+					Name:               "TestBulkSyncResource-filtered",
+					SourceConnectionID: sourceID,
+					DestConnectionID:   destID,
+					Mode:               "replicate",
+					Active:             "false",
+					Schemas: `[{
+    id = "polytomic.sync_test_source"
+    filters = [{
+      field_id = "created_at"
+      function = "OnOrAfter"
+      value    = jsonencode("2024-01-01")
+    }]
+  }]`,
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_bulk_sync.test",
+						tfjsonpath.New("active"),
+						knownvalue.Bool(false),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccBulkSyncExists(t, "TestBulkSyncResource-filtered"),
+				),
+			},
+			{
+				ResourceName:            "polytomic_bulk_sync.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"destination", "source", "schemas"},
+			},
+		},
+	})
+}
+
 func testAccBulkSyncExists(t *testing.T, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r, ok := s.RootModule().Resources["polytomic_bulk_sync.test"]
@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
@@ -21,6 +24,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &connectionSchemaPrimaryKeysResource{}
 var _ resource.ResourceWithImportState = &connectionSchemaPrimaryKeysResource{}
+var _ resource.ResourceWithIdentity = &connectionSchemaPrimaryKeysResource{}
 
 func NewConnectionSchemaPrimaryKeysResource() resource.Resource {
 	return &connectionSchemaPrimaryKeysResource{}
@@ -175,13 +179,14 @@ func (r *connectionSchemaPrimaryKeysResource) Create(ctx context.Context, req re
 	}
 
 	// Set ID in composite format
-	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s",
+	data.ID = types.StringValue(schemaID(
 		data.Organization.ValueString(),
 		data.ConnectionID.ValueString(),
 		data.SchemaID.ValueString()))
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setSchemaPrimaryKeysIdentity(ctx, resp.Identity, data)...)
 }
 
 func (r *connectionSchemaPrimaryKeysResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -268,6 +273,7 @@ func (r *connectionSchemaPrimaryKeysResource) Read(ctx context.Context, req reso
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setSchemaPrimaryKeysIdentity(ctx, resp.Identity, data)...)
 }
 
 func (r *connectionSchemaPrimaryKeysResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -341,6 +347,7 @@ func (r *connectionSchemaPrimaryKeysResource) Update(ctx context.Context, req re
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setSchemaPrimaryKeysIdentity(ctx, resp.Identity, data)...)
 }
 
 func (r *connectionSchemaPrimaryKeysResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -380,20 +387,70 @@ func (r *connectionSchemaPrimaryKeysResource) Delete(ctx context.Context, req re
 }
 
 func (r *connectionSchemaPrimaryKeysResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// ID format: organization/connection_id/schema_id
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 3 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID in format: organization/connection_id/schema_id, got: %s", req.ID),
-		)
-		return
+	var identity connectionSchemaPrimaryKeysIdentityModel
+	if req.ID != "" {
+		// ID format: organization/connection_id/schema_id
+		parts := strings.Split(req.ID, "/")
+		if len(parts) != 3 {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected import ID in format: organization/connection_id/schema_id, got: %s", req.ID),
+			)
+			return
+		}
+		identity.Organization = types.StringValue(parts[0])
+		identity.ConnectionID = types.StringValue(parts[1])
+		identity.SchemaID = types.StringValue(parts[2])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_id"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema_id"), parts[2])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), identity.Organization)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_id"), identity.ConnectionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema_id"), identity.SchemaID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), schemaID(
+		identity.Organization.ValueString(),
+		identity.ConnectionID.ValueString(),
+		identity.SchemaID.ValueString()))...)
 
 	// Note: field_ids will be populated by the subsequent Read operation
 }
+
+type connectionSchemaPrimaryKeysIdentityModel struct {
+	Organization types.String `tfsdk:"organization"`
+	ConnectionID types.String `tfsdk:"connection_id"`
+	SchemaID     types.String `tfsdk:"schema_id"`
+}
+
+func (r *connectionSchemaPrimaryKeysResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization": identityschema.StringAttribute{
+				Description:       "Organization ID",
+				RequiredForImport: true,
+			},
+			"connection_id": identityschema.StringAttribute{
+				Description:       "Connection ID",
+				RequiredForImport: true,
+			},
+			"schema_id": identityschema.StringAttribute{
+				Description:       "Schema ID",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func setSchemaPrimaryKeysIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data connectionSchemaPrimaryKeysResourceModel) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, connectionSchemaPrimaryKeysIdentityModel{
+		Organization: data.Organization,
+		ConnectionID: data.ConnectionID,
+		SchemaID:     data.SchemaID,
+	})
+}
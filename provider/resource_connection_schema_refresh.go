@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &connectionSchemaRefreshResource{}
+
+func NewConnectionSchemaRefreshResource() resource.Resource {
+	return &connectionSchemaRefreshResource{}
+}
+
+type connectionSchemaRefreshResource struct {
+	provider *providerclient.Provider
+}
+
+type connectionSchemaRefreshResourceModel struct {
+	ID           types.String      `tfsdk:"id"`
+	Organization types.String      `tfsdk:"organization"`
+	ConnectionID types.String      `tfsdk:"connection_id"`
+	Triggers     types.Map         `tfsdk:"triggers"`
+	RefreshedAt  timetypes.RFC3339 `tfsdk:"refreshed_at"`
+}
+
+func (r *connectionSchemaRefreshResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_schema_refresh"
+}
+
+func (r *connectionSchemaRefreshResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: ":meta:subcategory:Connections: Connection Schema Refresh\n\n" +
+			"Refreshes a connection's schema cache, so tables and fields added to the source are discovered, " +
+			"and waits for the refresh to complete. The refresh runs when the resource is created, and again " +
+			"whenever `triggers` changes. Make bulk syncs depend on this resource to configure them after the refresh.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the refreshed connection",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "Organization ID",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values which refresh the schemas again when changed, e.g. a list of expected tables.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"refreshed_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the refresh completed",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *connectionSchemaRefreshResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		r.provider = provider
+	}
+}
+
+func (r *connectionSchemaRefreshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data connectionSchemaRefreshResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	if err := refreshConnectionSchemas(ctx, client, data.ConnectionID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, err.Error())
+		return
+	}
+
+	data.ID = data.ConnectionID
+	data.RefreshedAt = timetypes.NewRFC3339TimeValue(time.Now().UTC().Truncate(time.Second))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the refresh in state; a refresh has nothing to read back.
+func (r *connectionSchemaRefreshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update is never called with changes which need a refresh, since changing
+// any configured attribute replaces the resource.
+func (r *connectionSchemaRefreshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data connectionSchemaRefreshResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the refresh from state; the refreshed schemas are kept.
+func (r *connectionSchemaRefreshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+)
+
+func TestConnectionSchemaRefreshResource(t *testing.T) {
+	pollInterval := schemaRefreshPollInterval
+	schemaRefreshPollInterval = time.Millisecond
+	t.Cleanup(func() { schemaRefreshPollInterval = pollInterval })
+
+	factories, server := FakeProtoV6ProviderFactories(t)
+	connID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	server.SetBulkSource(connID, fakeapi.Object{"schemas": []any{
+		fakeapi.Object{"id": "public.users", "name": "users"},
+	}})
+
+	config := func(version string) string {
+		return fmt.Sprintf(`
+resource "polytomic_connection_schema_refresh" "test" {
+  connection_id = %q
+  triggers = {
+    version = %q
+  }
+}
+`, connID, version)
+	}
+	refreshes := func(check func(n int) bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if n := server.SchemaRefreshes(connID); !check(n) {
+				return fmt.Errorf("unexpected number of schema refreshes: %d", n)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_connection_schema_refresh.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(connID),
+					),
+					statecheck.ExpectKnownValue(
+						"polytomic_connection_schema_refresh.test",
+						tfjsonpath.New("refreshed_at"),
+						knownvalue.NotNull(),
+					),
+				},
+				Check: refreshes(func(n int) bool { return n == 1 }),
+			},
+			{
+				// changing triggers refreshes again
+				Config: config("2"),
+				Check:  refreshes(func(n int) bool { return n == 2 }),
+			},
+			{
+				Config:   config("2"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("2") + fmt.Sprintf(`
+data "polytomic_bulk_source" "test" {
+  connection_id   = %q
+  refresh_schemas = true
+
+  depends_on = [polytomic_connection_schema_refresh.test]
+}
+`, connID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.polytomic_bulk_source.test",
+						tfjsonpath.New("schemas").AtSliceIndex(0).AtMapKey("id"),
+						knownvalue.StringExact("public.users"),
+					),
+				},
+				Check: refreshes(func(n int) bool { return n > 2 }),
+			},
+		},
+	})
+}
+
+func TestConnectionSchemaRefreshResourceStatus(t *testing.T) {
+	pollInterval := schemaRefreshPollInterval
+	schemaRefreshPollInterval = time.Millisecond
+	t.Cleanup(func() { schemaRefreshPollInterval = pollInterval })
+
+	factories, server := FakeProtoV6ProviderFactories(t)
+	connID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "source", "type": "postgresql"})
+	config := fmt.Sprintf(`
+resource "polytomic_connection_schema_refresh" "test" {
+  connection_id = %q
+}
+`, connID)
+
+	// The status of the previous refresh is reported until the refresh
+	// starts, so only the status it ends with fails the apply.
+	server.SetSchemaRefreshStatuses(connID, "ready", "error", "refreshing", "error")
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{{
+			Config:      config,
+			ExpectError: regexp.MustCompile(`failed with status "error"`),
+		}},
+	})
+}
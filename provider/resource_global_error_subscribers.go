@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -12,12 +11,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/polytomic/polytomic-go"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 const globalErrorSubscribersResourceID = "global-error-subscribers"
 
 var _ resource.Resource = &globalErrorSubscribersResource{}
 var _ resource.ResourceWithImportState = &globalErrorSubscribersResource{}
+var _ resource.ResourceWithIdentity = &globalErrorSubscribersResource{}
 
 func (r *globalErrorSubscribersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_global_error_subscribers"
@@ -111,6 +112,7 @@ func (r *globalErrorSubscribersResource) Create(ctx context.Context, req resourc
 	data.ID = types.StringValue(globalErrorSubscribersResourceID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 }
 
 func (r *globalErrorSubscribersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -147,6 +149,7 @@ func (r *globalErrorSubscribersResource) Read(ctx context.Context, req resource.
 	data.ID = types.StringValue(globalErrorSubscribersResourceID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 }
 
 func (r *globalErrorSubscribersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -193,6 +196,7 @@ func (r *globalErrorSubscribersResource) Update(ctx context.Context, req resourc
 	data.ID = types.StringValue(globalErrorSubscribersResourceID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 }
 
 func (r *globalErrorSubscribersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -219,5 +223,9 @@ func (r *globalErrorSubscribersResource) Delete(ctx context.Context, req resourc
 }
 
 func (r *globalErrorSubscribersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *globalErrorSubscribersResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
 }
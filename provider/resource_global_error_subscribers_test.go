@@ -67,6 +67,37 @@ func TestAccGlobalErrorSubscribersResource(t *testing.T) {
 	})
 }
 
+func TestGlobalErrorSubscribersResource(t *testing.T) {
+	factories, _ := FakeProtoV6ProviderFactories(t)
+	name := "TestGlobalErrorSubscribers"
+	email1 := fmt.Sprintf("%s-1@example.com", name)
+	email2 := fmt.Sprintf("%s-2@example.com", name)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: TestCaseTfResource(t, globalErrorSubscribersResourceTemplateTwoEmails, TestCaseTfArgs{
+					Name:   name,
+					APIKey: true,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccGlobalErrorSubscribersMatch(t, []string{email1, email2}, true),
+				),
+			},
+			{
+				Config: TestCaseTfResource(t, globalErrorSubscribersResourceTemplateOneEmail, TestCaseTfArgs{
+					Name:   name,
+					APIKey: true,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccGlobalErrorSubscribersMatch(t, []string{email1}, true),
+				),
+			},
+		},
+	})
+}
+
 func testAccGlobalErrorSubscribersMatch(t *testing.T, expected []string, apiKey bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		var orgID string
@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
@@ -20,13 +23,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &modelResource{}
 var _ resource.ResourceWithImportState = &modelResource{}
+var _ resource.ResourceWithIdentity = &modelResource{}
+var _ list.ListResourceWithConfigure = &modelResource{}
 var _ resource.ResourceWithUpgradeState = &modelResource{}
+var _ resource.ResourceWithModifyPlan = &modelResource{}
 
 func (r *modelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -44,6 +52,10 @@ func (r *modelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"connection_id": schema.StringAttribute{
 				MarkdownDescription: "",
@@ -125,6 +137,12 @@ func (r *modelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional: true,
 				Computed: true,
 			},
+			"manage_relations": schema.BoolAttribute{
+				MarkdownDescription: "Whether `relations` configures the model's relations. Set to `false` to configure them with `polytomic_model_relation` resources instead; `relations` must then be unset, and the model's relations are left unchanged when it's updated.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
 			"identifier": schema.StringAttribute{
 				MarkdownDescription: "",
 				Optional:            true,
@@ -183,6 +201,7 @@ type modelResourceResourceData struct {
 	Fields           types.Set         `tfsdk:"fields"`
 	AdditionalFields types.Set         `tfsdk:"additional_fields"`
 	Relations        types.Set         `tfsdk:"relations"`
+	ManageRelations  types.Bool        `tfsdk:"manage_relations"`
 	Identifier       types.String      `tfsdk:"identifier"`
 	TrackingColumns  types.Set         `tfsdk:"tracking_columns"`
 	Policies         types.Set         `tfsdk:"policies"`
@@ -211,6 +230,8 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	// manage_relations defaults to true, which is only reflected in the plan
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("manage_relations"), &data.ManageRelations)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -404,6 +425,10 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.Configuration = types.StringValue(string(enc))
 	data.Fields = fields
 	data.Relations = relations
+	if !data.ManageRelations.ValueBool() {
+		// configured by polytomic_model_relation resources
+		data.Relations = types.SetNull(types.ObjectType{AttrTypes: modelRelationAttrTypes})
+	}
 	data.Identifier = types.StringValue(pointer.Get(model.Data.Identifier))
 	data.TrackingColumns = trackingColumns
 	data.AdditionalFields = additionalFields
@@ -439,6 +464,7 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -459,6 +485,10 @@ func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		resp.Diagnostics.AddError("Error getting client", err.Error())
 		return
 	}
+	if data.ManageRelations.IsNull() {
+		// imported, or upgraded from a version without manage_relations
+		data.ManageRelations = types.BoolValue(true)
+	}
 	model, err := client.Models.Get(ctx, data.ID.ValueString(), &polytomic.ModelsGetRequest{})
 	if err != nil {
 		pErr := &ptcore.APIError{}
@@ -595,6 +625,10 @@ func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Configuration = types.StringValue(string(enc))
 	data.Fields = fields
 	data.Relations = relations
+	if !data.ManageRelations.ValueBool() {
+		// configured by polytomic_model_relation resources
+		data.Relations = types.SetNull(types.ObjectType{AttrTypes: modelRelationAttrTypes})
+	}
 	data.Identifier = types.StringValue(pointer.Get(model.Data.Identifier))
 	data.TrackingColumns = trackingColumns
 	data.AdditionalFields = additionalFields
@@ -630,6 +664,7 @@ func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 
 }
 
@@ -703,6 +738,16 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		resp.Diagnostics.AddError("Error getting client", err.Error())
 		return
 	}
+	if !data.ManageRelations.ValueBool() {
+		// keep the relations configured by polytomic_model_relation resources
+		defer lockModelRelations(data.ID.ValueString())()
+		current, err := client.Models.Get(ctx, data.ID.ValueString(), &polytomic.ModelsGetRequest{})
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating model", err.Error())
+			return
+		}
+		request.Relations = current.Data.Relations
+	}
 	model, err := client.Models.Update(ctx, data.ID.ValueString(), request)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating model", err.Error())
@@ -833,6 +878,10 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 	data.Configuration = types.StringValue(string(enc))
 	data.Fields = fields
 	data.Relations = relations
+	if !data.ManageRelations.ValueBool() {
+		// configured by polytomic_model_relation resources
+		data.Relations = types.SetNull(types.ObjectType{AttrTypes: modelRelationAttrTypes})
+	}
 	data.Identifier = types.StringValue(pointer.Get(model.Data.Identifier))
 	data.TrackingColumns = trackingColumns
 	data.AdditionalFields = additionalFields
@@ -868,6 +917,7 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 
 }
 
@@ -893,8 +943,25 @@ func (r *modelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ModifyPlan plans the relations of a model whose relations are configured by
+// polytomic_model_relation resources.
+func (r *modelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	planUnmanaged(ctx, req, resp, "manage_relations", "relations",
+		types.SetNull(types.ObjectType{AttrTypes: modelRelationAttrTypes}),
+		nil,
+		"Conflicting relation configuration",
+		"configure the model's relations with polytomic_model_relation resources.")
+}
+
 func (r *modelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *modelResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
 }
 func (r *modelResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
 	return map[int64]resource.StateUpgrader{
@@ -925,6 +992,7 @@ func (r *modelResource) UpgradeState(ctx context.Context) map[int64]resource.Sta
 					Fields:           priorStateData.Fields,
 					AdditionalFields: priorStateData.AdditionalFields,
 					Relations:        priorStateData.Relations,
+					ManageRelations:  types.BoolValue(true),
 					Identifier:       priorStateData.Identifier,
 					TrackingColumns:  priorStateData.TrackingColumns,
 				}
@@ -934,3 +1002,37 @@ func (r *modelResource) UpgradeState(ctx context.Context) map[int64]resource.Sta
 		},
 	}
 }
+
+func (r *modelResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *modelResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listresource.Config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := r.provider.Client(ctx, config.Organization.ValueString())
+	if err != nil {
+		stream.Results = listresource.Error("Error getting client", err.Error())
+		return
+	}
+	models, err := client.Models.List(ctx)
+	if err != nil {
+		stream.Results = listresource.Error(providerclient.ErrorSummary, fmt.Sprintf("Error listing models: %s", err))
+		return
+	}
+
+	items := make([]listresource.Item, 0, len(models.Data))
+	for _, v := range models.Data {
+		items = append(items, listresource.Item{
+			Organization: pointer.GetString(v.OrganizationId),
+			ID:           pointer.GetString(v.Id),
+			DisplayName:  pointer.GetString(v.Name),
+		})
+	}
+	stream.Results = listresource.Results(ctx, req, r, config, items)
+}
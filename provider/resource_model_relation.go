@@ -0,0 +1,399 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/polytomic-go"
+	ptclient "github.com/polytomic/polytomic-go/client"
+	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &modelRelationResource{}
+var _ resource.ResourceWithImportState = &modelRelationResource{}
+var _ resource.ResourceWithIdentity = &modelRelationResource{}
+
+func NewModelRelationResource() resource.Resource {
+	return &modelRelationResource{}
+}
+
+type modelRelationResource struct {
+	provider *providerclient.Provider
+}
+
+type modelRelationResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Organization types.String `tfsdk:"organization"`
+	ModelID      types.String `tfsdk:"model_id"`
+	From         types.String `tfsdk:"from"`
+	ToModelID    types.String `tfsdk:"to_model_id"`
+	ToField      types.String `tfsdk:"to_field"`
+}
+
+func (m modelRelationResourceModel) matches(relation *polytomic.ModelRelation) bool {
+	return relation != nil && relation.To != nil &&
+		relation.From == m.From.ValueString() &&
+		relation.To.ModelId == m.ToModelID.ValueString() &&
+		relation.To.Field == m.ToField.ValueString()
+}
+
+func (m modelRelationResourceModel) id() string {
+	return strings.Join([]string{
+		m.Organization.ValueString(),
+		m.ModelID.ValueString(),
+		m.From.ValueString(),
+		m.ToModelID.ValueString(),
+		m.ToField.ValueString(),
+	}, "/")
+}
+
+func (r *modelRelationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_relation"
+}
+
+func (r *modelRelationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: ":meta:subcategory:Models: Model Relation\n\n" +
+			"Relates a field of a model to a field of another model, so relations can be declared apart from " +
+			"the models they relate, e.g. in a different module, or between models which relate to each other. " +
+			"The model must have `manage_relations` set to `false`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier in the format: organization/model_id/from/to_model_id/to_field",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "Organization ID",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"model_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the model the relation is from",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "Field of the model which references the related model",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"to_model_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the related model",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"to_field": schema.StringAttribute{
+				MarkdownDescription: "Field of the related model which `from` references",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+		},
+	}
+}
+
+func (r *modelRelationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if provider := providerclient.GetProvider(req.ProviderData, resp.Diagnostics); provider != nil {
+		r.provider = provider
+	}
+}
+
+func (r *modelRelationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data modelRelationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	model, err := updateModelRelations(ctx, client, data.ModelID.ValueString(), func(relations []*polytomic.ModelRelation) ([]*polytomic.ModelRelation, error) {
+		for _, relation := range relations {
+			if data.matches(relation) {
+				return nil, fmt.Errorf("model %s already relates %s to %s.%s; import the relation to manage it",
+					data.ModelID.ValueString(), data.From.ValueString(), data.ToModelID.ValueString(), data.ToField.ValueString())
+			}
+		}
+		return append(relations, &polytomic.ModelRelation{
+			From: data.From.ValueString(),
+			To: &polytomic.ModelRelationTo{
+				ModelId: data.ToModelID.ValueString(),
+				Field:   data.ToField.ValueString(),
+			},
+		}), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error creating model relation: %s", err))
+		return
+	}
+	data.Organization = types.StringPointerValue(model.OrganizationId)
+	data.ID = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setModelRelationIdentity(ctx, resp.Identity, data)...)
+}
+
+func (r *modelRelationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data modelRelationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	model, err := client.Models.Get(ctx, data.ModelID.ValueString(), &polytomic.ModelsGetRequest{})
+	if err != nil {
+		pErr := &ptcore.APIError{}
+		if errors.As(err, &pErr) && pErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading model: %s", err))
+		return
+	}
+	if !slices.ContainsFunc(model.Data.Relations, data.matches) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Organization = types.StringPointerValue(model.Data.OrganizationId)
+	data.ID = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setModelRelationIdentity(ctx, resp.Identity, data)...)
+}
+
+// Update only records the plan: changing any of the relation's attributes
+// replaces it.
+func (r *modelRelationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data modelRelationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setModelRelationIdentity(ctx, resp.Identity, data)...)
+}
+
+func (r *modelRelationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data modelRelationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	_, err = updateModelRelations(ctx, client, data.ModelID.ValueString(), func(relations []*polytomic.ModelRelation) ([]*polytomic.ModelRelation, error) {
+		remaining := make([]*polytomic.ModelRelation, 0, len(relations))
+		for _, relation := range relations {
+			if !data.matches(relation) {
+				remaining = append(remaining, relation)
+			}
+		}
+		return remaining, nil
+	})
+	if err != nil {
+		pErr := &ptcore.APIError{}
+		if errors.As(err, &pErr) && pErr.StatusCode == http.StatusNotFound {
+			// the model is already gone
+			return
+		}
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error deleting model relation: %s", err))
+	}
+}
+
+func (r *modelRelationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity modelRelationResourceModel
+	if req.ID != "" {
+		parts := strings.Split(req.ID, "/")
+		if len(parts) != 5 || slices.Contains(parts, "") {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected import ID in format: organization/model_id/from/to_model_id/to_field, got: %s", req.ID))
+			return
+		}
+		identity.Organization = types.StringValue(parts[0])
+		identity.ModelID = types.StringValue(parts[1])
+		identity.From = types.StringValue(parts[2])
+		identity.ToModelID = types.StringValue(parts[3])
+		identity.ToField = types.StringValue(parts[4])
+	} else {
+		var id modelRelationIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		identity = id.model()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), identity.Organization)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("model_id"), identity.ModelID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("from"), identity.From)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("to_model_id"), identity.ToModelID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("to_field"), identity.ToField)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.id())...)
+}
+
+type modelRelationIdentityModel struct {
+	Organization types.String `tfsdk:"organization"`
+	ModelID      types.String `tfsdk:"model_id"`
+	From         types.String `tfsdk:"from"`
+	ToModelID    types.String `tfsdk:"to_model_id"`
+	ToField      types.String `tfsdk:"to_field"`
+}
+
+func (m modelRelationIdentityModel) model() modelRelationResourceModel {
+	return modelRelationResourceModel{
+		Organization: m.Organization,
+		ModelID:      m.ModelID,
+		From:         m.From,
+		ToModelID:    m.ToModelID,
+		ToField:      m.ToField,
+	}
+}
+
+func (r *modelRelationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization": identityschema.StringAttribute{
+				Description:       "Organization ID",
+				RequiredForImport: true,
+			},
+			"model_id": identityschema.StringAttribute{
+				Description:       "Model ID",
+				RequiredForImport: true,
+			},
+			"from": identityschema.StringAttribute{
+				Description:       "Field of the model",
+				RequiredForImport: true,
+			},
+			"to_model_id": identityschema.StringAttribute{
+				Description:       "Related model ID",
+				RequiredForImport: true,
+			},
+			"to_field": identityschema.StringAttribute{
+				Description:       "Field of the related model",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func setModelRelationIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data modelRelationResourceModel) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, modelRelationIdentityModel{
+		Organization: data.Organization,
+		ModelID:      data.ModelID,
+		From:         data.From,
+		ToModelID:    data.ToModelID,
+		ToField:      data.ToField,
+	})
+}
+
+// modelRelationAttrTypes are the attribute types of polytomic_model's
+// relations.
+var modelRelationAttrTypes = map[string]attr.Type{
+	"to": types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"model_id": types.StringType,
+			"field":    types.StringType,
+		},
+	},
+	"from": types.StringType,
+}
+
+// modelRelationLocks holds a mutex for each model whose relations are
+// updated, so concurrently applied polytomic_model_relation resources of the
+// same model don't overwrite each other's changes.
+var modelRelationLocks sync.Map
+
+func lockModelRelations(modelID string) func() {
+	mu, _ := modelRelationLocks.LoadOrStore(modelID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// updateModelRelations replaces a model's relations with those returned by
+// update, leaving the rest of the model unchanged, and returns the updated
+// model.
+func updateModelRelations(ctx context.Context, client *ptclient.Client, modelID string, update func([]*polytomic.ModelRelation) ([]*polytomic.ModelRelation, error)) (*polytomic.ModelResponse, error) {
+	defer lockModelRelations(modelID)()
+
+	model, err := client.Models.Get(ctx, modelID, &polytomic.ModelsGetRequest{})
+	if err != nil {
+		return nil, err
+	}
+	request := modelUpdateRequest(model.Data)
+	request.Relations, err = update(model.Data.Relations)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := client.Models.Update(ctx, modelID, request)
+	if err != nil {
+		return nil, err
+	}
+	return updated.Data, nil
+}
+
+// modelUpdateRequest returns the request which updates a model to what it
+// already is.
+func modelUpdateRequest(model *polytomic.ModelResponse) *polytomic.UpdateModelRequest {
+	request := &polytomic.UpdateModelRequest{
+		Name:            pointer.GetString(model.Name),
+		ConnectionId:    pointer.GetString(model.ConnectionId),
+		Configuration:   model.Configuration,
+		Relations:       model.Relations,
+		TrackingColumns: model.TrackingColumns,
+		OrganizationId:  model.OrganizationId,
+	}
+	for _, field := range model.Fields {
+		if !pointer.GetBool(field.UserAdded) {
+			request.Fields = append(request.Fields, pointer.GetString(field.Name))
+			continue
+		}
+		request.AdditionalFields = append(request.AdditionalFields, &polytomic.ModelModelFieldRequest{
+			Name:  pointer.GetString(field.Name),
+			Type:  pointer.GetString(field.Type),
+			Label: pointer.GetString(field.Label),
+		})
+	}
+	if pointer.GetString(model.Identifier) != "" {
+		request.Identifier = model.Identifier
+	}
+	return request
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+)
+
+func TestModelRelationResource(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	connID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "warehouse", "type": "postgresql"})
+
+	models := func(usersName, relations string) string {
+		return fmt.Sprintf(`
+resource "polytomic_model" "accounts" {
+  name          = "accounts"
+  connection_id = %[1]q
+  configuration = jsonencode({ table = "accounts" })
+  fields        = ["id"]
+}
+
+resource "polytomic_model" "users" {
+  name             = %[2]q
+  connection_id    = %[1]q
+  configuration    = jsonencode({ table = "users" })
+  fields           = ["id", "account_id"]
+  manage_relations = false
+%[3]s
+}
+`, connID, usersName, relations)
+	}
+	config := func(usersName, relations string) string {
+		return models(usersName, relations) + `
+resource "polytomic_model_relation" "users_accounts" {
+  model_id    = polytomic_model.users.id
+  from        = "account_id"
+  to_model_id = polytomic_model.accounts.id
+  to_field    = "id"
+}
+`
+	}
+	// related checks the relations of the users model.
+	related := func(want int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			users := s.RootModule().Resources["polytomic_model.users"].Primary.ID
+			accounts := s.RootModule().Resources["polytomic_model.accounts"].Primary.ID
+			model, ok := server.Get(fakeapi.Models, users)
+			if !ok {
+				return fmt.Errorf("model not found")
+			}
+			relations, _ := model["relations"].([]any)
+			if len(relations) != want {
+				return fmt.Errorf("expected %d relations, got %v", want, relations)
+			}
+			for _, r := range relations {
+				relation := r.(fakeapi.Object)
+				to, _ := relation["to"].(fakeapi.Object)
+				if relation["from"] != "account_id" || to["model_id"] != accounts || to["field"] != "id" {
+					return fmt.Errorf("unexpected relation %v", relation)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("users", ""),
+				Check: resource.ComposeTestCheckFunc(
+					related(1),
+					resource.TestCheckNoResourceAttr("polytomic_model.users", "relations"),
+				),
+			},
+			{
+				// updating the model keeps the relation
+				Config: config("all users", ""),
+				Check:  related(1),
+			},
+			{
+				ResourceName:      "polytomic_model_relation.users_accounts",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("all users", `
+  relations = [{
+    from = "account_id"
+    to   = { model_id = polytomic_model.accounts.id, field = "id" }
+  }]`),
+				ExpectError: regexp.MustCompile(`Conflicting relation configuration`),
+			},
+			{
+				// removing the relation resource removes the relation
+				Config: models("all users", ""),
+				Check:  related(0),
+			},
+		},
+	})
+}
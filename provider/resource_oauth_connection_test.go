@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestOAuthConnectionResource(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	server.SetOAuthType("airtable")
+
+	config := func(name string, wait bool) string {
+		return fmt.Sprintf(`
+resource "polytomic_oauth_connection" "test" {
+  name                   = %q
+  type                   = "airtable"
+  redirect_url           = "https://example.com/connected"
+  wait_for_authorization = %t
+  wait_timeout           = "1s"
+}
+`, name, wait)
+	}
+	authorized := func(want bool, status string) []statecheck.StateCheck {
+		return []statecheck.StateCheck{
+			statecheck.ExpectKnownValue("polytomic_oauth_connection.test",
+				tfjsonpath.New("authorized"), knownvalue.Bool(want)),
+			statecheck.ExpectKnownValue("polytomic_oauth_connection.test",
+				tfjsonpath.New("status"), knownvalue.StringExact(status)),
+			statecheck.ExpectKnownValue("polytomic_oauth_connection.test",
+				tfjsonpath.New("authorization_url"), knownvalue.NotNull()),
+		}
+	}
+	// applied checks how long the step's apply took, and how many connect
+	// links have been created for the connection.
+	var start time.Time
+	var id string
+	applied := func(minDuration, maxDuration time.Duration, links int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if elapsed := time.Since(start); elapsed < minDuration || elapsed > maxDuration {
+				return fmt.Errorf("expected apply to take between %s and %s, took %s", minDuration, maxDuration, elapsed)
+			}
+			id = s.RootModule().Resources["polytomic_oauth_connection.test"].Primary.ID
+			if n := server.ConnectLinks(id); n != links {
+				return fmt.Errorf("expected %d connect links, got %d", links, n)
+			}
+			return nil
+		}
+	}
+	started := func() { start = time.Now() }
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				// creating doesn't wait, since the authorization URL can't be
+				// read until the apply completes; while the connection isn't
+				// authorized, each plan updates it to wait
+				PreConfig:          started,
+				Config:             config("Bases", true),
+				ConfigStateChecks:  authorized(false, "unhealthy"),
+				Check:              applied(0, 5*time.Second, 1),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// updating waits for the existing URL to be used, and times
+				// out with a warning
+				PreConfig:          started,
+				Config:             config("All Bases", true),
+				ConfigStateChecks:  authorized(false, "unhealthy"),
+				Check:              applied(time.Second, time.Minute, 1),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// applying again without changes also waits
+				PreConfig:          started,
+				Config:             config("All Bases", true),
+				ConfigStateChecks:  authorized(false, "unhealthy"),
+				Check:              applied(time.Second, time.Minute, 1),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// updating without waiting creates a new URL
+				PreConfig:         started,
+				Config:            config("Bases", false),
+				ConfigStateChecks: authorized(false, "unhealthy"),
+				Check:             applied(0, 5*time.Second, 2),
+			},
+			{
+				// the status is refreshed once the connection is authorized
+				PreConfig:         func() { server.Authorize(id) },
+				Config:            config("Bases", false),
+				ConfigStateChecks: authorized(true, "healthy"),
+			},
+		},
+	})
+}
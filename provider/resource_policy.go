@@ -7,8 +7,9 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,11 +18,15 @@ import (
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
 	"github.com/polytomic/polytomic-go/permissions"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 var _ resource.Resource = &policyResource{}
 var _ resource.ResourceWithImportState = &policyResource{}
+var _ resource.ResourceWithIdentity = &policyResource{}
+var _ list.ListResourceWithConfigure = &policyResource{}
 
 func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -31,6 +36,10 @@ func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Organization ID",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required: true,
@@ -167,6 +176,7 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -246,6 +256,7 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -325,6 +336,7 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -350,5 +362,47 @@ func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *policyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *policyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *policyResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *policyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listresource.Config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := r.provider.Client(ctx, config.Organization.ValueString())
+	if err != nil {
+		stream.Results = listresource.Error("Error getting client", err.Error())
+		return
+	}
+	policies, err := client.Permissions.Policies.List(ctx)
+	if err != nil {
+		stream.Results = listresource.Error(providerclient.ErrorSummary, fmt.Sprintf("Error listing policies: %s", err))
+		return
+	}
+
+	items := make([]listresource.Item, 0, len(policies.Data))
+	for _, v := range policies.Data {
+		// System policies are not editable
+		if pointer.GetBool(v.System) {
+			continue
+		}
+		items = append(items, listresource.Item{
+			Organization: pointer.GetString(v.OrganizationId),
+			ID:           pointer.GetString(v.Id),
+			DisplayName:  pointer.GetString(v.Name),
+		})
+	}
+	stream.Results = listresource.Results(ctx, req, r, config, items)
 }
@@ -58,6 +58,32 @@ func TestAccPolicy(t *testing.T) {
 	})
 }
 
+func TestPolicyResource(t *testing.T) {
+	factories, _ := FakeProtoV6ProviderFactories(t)
+	name := "TestPolicyResource"
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: TestCaseTfResource(t, policyResourceTemplate, TestCaseTfArgs{
+					Name:   name,
+					APIKey: true,
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_policy.test",
+						tfjsonpath.New("policy_actions"),
+						knownvalue.ListSizeExact(2),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccPolicyExists(t, name, true),
+				),
+			},
+		},
+	})
+}
+
 func testAccPolicyExists(t *testing.T, name string, apiKey bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		var orgID string
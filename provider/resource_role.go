@@ -6,7 +6,8 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -14,11 +15,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	ptcore "github.com/polytomic/polytomic-go/core"
 	"github.com/polytomic/polytomic-go/permissions"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 var _ resource.Resource = &roleResource{}
 var _ resource.ResourceWithImportState = &roleResource{}
+var _ resource.ResourceWithIdentity = &roleResource{}
+var _ list.ListResourceWithConfigure = &roleResource{}
 
 func (r *roleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -28,6 +33,10 @@ func (r *roleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Organization ID",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required: true,
@@ -101,6 +110,7 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -138,6 +148,7 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -175,6 +186,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -200,5 +212,47 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *roleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+func (r *roleResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *roleResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listresource.Config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := r.provider.Client(ctx, config.Organization.ValueString())
+	if err != nil {
+		stream.Results = listresource.Error("Error getting client", err.Error())
+		return
+	}
+	roles, err := client.Permissions.Roles.List(ctx)
+	if err != nil {
+		stream.Results = listresource.Error(providerclient.ErrorSummary, fmt.Sprintf("Error listing roles: %s", err))
+		return
+	}
+
+	items := make([]listresource.Item, 0, len(roles.Data))
+	for _, v := range roles.Data {
+		// System roles are not editable
+		if pointer.GetBool(v.System) {
+			continue
+		}
+		items = append(items, listresource.Item{
+			Organization: pointer.GetString(v.OrganizationId),
+			ID:           pointer.GetString(v.Id),
+			DisplayName:  pointer.GetString(v.Name),
+		})
+	}
+	stream.Results = listresource.Results(ctx, req, r, config, items)
 }
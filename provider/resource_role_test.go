@@ -43,6 +43,37 @@ func TestAccRole(t *testing.T) {
 	})
 }
 
+func TestRoleResource(t *testing.T) {
+	factories, _ := FakeProtoV6ProviderFactories(t)
+	name := "TestRoleResource"
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: TestCaseTfResource(t, roleResourceTemplate, TestCaseTfArgs{
+					Name:   name,
+					APIKey: true,
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_role.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(name),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccRoleExists(t, name, true),
+				),
+			},
+			{
+				ResourceName:      "polytomic_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccRoleExists(t *testing.T, name string, apiKey bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		var orgID string
@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,12 +27,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/polytomic/polytomic-go"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &syncResource{}
 var _ resource.ResourceWithImportState = &syncResource{}
+var _ resource.ResourceWithIdentity = &syncResource{}
+var _ list.ListResourceWithConfigure = &syncResource{}
+var _ resource.ResourceWithModifyPlan = &syncResource{}
+var _ resource.ResourceWithConfigValidators = &syncResource{}
 
 // NewSyncResourceForSchemaIntrospection returns a sync resource instance
 // for schema introspection. This is used by the importer to validate field mappings.
@@ -54,6 +63,7 @@ func (r *syncResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -106,7 +116,7 @@ func (r *syncResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:            true,
 			},
 			"fields": schema.SetNestedAttribute{
-				MarkdownDescription: "Fields to sync from source to destination.",
+				MarkdownDescription: "Fields to sync from source to destination. Required unless `auto_map` is set; fields listed here take precedence over those mapped by `auto_map`.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"source": schema.SingleNestedAttribute{
@@ -145,7 +155,19 @@ func (r *syncResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 						},
 					},
 				},
-				Required: true,
+				Optional: true,
+			},
+			"auto_map": schema.SingleNestedAttribute{
+				MarkdownDescription: "Map the model's fields to the target object's fields with matching names. Requires `target.object`.",
+				Optional:            true,
+				Attributes:          syncAutoMap{}.SchemaAttributes(),
+			},
+			"auto_mapped_fields": schema.SetNestedAttribute{
+				MarkdownDescription: "Fields mapped by `auto_map`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: autoMappedField{}.SchemaAttributes(),
+				},
 			},
 			"override_fields": schema.SetNestedAttribute{
 				MarkdownDescription: "Fields whose values are set unconditionally in the target, regardless of source data.",
@@ -326,7 +348,9 @@ func (r *syncResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 						},
 					},
 					"run_after": schema.SingleNestedAttribute{
-						MarkdownDescription: "Configure this sync to run after other syncs complete. Used with `runafter` frequency.",
+						MarkdownDescription: "Configure this sync to run after other syncs complete. Used with `runafter` frequency. " +
+							"Dependencies are checked against the organization's syncs when planning, so inactive dependencies " +
+							"and cycles are reported as warnings even if the same apply activates the dependency or removes the cycle.",
 						Attributes: map[string]schema.Attribute{
 							"sync_ids": schema.SetAttribute{
 								MarkdownDescription: "Sync identifiers that must complete before this sync runs.",
@@ -421,6 +445,11 @@ func (r *syncResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"enrichment_model_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the models other than the identity's model which fields are mapped from. Their fields are joined to the identity model's records through model relations, which can be declared with `polytomic_model_relation`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 			"policies": schema.SetAttribute{
 				MarkdownDescription: "Policy IDs attached to this sync",
 				ElementType:         types.StringType,
@@ -461,6 +490,8 @@ type syncResourceResourceData struct {
 	Target               types.Object      `tfsdk:"target"`
 	Mode                 types.String      `tfsdk:"mode"`
 	Fields               types.Set         `tfsdk:"fields"`
+	AutoMap              types.Object      `tfsdk:"auto_map"`
+	AutoMappedFields     types.Set         `tfsdk:"auto_mapped_fields"`
 	OverrideFields       types.Set         `tfsdk:"override_fields"`
 	Filters              types.Set         `tfsdk:"filters"`
 	TargetFilters        types.Set         `tfsdk:"target_filters"`
@@ -474,6 +505,7 @@ type syncResourceResourceData struct {
 	OnlyEnrichUpdates    types.Bool        `tfsdk:"only_enrich_updates"`
 	SkipInitialBackfill  types.Bool        `tfsdk:"skip_initial_backfill"`
 	ModelIds             types.Set         `tfsdk:"model_ids"`
+	EnrichmentModelIds   types.Set         `tfsdk:"enrichment_model_ids"`
 	Policies             types.Set         `tfsdk:"policies"`
 	CreatedAt            timetypes.RFC3339 `tfsdk:"created_at"`
 	CreatedBy            types.Object      `tfsdk:"created_by"`
@@ -824,6 +856,17 @@ func (r *syncResource) Create(ctx context.Context, req resource.CreateRequest, r
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auto_map"), &data.AutoMap)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auto_mapped_fields"), &data.AutoMappedFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	autoMapped, diags := r.applyAutoMap(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fields = append(fields, autoMapped...)
 
 	overrideFields, d := overrideFieldsToSDK(ctx, data.OverrideFields)
 	resp.Diagnostics.Append(d...)
@@ -906,6 +949,7 @@ func (r *syncResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 	configTarget := data.Target
+	prior := data
 	configPassphrase := data.EncryptionPassphrase
 
 	sync, err := client.ModelSync.Create(ctx, request)
@@ -926,11 +970,18 @@ func (r *syncResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	diags = splitAutoMappedFields(ctx, &data, prior)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
 	// Preserve write-only encryption_passphrase from the plan (the API never returns it).
 	data.EncryptionPassphrase = configPassphrase
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 
 }
 
@@ -949,6 +1000,7 @@ func (r *syncResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 	priorTarget := data.Target
+	prior := data
 	priorPassphrase := data.EncryptionPassphrase
 
 	sync, err := client.ModelSync.Get(ctx, data.ID.ValueString())
@@ -976,11 +1028,18 @@ func (r *syncResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	diags = splitAutoMappedFields(ctx, &data, prior)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
 	// Preserve write-only encryption_passphrase from prior state (the API never returns it).
 	data.EncryptionPassphrase = priorPassphrase
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 
 }
 
@@ -1035,6 +1094,12 @@ func (r *syncResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	autoMapped, diags := r.applyAutoMap(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fields = append(fields, autoMapped...)
 
 	overrideFields, d := overrideFieldsToSDK(ctx, data.OverrideFields)
 	resp.Diagnostics.Append(d...)
@@ -1110,6 +1175,7 @@ func (r *syncResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	planTarget := data.Target
+	prior := data
 	planPassphrase := data.EncryptionPassphrase
 
 	client, err := r.provider.Client(ctx, data.Organization.ValueString())
@@ -1135,11 +1201,18 @@ func (r *syncResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	diags = splitAutoMappedFields(ctx, &data, prior)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
 	// Preserve write-only encryption_passphrase from the plan (the API never returns it).
 	data.EncryptionPassphrase = planPassphrase
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.ID)...)
 }
 
 func (r *syncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -1166,7 +1239,125 @@ func (r *syncResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *syncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resourceidentity.ImportState(ctx, req, resp)
+}
+
+func (r *syncResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
+// ModifyPlan resolves the sync's run_after dependencies against the
+// organization's syncs, so that cycles and inactive or deleted upstreams are
+// reported at plan time instead of leaving a chain that never fires.
+// Deactivating or deleting an active sync that others run after produces a
+// warning.
+// auto_map is resolved against the model's and target's fields, and
+// enrichment models which aren't related to the identity model are warned
+// about.
+func (r *syncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.provider == nil {
+		return
+	}
+
+	var state *syncResourceResourceData
+	if !req.State.Raw.IsNull() {
+		state = &syncResourceResourceData{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if req.Plan.Raw.IsNull() {
+		// Destroy; an inactive sync doesn't trigger its dependents anyway.
+		if !state.Active.ValueBool() {
+			return
+		}
+		client, err := r.provider.Client(ctx, state.Organization.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting client", err.Error())
+			return
+		}
+		graph, err := loadSyncGraph(ctx, client)
+		if err != nil {
+			resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading sync dependency graph: %s", err))
+			return
+		}
+		resp.Diagnostics.Append(graph.validateDependents(state.ID.ValueString(), "deleted")...)
+		return
+	}
+
+	r.planAutoMap(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.planEnrichment(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan syncResourceResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Organization.IsUnknown() {
+		return
+	}
+
+	var syncIDs, bulkSyncIDs []string
+	var runAfter types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("schedule").AtName("run_after"), &runAfter)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !runAfter.IsNull() && !runAfter.IsUnknown() {
+		var known bool
+		attrs := runAfter.Attributes()
+		if syncIDs, known = knownStrings(attrs["sync_ids"].(types.Set)); !known {
+			return
+		}
+		if bulkSyncIDs, known = knownStrings(attrs["bulk_sync_ids"].(types.Set)); !known {
+			return
+		}
+	}
+
+	deactivating := state != nil && state.Active.ValueBool() &&
+		!plan.Active.IsUnknown() && !plan.Active.ValueBool()
+	if len(syncIDs) == 0 && len(bulkSyncIDs) == 0 && !deactivating {
+		return
+	}
+
+	client, err := r.provider.Client(ctx, plan.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting client", err.Error())
+		return
+	}
+	graph, err := loadSyncGraph(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading sync dependency graph: %s", err))
+		return
+	}
+
+	id := ""
+	if state != nil {
+		id = state.ID.ValueString()
+	}
+	if deactivating {
+		resp.Diagnostics.Append(graph.validateDependents(id, "deactivated")...)
+	}
+	resp.Diagnostics.Append(graph.validateRunAfter(id, plan.Active.IsUnknown() || plan.Active.ValueBool(), syncIDs, bulkSyncIDs)...)
+}
+
+// ConfigValidators requires a sync to map fields, either explicitly or with
+// auto_map.
+func (r *syncResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("fields"),
+			path.MatchRoot("auto_map"),
+		),
+	}
 }
 
 // preserveTargetCreate copies the "create" attribute from priorTarget into data.Target,
@@ -1271,6 +1462,8 @@ func syncDataFromResponse(ctx context.Context, sync *polytomic.ModelSyncResponse
 	if diags.HasError() {
 		return data, diags
 	}
+	data.AutoMap = types.ObjectNull(syncAutoMap{}.AttrTypes())
+	data.AutoMappedFields = types.SetNull(types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()})
 
 	// Override Fields
 	overrideFieldAttrTypes := map[string]attr.Type{
@@ -1422,6 +1615,14 @@ func syncDataFromResponse(ctx context.Context, sync *polytomic.ModelSyncResponse
 	if diags.HasError() {
 		return data, diags
 	}
+	data.EnrichmentModelIds = types.SetNull(types.StringType)
+	if sync.Identity != nil && sync.Identity.Source != nil && sync.Identity.Source.ModelId != "" {
+		data.EnrichmentModelIds, diags = types.SetValueFrom(ctx, types.StringType,
+			enrichmentModelIDs(sync.Identity.Source.ModelId, modelIDs))
+		if diags.HasError() {
+			return data, diags
+		}
+	}
 
 	// Policies
 	data.Policies, diags = types.SetValueFrom(ctx, types.StringType, sync.Policies)
@@ -1461,3 +1662,37 @@ func marshalJSONNormalized(v interface{}) jsontypes.Normalized {
 	}
 	return jsontypes.NewNormalizedValue(string(b))
 }
+
+func (r *syncResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *syncResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listresource.Config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := r.provider.Client(ctx, config.Organization.ValueString())
+	if err != nil {
+		stream.Results = listresource.Error("Error getting client", err.Error())
+		return
+	}
+	syncs, err := client.ModelSync.List(ctx, &polytomic.ModelSyncListRequest{})
+	if err != nil {
+		stream.Results = listresource.Error(providerclient.ErrorSummary, fmt.Sprintf("Error listing syncs: %s", err))
+		return
+	}
+
+	items := make([]listresource.Item, 0, len(syncs.Data))
+	for _, v := range syncs.Data {
+		items = append(items, listresource.Item{
+			Organization: pointer.GetString(v.OrganizationId),
+			ID:           pointer.GetString(v.Id),
+			DisplayName:  pointer.GetString(v.Name),
+		})
+	}
+	stream.Results = listresource.Results(ctx, req, r, config, items)
+}
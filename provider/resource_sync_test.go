@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
@@ -56,6 +57,61 @@ func TestAccSyncResource(t *testing.T) {
 	})
 }
 
+func TestSyncResource(t *testing.T) {
+	factories, _ := FakeProtoV6ProviderFactories(t)
+	config := func(name string) string {
+		return TestCaseTfResource(t, syncResourceTemplate, TestCaseTfArgs{
+			Name:   name,
+			APIKey: true,
+			Postgres: postgresTestConfig{
+				Host:     "db.example.com",
+				Database: "polytomic",
+				Username: "polytomic",
+				Password: "secret",
+				Port:     5432,
+			},
+		})
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("TestSyncResource"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_sync.test",
+						tfjsonpath.New("mode"),
+						knownvalue.StringExact("replace"),
+					),
+					statecheck.ExpectKnownValue(
+						"polytomic_sync.test",
+						tfjsonpath.New("active"),
+						knownvalue.Bool(false),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccSyncExists(t, "TestSyncResource", true),
+				),
+			},
+			{
+				// organization isn't configured, so renaming updates the
+				// connection, model and sync in place
+				Config: config("TestSyncResource-renamed"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("polytomic_postgresql_connection.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("polytomic_model.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("polytomic_sync.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccSyncExists(t, "TestSyncResource-renamed", true),
+				),
+			},
+		},
+	})
+}
+
 func testAccSyncExists(t *testing.T, name string, apiKey bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		var orgID string
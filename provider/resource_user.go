@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,13 +18,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/polytomic/polytomic-go"
+	ptclient "github.com/polytomic/polytomic-go/client"
 	ptcore "github.com/polytomic/polytomic-go/core"
+	"github.com/polytomic/terraform-provider-polytomic/internal/listresource"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/internal/resourceidentity"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &userResource{}
 var _ resource.ResourceWithImportState = &userResource{}
+var _ resource.ResourceWithIdentity = &userResource{}
+var _ list.ListResourceWithConfigure = &userResource{}
 
 func (r *userResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -140,6 +146,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -193,6 +200,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -258,6 +266,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resourceidentity.Set(ctx, resp.Identity, data.Organization, data.Id)...)
 }
 
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -281,7 +290,17 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+func (r *userResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceidentity.Schema
+}
+
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		// Importing by identity
+		resourceidentity.ImportState(ctx, req, resp)
+		return
+	}
+
 	var organizationID, identifier string
 
 	// Parse import ID format
@@ -300,18 +319,11 @@ func (r *userResource) ImportState(ctx context.Context, req resource.ImportState
 			return
 		}
 
-		identity, err := client.Identity.Get(ctx)
+		organizationID, err = callerOrganization(ctx, client)
 		if err != nil {
-			resp.Diagnostics.AddError("Error getting caller identity", fmt.Sprintf("Unable to determine organization from API key: %s", err))
+			resp.Diagnostics.AddError("Error getting organization", err.Error())
 			return
 		}
-
-		if identity.Data.OrganizationId == nil {
-			resp.Diagnostics.AddError("Error getting organization", "Caller identity does not have an organization ID")
-			return
-		}
-
-		organizationID = *identity.Data.OrganizationId
 	} else {
 		resp.Diagnostics.AddError(
 			"Invalid import ID format",
@@ -388,3 +400,59 @@ func (r *userResource) ImportState(ctx context.Context, req resource.ImportState
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organizationID)...)
 }
+
+// callerOrganization returns the organization of the API key used by client.
+func callerOrganization(ctx context.Context, client *ptclient.Client) (string, error) {
+	identity, err := client.Identity.Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine organization from API key: %w", err)
+	}
+	if identity.Data.OrganizationId == nil {
+		return "", errors.New("caller identity does not have an organization ID")
+	}
+	return *identity.Data.OrganizationId, nil
+}
+
+func (r *userResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listresource.ConfigSchema
+}
+
+func (r *userResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listresource.Config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := r.provider.Client(ctx, config.Organization.ValueString())
+	if err != nil {
+		stream.Results = listresource.Error("Error getting client", err.Error())
+		return
+	}
+	// Users are listed by organization, so default to the caller's.
+	organizationID := config.Organization.ValueString()
+	if organizationID == "" {
+		organizationID, err = callerOrganization(ctx, client)
+		if err != nil {
+			stream.Results = listresource.Error("Error getting organization", err.Error())
+			return
+		}
+		config.Organization = types.StringValue(organizationID)
+	}
+	users, err := client.Users.List(ctx, organizationID)
+	if err != nil {
+		stream.Results = listresource.Error(providerclient.ErrorSummary, fmt.Sprintf("Error listing users: %s", err))
+		return
+	}
+
+	items := make([]listresource.Item, 0, len(users.Data))
+	for _, user := range users.Data {
+		items = append(items, listresource.Item{
+			Organization: pointer.GetString(user.OrganizationId),
+			ID:           pointer.GetString(user.Id),
+			DisplayName:  pointer.GetString(user.Email),
+		})
+	}
+	stream.Results = listresource.Results(ctx, req, r, config, items)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
 )
 
 func TestAccUser_basic(t *testing.T) {
@@ -68,6 +69,46 @@ func TestAccUser_basic(t *testing.T) {
 	})
 }
 
+func TestUserResource(t *testing.T) {
+	// users belong to an organization created by the test, which requires a
+	// partner key
+	factories, server := FakeProtoV6ProviderFactories(t)
+	t.Setenv(providerclient.PolytomicAPIKey, "")
+	t.Setenv(providerclient.PolytomicPartnerKey, server.PartnerKey)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResource("test@example.com", "admin", "TestUserResource"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_user.admin",
+						tfjsonpath.New("role"),
+						knownvalue.StringExact("admin"),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserExists(t, "test@example.com", false),
+				),
+			},
+			{
+				Config: testAccUserResource("mIxEdCase@example.com", "admin", "TestUserResource"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_user.admin",
+						tfjsonpath.New("email"),
+						knownvalue.StringExact("mIxEdCase@example.com"),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserExists(t, "mIxEdCase@example.com", false),
+				),
+			},
+		},
+	})
+}
+
 func testAccUserExists(t *testing.T, email string, apiKey bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		var orgID string
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ptclient "github.com/polytomic/polytomic-go/client"
+)
+
+const (
+	// schemaCacheRefreshing is the cache status of a connection whose schemas
+	// are being refreshed.
+	schemaCacheRefreshing = "refreshing"
+	// schemaCacheReady is the cache status of a connection whose schemas have
+	// been refreshed.
+	schemaCacheReady = "ready"
+)
+
+var (
+	// schemaRefreshPollInterval is how often the schema cache status is
+	// checked while waiting for a refresh to complete.
+	schemaRefreshPollInterval = 5 * time.Second
+	// schemaRefreshTimeout is how long to wait for a refresh to complete.
+	schemaRefreshTimeout = 10 * time.Minute
+)
+
+// refreshConnectionSchemas requests a refresh of a connection's schema cache,
+// so tables added to the source are discovered, and waits for the refresh to
+// complete. The status reported before the refresh starts is that of the
+// previous refresh, so the refresh is complete once the cache is ready after
+// having been refreshing; any other status it ends with is an error.
+func refreshConnectionSchemas(ctx context.Context, client *ptclient.Client, connectionID string) error {
+	if err := client.Schemas.Refresh(ctx, connectionID); err != nil {
+		return fmt.Errorf("error requesting schema refresh: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, schemaRefreshTimeout)
+	defer cancel()
+	started := false
+	for {
+		status, err := client.Schemas.GetStatus(ctx, connectionID)
+		if err != nil {
+			return fmt.Errorf("error reading schema cache status: %w", err)
+		}
+		cacheStatus := pointer.Get(status.Data.CacheStatus)
+		switch {
+		case cacheStatus == schemaCacheRefreshing:
+			started = true
+		case !started:
+			// the status is still that of the previous refresh
+		case cacheStatus == schemaCacheReady:
+			return nil
+		default:
+			return fmt.Errorf("schema refresh of connection %s failed with status %q", connectionID, cacheStatus)
+		}
+
+		tflog.Info(ctx, "Waiting for schema refresh", map[string]any{
+			"connection_id": connectionID,
+			"status":        cacheStatus,
+		})
+		select {
+		case <-ctx.Done():
+			if !started {
+				return fmt.Errorf("schema refresh of connection %s didn't start within %s", connectionID, schemaRefreshTimeout)
+			}
+			return fmt.Errorf("schema refresh of connection %s didn't complete within %s", connectionID, schemaRefreshTimeout)
+		case <-time.After(schemaRefreshPollInterval):
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/polytomic/terraform-provider-polytomic/internal/schemasnapshot"
+	"github.com/stretchr/testify/require"
+)
+
+var updateSchema = flag.Bool("update-schema", false, "update the provider schema snapshot")
+
+const schemaSnapshotPath = "testdata/schema.json"
+
+// TestSchemaSnapshot compares the provider's schema to the checked in
+// snapshot, and is skipped until the snapshot is created. Breaking changes to
+// a resource require incrementing its schema version and adding a state
+// upgrader from the previous version; other breaking changes are accepted by
+// updating the snapshot with -update-schema.
+func TestSchemaSnapshot(t *testing.T) {
+	ctx := t.Context()
+	current, err := schemasnapshot.FromProvider(ctx, providerserver.NewProtocol6(New("test")())())
+	require.NoError(t, err)
+
+	previous, err := schemasnapshot.Load(schemaSnapshotPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if !*updateSchema {
+			t.Skipf("%s has not been created; create it with: make schema-snapshot", schemaSnapshotPath)
+		}
+		require.NoError(t, current.Write(schemaSnapshotPath))
+		return
+	}
+	require.NoError(t, err)
+
+	upgraders := resourceUpgraders(ctx)
+	for name, schema := range current.Resources {
+		prev, ok := previous.Resources[name]
+		if ok && schema.Version > prev.Version && !upgraders[name][prev.Version] {
+			t.Errorf("%s's schema version was incremented to %d, but it has no state upgrader from version %d",
+				name, schema.Version, prev.Version)
+		}
+	}
+	for _, c := range schemasnapshot.BreakingChanges(previous, current) {
+		switch {
+		case c.Upgraded:
+		case c.Kind == schemasnapshot.KindResource && c.Path != "":
+			t.Errorf("breaking change to %s; increment the resource's schema version and add a state upgrader", c)
+		case *updateSchema:
+			t.Logf("accepting breaking change to %s", c)
+		default:
+			t.Errorf("breaking change to %s; re-run with -update-schema to accept it", c)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+
+	if *updateSchema {
+		require.NoError(t, current.Write(schemaSnapshotPath))
+		return
+	}
+	if !reflect.DeepEqual(previous, current) {
+		t.Errorf("the provider schema has changed; update %s with: go test ./provider -run TestSchemaSnapshot -update-schema",
+			schemaSnapshotPath)
+	}
+}
+
+// resourceUpgraders returns the schema versions each resource can upgrade
+// state from.
+func resourceUpgraders(ctx context.Context) map[string]map[int64]bool {
+	upgraders := map[string]map[int64]bool{}
+	for _, f := range New("test")().Resources(ctx) {
+		r := f()
+		var meta resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: Name}, &meta)
+
+		versions := map[int64]bool{}
+		if u, ok := r.(resource.ResourceWithUpgradeState); ok {
+			for v := range u.UpgradeState(ctx) {
+				versions[v] = true
+			}
+		}
+		upgraders[meta.TypeName] = versions
+	}
+	return upgraders
+}
@@ -0,0 +1,460 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	stdpath "path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/polytomic/polytomic-go"
+	"github.com/polytomic/polytomic-go/modelsync"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+const (
+	autoMapExact           = "exact"
+	autoMapCaseInsensitive = "case_insensitive"
+	autoMapSnakeCase       = "snake_case"
+)
+
+// syncAutoMap maps a model's fields to the target's fields with matching
+// names.
+type syncAutoMap struct {
+	ModelID  types.String `tfsdk:"model_id"`
+	Strategy types.String `tfsdk:"strategy"`
+	Exclude  types.Set    `tfsdk:"exclude"`
+}
+
+func (syncAutoMap) SchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"model_id": schema.StringAttribute{
+			MarkdownDescription: "Model whose fields are mapped.",
+			Required:            true,
+		},
+		"strategy": schema.StringAttribute{
+			MarkdownDescription: "How model field names are matched to target field names: `exact`, `case_insensitive`, or `snake_case`, which compares names converted to snake case, so `FirstName` matches `first_name`. Defaults to `exact`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(autoMapExact),
+			Validators: []validator.String{
+				stringvalidator.OneOf(autoMapExact, autoMapCaseInsensitive, autoMapSnakeCase),
+			},
+		},
+		"exclude": schema.SetAttribute{
+			MarkdownDescription: "[Patterns](../resources/bulk_sync#patterns) of model field names not to map, e.g. `_*`.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+	}
+}
+
+func (syncAutoMap) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"model_id": types.StringType,
+		"strategy": types.StringType,
+		"exclude":  types.SetType{ElemType: types.StringType},
+	}
+}
+
+// autoMappedField is a field mapping resolved from a sync's auto_map.
+type autoMappedField struct {
+	Source types.Object `tfsdk:"source"`
+	Target types.String `tfsdk:"target"`
+}
+
+func (autoMappedField) SchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"source": schema.SingleNestedAttribute{
+			MarkdownDescription: "Source model field reference.",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"model_id": schema.StringAttribute{
+					MarkdownDescription: "Source model identifier.",
+					Computed:            true,
+				},
+				"field": schema.StringAttribute{
+					MarkdownDescription: "Source field name.",
+					Computed:            true,
+				},
+			},
+		},
+		"target": schema.StringAttribute{
+			MarkdownDescription: "Target field identifier that the source value is written to.",
+			Computed:            true,
+		},
+	}
+}
+
+func (autoMappedField) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"source": types.ObjectType{AttrTypes: map[string]attr.Type{
+			"model_id": types.StringType,
+			"field":    types.StringType,
+		}},
+		"target": types.StringType,
+	}
+}
+
+// targetField is a field of a sync's target object.
+type targetField struct {
+	id, name string
+}
+
+// autoMapping maps a model field to a target field.
+type autoMapping struct {
+	field, target string
+}
+
+// snakeCase converts name to snake case, splitting words at changes of case
+// and at any character which isn't a letter or digit.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// split "firstName" and the "Server" of "HTTPServer"
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// autoMapKey returns the key name is compared by under strategy.
+func autoMapKey(strategy, name string) string {
+	switch strategy {
+	case autoMapCaseInsensitive:
+		return strings.ToLower(name)
+	case autoMapSnakeCase:
+		return snakeCase(name)
+	default:
+		return name
+	}
+}
+
+// matchAutoMap maps modelFields to the targetFields whose names match under
+// strategy, sorted by target. Fields matching exclude, and targets which are
+// already mapped, are skipped; each target is mapped at most once, from the
+// first matching model field in sorted order.
+func matchAutoMap(strategy string, exclude, modelFields []string, targetFields []targetField, mapped []string) ([]autoMapping, error) {
+	targets := map[string]string{}
+	for _, f := range targetFields {
+		key := autoMapKey(strategy, cmp.Or(f.name, f.id))
+		if _, ok := targets[key]; !ok {
+			targets[key] = f.id
+		}
+	}
+
+	mappings := []autoMapping{}
+	claimed := map[string]bool{}
+	for _, t := range mapped {
+		claimed[t] = true
+	}
+	for _, field := range slices.Sorted(slices.Values(modelFields)) {
+		skip := false
+		for _, p := range exclude {
+			ok, err := stdpath.Match(p, field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+			}
+			skip = skip || ok
+		}
+		if skip {
+			continue
+		}
+		target, ok := targets[autoMapKey(strategy, field)]
+		if !ok || claimed[target] {
+			continue
+		}
+		claimed[target] = true
+		mappings = append(mappings, autoMapping{field: field, target: target})
+	}
+	slices.SortFunc(mappings, func(a, b autoMapping) int { return cmp.Compare(a.target, b.target) })
+	return mappings, nil
+}
+
+// autoMappedFieldsValue returns mappings from modelID as the value of
+// auto_mapped_fields.
+func autoMappedFieldsValue(ctx context.Context, modelID string, mappings []autoMapping) (types.Set, diag.Diagnostics) {
+	type source struct {
+		ModelID string `tfsdk:"model_id"`
+		Field   string `tfsdk:"field"`
+	}
+	type field struct {
+		Source source `tfsdk:"source"`
+		Target string `tfsdk:"target"`
+	}
+	values := make([]field, len(mappings))
+	for i, m := range mappings {
+		values[i] = field{Source: source{ModelID: modelID, Field: m.field}, Target: m.target}
+	}
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}, values)
+}
+
+// explicitTargets returns the targets of a sync's fields, and whether they're
+// known.
+func explicitTargets(ctx context.Context, fields types.Set) ([]string, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if fields.IsNull() {
+		return nil, true, diags
+	}
+	if fields.IsUnknown() {
+		return nil, false, diags
+	}
+	var targets []string
+	for _, elem := range fields.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			return nil, false, diags
+		}
+		target, ok := obj.Attributes()["target"].(types.String)
+		if !ok || target.IsUnknown() {
+			return nil, false, diags
+		}
+		targets = append(targets, target.ValueString())
+	}
+	return targets, true, diags
+}
+
+// autoMapKnown reports whether data's auto_map can be resolved, i.e. whether
+// it, the target and the explicitly mapped targets are known.
+func autoMapKnown(ctx context.Context, data syncResourceResourceData) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.AutoMap.IsUnknown() || data.Target.IsUnknown() {
+		return false, diags
+	}
+	if v, err := data.AutoMap.ToTerraformValue(ctx); err != nil || !v.IsFullyKnown() {
+		return false, diags
+	}
+	attrs := data.Target.Attributes()
+	for _, name := range []string{"connection_id", "object"} {
+		if attrs[name].IsUnknown() {
+			return false, diags
+		}
+	}
+	_, known, d := explicitTargets(ctx, data.Fields)
+	diags.Append(d...)
+	return known, diags
+}
+
+// resolveAutoMap resolves data's auto_map against the fields of its model and
+// target object.
+func (r *syncResource) resolveAutoMap(ctx context.Context, data syncResourceResourceData) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var autoMap syncAutoMap
+	diags.Append(data.AutoMap.As(ctx, &autoMap, basetypes.ObjectAsOptions{})...)
+	var target Target
+	diags.Append(data.Target.As(ctx, &target, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+	var exclude []string
+	if !autoMap.Exclude.IsNull() {
+		diags.Append(autoMap.Exclude.ElementsAs(ctx, &exclude, false)...)
+	}
+	mapped, _, d := explicitTargets(ctx, data.Fields)
+	diags.Append(d...)
+	if diags.HasError() {
+		return types.SetNull(types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}), diags
+	}
+	if target.Object == nil {
+		diags.AddAttributeError(path.Root("auto_map"), "Missing target object",
+			"auto_map requires target.object, so the target's fields can be read; new target objects must be mapped with fields.")
+		return types.SetNull(types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}), diags
+	}
+
+	client, err := r.provider.Client(ctx, data.Organization.ValueString())
+	if err != nil {
+		diags.AddError("Error getting client", err.Error())
+		return types.SetNull(types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}), diags
+	}
+	model, err := client.Models.Get(ctx, autoMap.ModelID.ValueString(), &polytomic.ModelsGetRequest{})
+	if err != nil {
+		diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading model: %s", err))
+		return types.SetNull(types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}), diags
+	}
+	targetMeta, err := client.ModelSync.Targets.GetTargetFields(ctx, target.ConnectionID, &modelsync.TargetsGetTargetFieldsRequest{
+		Target: pointer.Get(target.Object),
+	})
+	if err != nil {
+		diags.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading target fields: %s", err))
+		return types.SetNull(types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}), diags
+	}
+
+	modelFields := make([]string, 0, len(model.Data.Fields))
+	for _, f := range model.Data.Fields {
+		modelFields = append(modelFields, pointer.Get(f.Name))
+	}
+	targetFields := make([]targetField, 0, len(targetMeta.Data.Fields))
+	for _, f := range targetMeta.Data.Fields {
+		targetFields = append(targetFields, targetField{id: pointer.Get(f.Id), name: pointer.Get(f.Name)})
+	}
+	mappings, err := matchAutoMap(autoMap.Strategy.ValueString(), exclude, modelFields, targetFields, mapped)
+	if err != nil {
+		diags.AddAttributeError(path.Root("auto_map").AtName("exclude"), "Invalid auto_map", err.Error())
+		return types.SetNull(types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}), diags
+	}
+	return autoMappedFieldsValue(ctx, autoMap.ModelID.ValueString(), mappings)
+}
+
+// planAutoMap plans auto_mapped_fields as the resolved auto_map.
+func (r *syncResource) planAutoMap(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan syncResourceResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fieldsPath := path.Root("auto_mapped_fields")
+	fieldType := types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}
+	if plan.AutoMap.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fieldsPath, types.SetNull(fieldType))...)
+		return
+	}
+
+	known, diags := autoMapKnown(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !known {
+		// applyAutoMap resolves it
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fieldsPath, types.SetUnknown(fieldType))...)
+		return
+	}
+
+	mapped, diags := r.resolveAutoMap(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(mapped.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("auto_map"), "No fields mapped",
+			"auto_map doesn't match any of the target's fields which aren't already mapped by fields.")
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fieldsPath, mapped)...)
+}
+
+// applyAutoMap returns the fields mapped by data's auto_map, resolving it if
+// it wasn't known when planning.
+func (r *syncResource) applyAutoMap(ctx context.Context, data *syncResourceResourceData) ([]*polytomic.ModelSyncField, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.AutoMap.IsNull() {
+		return nil, diags
+	}
+	if data.AutoMappedFields.IsUnknown() || data.AutoMappedFields.IsNull() {
+		data.AutoMappedFields, diags = r.resolveAutoMap(ctx, *data)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	var mapped []autoMappedField
+	diags.Append(data.AutoMappedFields.ElementsAs(ctx, &mapped, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	fields := make([]*polytomic.ModelSyncField, len(mapped))
+	for i, m := range mapped {
+		var source struct {
+			ModelID string `tfsdk:"model_id"`
+			Field   string `tfsdk:"field"`
+		}
+		diags.Append(m.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+		fields[i] = &polytomic.ModelSyncField{
+			Source: &polytomic.Source{ModelId: source.ModelID, Field: source.Field},
+			Target: m.Target.ValueString(),
+		}
+	}
+	return fields, diags
+}
+
+// splitAutoMappedFields moves the fields of data which were mapped by
+// auto_map, as recorded in prior's auto_mapped_fields, from fields to
+// auto_mapped_fields, so they aren't compared to the configured fields. prior
+// is the plan or state data was read for; its auto_map is preserved, since
+// the API doesn't return it.
+func splitAutoMappedFields(ctx context.Context, data *syncResourceResourceData, prior syncResourceResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	fieldType := types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}
+	data.AutoMap = types.ObjectNull(syncAutoMap{}.AttrTypes())
+	data.AutoMappedFields = types.SetNull(fieldType)
+	if prior.AutoMap.IsNull() || prior.AutoMap.IsUnknown() {
+		return diags
+	}
+	data.AutoMap = prior.AutoMap
+
+	auto := map[string]bool{}
+	if !prior.AutoMappedFields.IsNull() && !prior.AutoMappedFields.IsUnknown() {
+		var mapped []autoMappedField
+		diags.Append(prior.AutoMappedFields.ElementsAs(ctx, &mapped, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		for _, m := range mapped {
+			auto[m.Target.ValueString()] = true
+		}
+	}
+
+	var explicit, moved []types.Object
+	for _, elem := range data.Fields.Elements() {
+		obj := elem.(types.Object)
+		target := obj.Attributes()["target"].(types.String).ValueString()
+		if !auto[target] {
+			explicit = append(explicit, obj)
+			continue
+		}
+		moved = append(moved, obj)
+	}
+
+	fields := make([]attr.Value, len(moved))
+	for i, obj := range moved {
+		attrs := obj.Attributes()
+		var d diag.Diagnostics
+		fields[i], d = types.ObjectValue(autoMappedField{}.AttrTypes(), map[string]attr.Value{
+			"source": attrs["source"],
+			"target": attrs["target"],
+		})
+		diags.Append(d...)
+	}
+	var d diag.Diagnostics
+	data.AutoMappedFields, d = types.SetValue(fieldType, fields)
+	diags.Append(d...)
+
+	elemType := data.Fields.ElementType(ctx)
+	if len(explicit) == 0 && prior.Fields.IsNull() {
+		// only auto_map maps fields
+		data.Fields = types.SetNull(elemType)
+		return diags
+	}
+	values := make([]attr.Value, len(explicit))
+	for i, obj := range explicit {
+		values[i] = obj
+	}
+	data.Fields, d = types.SetValue(elemType, values)
+	diags.Append(d...)
+	return diags
+}
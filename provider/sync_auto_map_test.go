@@ -0,0 +1,349 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"first_name":   "first_name",
+		"FirstName":    "first_name",
+		"firstName":    "first_name",
+		"First Name":   "first_name",
+		"first-name":   "first_name",
+		"HTTPServer":   "http_server",
+		"address2Line": "address2_line",
+		"ID":           "id",
+		"_private":     "private",
+	} {
+		assert.Equal(t, want, snakeCase(name), name)
+	}
+}
+
+func TestMatchAutoMap(t *testing.T) {
+	modelFields := []string{"Email", "FirstName", "id", "last_name", "_loaded_at"}
+	targetFields := []targetField{
+		{id: "email", name: "Email"},
+		{id: "first_name", name: "first_name"},
+		{id: "LastName", name: "Last Name"},
+		{id: "Id"},
+		{id: "loaded_at", name: "loaded_at"},
+	}
+
+	tests := map[string]struct {
+		strategy string
+		exclude  []string
+		mapped   []string
+		want     []autoMapping
+	}{
+		"exact": {
+			strategy: autoMapExact,
+			want: []autoMapping{
+				{field: "Email", target: "email"},
+			},
+		},
+		"case insensitive": {
+			strategy: autoMapCaseInsensitive,
+			want: []autoMapping{
+				{field: "id", target: "Id"},
+				{field: "Email", target: "email"},
+			},
+		},
+		"snake case": {
+			strategy: autoMapSnakeCase,
+			want: []autoMapping{
+				{field: "id", target: "Id"},
+				{field: "last_name", target: "LastName"},
+				{field: "Email", target: "email"},
+				{field: "FirstName", target: "first_name"},
+				{field: "_loaded_at", target: "loaded_at"},
+			},
+		},
+		"exclude": {
+			strategy: autoMapSnakeCase,
+			exclude:  []string{"_*", "*Name"},
+			want: []autoMapping{
+				{field: "id", target: "Id"},
+				{field: "last_name", target: "LastName"},
+				{field: "Email", target: "email"},
+			},
+		},
+		"already mapped": {
+			strategy: autoMapCaseInsensitive,
+			mapped:   []string{"email"},
+			want: []autoMapping{
+				{field: "id", target: "Id"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := matchAutoMap(tc.strategy, tc.exclude, modelFields, targetFields, tc.mapped)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := matchAutoMap(autoMapExact, []string{"[a"}, modelFields, targetFields, nil)
+		assert.ErrorContains(t, err, `invalid pattern "[a"`)
+	})
+}
+
+func TestSplitAutoMappedFields(t *testing.T) {
+	ctx := t.Context()
+	sourceType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"model_id": types.StringType,
+		"field":    types.StringType,
+	}}
+	fieldType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"source":             sourceType,
+		"target":             types.StringType,
+		"new":                types.BoolType,
+		"override_value":     types.StringType,
+		"sync_mode":          types.StringType,
+		"encryption_enabled": types.BoolType,
+	}}
+	autoFieldType := types.ObjectType{AttrTypes: autoMappedField{}.AttrTypes()}
+
+	source := func(field string) types.Object {
+		return types.ObjectValueMust(sourceType.AttrTypes, map[string]attr.Value{
+			"model_id": types.StringValue("model"),
+			"field":    types.StringValue(field),
+		})
+	}
+	field := func(name, target string) attr.Value {
+		return types.ObjectValueMust(fieldType.AttrTypes, map[string]attr.Value{
+			"source":             source(name),
+			"target":             types.StringValue(target),
+			"new":                types.BoolNull(),
+			"override_value":     types.StringNull(),
+			"sync_mode":          types.StringNull(),
+			"encryption_enabled": types.BoolNull(),
+		})
+	}
+	autoField := func(name, target string) attr.Value {
+		return types.ObjectValueMust(autoFieldType.AttrTypes, map[string]attr.Value{
+			"source": source(name),
+			"target": types.StringValue(target),
+		})
+	}
+	autoMap := types.ObjectValueMust(syncAutoMap{}.AttrTypes(), map[string]attr.Value{
+		"model_id": types.StringValue("model"),
+		"strategy": types.StringValue(autoMapCaseInsensitive),
+		"exclude":  types.SetNull(types.StringType),
+	})
+
+	// the API returns the explicit and auto-mapped fields together
+	read := func() syncResourceResourceData {
+		return syncResourceResourceData{
+			Fields: types.SetValueMust(fieldType, []attr.Value{
+				field("id", "Email"),
+				field("firstname", "FirstName"),
+			}),
+		}
+	}
+
+	t.Run("auto-mapped fields", func(t *testing.T) {
+		data := read()
+		prior := syncResourceResourceData{
+			Fields:           types.SetValueMust(fieldType, []attr.Value{field("id", "Email")}),
+			AutoMap:          autoMap,
+			AutoMappedFields: types.SetValueMust(autoFieldType, []attr.Value{autoField("firstname", "FirstName")}),
+		}
+		require.False(t, splitAutoMappedFields(ctx, &data, prior).HasError())
+		assert.Equal(t, autoMap, data.AutoMap, "auto_map is preserved")
+		assert.Equal(t, types.SetValueMust(fieldType, []attr.Value{field("id", "Email")}), data.Fields)
+		assert.Equal(t, types.SetValueMust(autoFieldType, []attr.Value{autoField("firstname", "FirstName")}), data.AutoMappedFields)
+	})
+
+	t.Run("only auto_map", func(t *testing.T) {
+		data := read()
+		prior := syncResourceResourceData{
+			Fields:  types.SetNull(fieldType),
+			AutoMap: autoMap,
+			AutoMappedFields: types.SetValueMust(autoFieldType, []attr.Value{
+				autoField("id", "Email"),
+				autoField("firstname", "FirstName"),
+			}),
+		}
+		require.False(t, splitAutoMappedFields(ctx, &data, prior).HasError())
+		assert.True(t, data.Fields.IsNull(), "fields stays unset")
+		assert.Len(t, data.AutoMappedFields.Elements(), 2)
+	})
+
+	t.Run("no auto_map", func(t *testing.T) {
+		data := read()
+		prior := syncResourceResourceData{
+			AutoMap:          types.ObjectNull(syncAutoMap{}.AttrTypes()),
+			AutoMappedFields: types.SetNull(autoFieldType),
+		}
+		require.False(t, splitAutoMappedFields(ctx, &data, prior).HasError())
+		assert.Equal(t, read().Fields, data.Fields)
+		assert.True(t, data.AutoMap.IsNull())
+		assert.True(t, data.AutoMappedFields.IsNull())
+	})
+}
+
+func TestSyncResourceAutoMap(t *testing.T) {
+	factories, server := FakeProtoV6ProviderFactories(t)
+	sourceID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "warehouse", "type": "postgresql"})
+	targetID := server.Put(fakeapi.Connections, fakeapi.Object{"name": "crm", "type": "salesforce"})
+	modelID := server.Put(fakeapi.Models, fakeapi.Object{
+		"name":          "contacts",
+		"connection_id": sourceID,
+		"fields": []any{
+			fakeapi.Object{"name": "id", "type": "string"},
+			fakeapi.Object{"name": "email", "type": "string"},
+			fakeapi.Object{"name": "firstname", "type": "string"},
+			fakeapi.Object{"name": "phone", "type": "string"},
+		},
+	})
+	server.SetTargetFields(targetID, "Contact",
+		fakeapi.Object{"id": "Email", "name": "Email"},
+		fakeapi.Object{"id": "FirstName", "name": "FirstName"},
+	)
+
+	config := func(fields string) string {
+		return fmt.Sprintf(`
+resource "polytomic_sync" "test" {
+  name   = "TestSyncResourceAutoMap"
+  mode   = "replace"
+  active = false
+  schedule = {
+    frequency = "manual"
+  }
+  target = {
+    connection_id = %q
+    object        = "Contact"
+  }
+  auto_map = {
+    model_id = %q
+    strategy = "case_insensitive"
+  }
+%s
+}
+`, targetID, modelID, fields)
+	}
+	autoMapped := func(mappings map[string]string) knownvalue.Check {
+		checks := []knownvalue.Check{}
+		for target, field := range mappings {
+			checks = append(checks, knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"source": knownvalue.ObjectExact(map[string]knownvalue.Check{
+					"model_id": knownvalue.StringExact(modelID),
+					"field":    knownvalue.StringExact(field),
+				}),
+				"target": knownvalue.StringExact(target),
+			}))
+		}
+		return knownvalue.SetExact(checks)
+	}
+	// synced checks the fields the sync was saved with, by target.
+	synced := func(want map[string]string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			sync, ok := server.Get(fakeapi.Syncs, s.RootModule().Resources["polytomic_sync.test"].Primary.ID)
+			if !ok {
+				return fmt.Errorf("sync not found")
+			}
+			got := map[string]string{}
+			for _, item := range sync["fields"].([]any) {
+				field := item.(fakeapi.Object)
+				got[field["target"].(string)] = field["source"].(fakeapi.Object)["field"].(string)
+			}
+			if !maps.Equal(got, want) {
+				return fmt.Errorf("expected sync fields %v, got %v", want, got)
+			}
+			return nil
+		}
+	}
+	idToEmail := `
+  fields = [{
+    source = {
+      model_id = "` + modelID + `"
+      field    = "id"
+    }
+    target = "Email"
+  }]
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_sync.test",
+						tfjsonpath.New("auto_mapped_fields"),
+						autoMapped(map[string]string{"Email": "email", "FirstName": "firstname"}),
+					),
+					statecheck.ExpectKnownValue(
+						"polytomic_sync.test",
+						tfjsonpath.New("fields"),
+						knownvalue.Null(),
+					),
+				},
+				Check: synced(map[string]string{"Email": "email", "FirstName": "firstname"}),
+			},
+			{
+				// the auto-mapped fields read back don't change the plan
+				Config: config(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				// an explicit field takes precedence over a match
+				Config: config(idToEmail),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_sync.test",
+						tfjsonpath.New("auto_mapped_fields"),
+						autoMapped(map[string]string{"FirstName": "firstname"}),
+					),
+				},
+				Check: synced(map[string]string{"Email": "id", "FirstName": "firstname"}),
+			},
+			{
+				// a new target field which matches is planned
+				PreConfig: func() {
+					server.SetTargetFields(targetID, "Contact",
+						fakeapi.Object{"id": "Email", "name": "Email"},
+						fakeapi.Object{"id": "FirstName", "name": "FirstName"},
+						fakeapi.Object{"id": "Phone", "name": "Phone"},
+					)
+				},
+				Config: config(idToEmail),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("polytomic_sync.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"polytomic_sync.test",
+						tfjsonpath.New("auto_mapped_fields"),
+						autoMapped(map[string]string{"FirstName": "firstname", "Phone": "phone"}),
+					),
+				},
+				Check: synced(map[string]string{"Email": "id", "FirstName": "firstname", "Phone": "phone"}),
+			},
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/polytomic-go"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+)
+
+// enrichmentModelIDs returns the models of modelIDs other than the identity
+// model, sorted. A sync reads its records from the identity model; fields of
+// enrichment models are joined to those records through model relations.
+func enrichmentModelIDs(identityModelID string, modelIDs []string) []string {
+	enrichment := []string{}
+	for _, id := range modelIDs {
+		if id != identityModelID && !slices.Contains(enrichment, id) {
+			enrichment = append(enrichment, id)
+		}
+	}
+	slices.Sort(enrichment)
+	return enrichment
+}
+
+// reachableModels returns the models which can be reached from a model by
+// following relations, which related returns for each model.
+func reachableModels(from string, related func(modelID string) ([]string, error)) (map[string]bool, error) {
+	reached := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		to, err := related(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, next := range to {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return reached, nil
+}
+
+// sourceModelID returns the model_id of a field's source, and whether it's
+// known.
+func sourceModelID(obj types.Object) (string, bool) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return "", !obj.IsUnknown()
+	}
+	source, ok := obj.Attributes()["source"].(types.Object)
+	if !ok || source.IsNull() || source.IsUnknown() {
+		return "", ok && !source.IsUnknown()
+	}
+	modelID, ok := source.Attributes()["model_id"].(types.String)
+	if !ok || modelID.IsUnknown() {
+		return "", false
+	}
+	return modelID.ValueString(), true
+}
+
+// mappedModelIDs returns the models data's fields and auto_map map fields
+// from, and whether they're all known.
+func mappedModelIDs(data syncResourceResourceData) ([]string, bool) {
+	var ids []string
+	for _, set := range []types.Set{data.Fields, data.AutoMappedFields} {
+		if set.IsUnknown() {
+			return nil, false
+		}
+		for _, elem := range set.Elements() {
+			obj, ok := elem.(types.Object)
+			if !ok {
+				return nil, false
+			}
+			id, known := sourceModelID(obj)
+			if !known {
+				return nil, false
+			}
+			if id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, true
+}
+
+// planEnrichment warns about enrichment models which aren't related to the
+// sync's identity model, since their fields can't be joined to the sync's
+// records. It's a warning rather than an error because the relation may be
+// created by the same apply, e.g. by a polytomic_model_relation in another
+// module.
+func (r *syncResource) planEnrichment(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// resp.Plan has auto_map resolved
+	var plan syncResourceResourceData
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	identityModelID, known := sourceModelID(plan.Identity)
+	if !known || identityModelID == "" {
+		return
+	}
+	modelIDs, known := mappedModelIDs(plan)
+	if !known {
+		return
+	}
+	enrichment := enrichmentModelIDs(identityModelID, modelIDs)
+	if len(enrichment) == 0 {
+		return
+	}
+
+	// organization is unknown when it isn't configured and the sync is
+	// being created, so its models are read with the provider's default
+	// client. Partner and deployment keys have no default, and the check
+	// is left to the next plan.
+	client, err := r.provider.Client(ctx, plan.Organization.ValueString())
+	if err != nil {
+		if !plan.Organization.IsUnknown() {
+			resp.Diagnostics.AddError("Error getting client", err.Error())
+		}
+		return
+	}
+	reached, err := reachableModels(identityModelID, func(modelID string) ([]string, error) {
+		model, err := client.Models.Get(ctx, modelID, &polytomic.ModelsGetRequest{})
+		if err != nil {
+			return nil, err
+		}
+		var to []string
+		for _, relation := range model.Data.Relations {
+			if relation != nil && relation.To != nil {
+				to = append(to, relation.To.ModelId)
+			}
+		}
+		return to, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(providerclient.ErrorSummary, fmt.Sprintf("Error reading model relations: %s", err))
+		return
+	}
+	for _, id := range enrichment {
+		if !reached[id] {
+			resp.Diagnostics.AddAttributeWarning(path.Root("fields"), "Enrichment model not related",
+				fmt.Sprintf("Fields are mapped from model %s, which isn't related to the identity model %s. "+
+					"Relate the models with polytomic_model_relation or the model's relations; if the relation "+
+					"is created in the same apply, add it to the sync's depends_on so it's created first.", id, identityModelID))
+		}
+	}
+}
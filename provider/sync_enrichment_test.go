@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
+	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichmentModelIDs(t *testing.T) {
+	assert.Equal(t, []string{"accounts", "plans"},
+		enrichmentModelIDs("users", []string{"users", "plans", "accounts", "users", "plans"}))
+	assert.Empty(t, enrichmentModelIDs("users", []string{"users"}))
+}
+
+func TestReachableModels(t *testing.T) {
+	relations := map[string][]string{
+		"users":    {"accounts"},
+		"accounts": {"plans", "users"},
+		"orders":   {"users"},
+	}
+	related := func(id string) ([]string, error) { return relations[id], nil }
+
+	reached, err := reachableModels("users", related)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"users": true, "accounts": true, "plans": true}, reached)
+
+	_, err = reachableModels("users", func(string) ([]string, error) { return nil, errors.New("boom") })
+	assert.EqualError(t, err, "boom")
+}
+
+func TestPlanEnrichment(t *testing.T) {
+	ctx := t.Context()
+	_, server := FakeProtoV6ProviderFactories(t)
+	accounts := server.Put(fakeapi.Models, fakeapi.Object{"name": "accounts", "connection_id": "warehouse"})
+	orders := server.Put(fakeapi.Models, fakeapi.Object{"name": "orders", "connection_id": "warehouse"})
+	users := server.Put(fakeapi.Models, fakeapi.Object{
+		"name":          "users",
+		"connection_id": "warehouse",
+		"relations": []any{
+			fakeapi.Object{"from": "account_id", "to": fakeapi.Object{"model_id": accounts, "field": "id"}},
+		},
+	})
+
+	p, err := providerclient.NewClientProvider(providerclient.OptionsFromEnv())
+	require.NoError(t, err)
+	r := &syncResource{provider: p}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	// object returns a value of typ with vals, and null attributes otherwise.
+	object := func(typ tftypes.Type, vals map[string]tftypes.Value) tftypes.Value {
+		attrs := map[string]tftypes.Value{}
+		for name, attrType := range typ.(tftypes.Object).AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+			if v, ok := vals[name]; ok {
+				attrs[name] = v
+			}
+		}
+		return tftypes.NewValue(typ, attrs)
+	}
+	syncType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	identityType := syncType.AttributeTypes["identity"].(tftypes.Object)
+	fieldsType := syncType.AttributeTypes["fields"].(tftypes.Set)
+	source := func(typ tftypes.Type, modelID string) tftypes.Value {
+		return object(typ, map[string]tftypes.Value{
+			"model_id": tftypes.NewValue(tftypes.String, modelID),
+			"field":    tftypes.NewValue(tftypes.String, "id"),
+		})
+	}
+	// warnings plans a sync whose identity is from users, with a field from
+	// each of models, and returns the warnings about unrelated models.
+	warnings := func(organization tftypes.Value, models ...string) []string {
+		fields := make([]tftypes.Value, len(models))
+		for i, id := range models {
+			fields[i] = object(fieldsType.ElementType, map[string]tftypes.Value{
+				"source": source(fieldsType.ElementType.(tftypes.Object).AttributeTypes["source"], id),
+				"target": tftypes.NewValue(tftypes.String, "field_"+id),
+			})
+		}
+		raw := object(syncType, map[string]tftypes.Value{
+			"organization": organization,
+			"identity": object(identityType, map[string]tftypes.Value{
+				"source":   source(identityType.AttributeTypes["source"], users),
+				"target":   tftypes.NewValue(tftypes.String, "id"),
+				"function": tftypes.NewValue(tftypes.String, "Equality"),
+			}),
+			"fields": tftypes.NewValue(fieldsType, fields),
+		})
+		resp := resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw}}
+		r.planEnrichment(ctx, resource.ModifyPlanRequest{}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var unrelated []string
+		for _, d := range resp.Diagnostics.Warnings() {
+			for _, id := range []string{accounts, orders} {
+				if strings.Contains(d.Detail(), "model "+id) {
+					unrelated = append(unrelated, id)
+				}
+			}
+		}
+		return unrelated
+	}
+
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	// a new sync's organization is unknown unless it's configured
+	assert.Equal(t, []string{orders}, warnings(unknown, users, accounts, orders))
+	assert.Empty(t, warnings(unknown, users, accounts))
+	assert.Equal(t, []string{orders}, warnings(tftypes.NewValue(tftypes.String, server.Organization), users, orders))
+}
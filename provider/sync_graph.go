@@ -0,0 +1,373 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/polytomic/polytomic-go"
+	ptclient "github.com/polytomic/polytomic-go/client"
+)
+
+const (
+	syncGraphNodeSync     = "sync"
+	syncGraphNodeBulkSync = "bulk_sync"
+)
+
+// syncGraphNode is a model sync or bulk sync participating in the run-after
+// dependency graph.
+type syncGraphNode struct {
+	ID     string
+	Name   string
+	Type   string
+	Active bool
+}
+
+// syncGraphEdge points from an upstream sync to the sync that runs after it.
+type syncGraphEdge struct {
+	From string
+	To   string
+}
+
+// syncGraph is the run-after dependency graph for an organization. Edges are
+// stored keyed by the downstream sync, mirroring how the API models
+// `schedule.run_after`.
+type syncGraph struct {
+	nodes     map[string]syncGraphNode
+	upstreams map[string][]string
+}
+
+func newSyncGraph() *syncGraph {
+	return &syncGraph{
+		nodes:     map[string]syncGraphNode{},
+		upstreams: map[string][]string{},
+	}
+}
+
+// loadSyncGraph builds the run-after graph from every model sync and bulk
+// sync visible to the client.
+func loadSyncGraph(ctx context.Context, client *ptclient.Client) (*syncGraph, error) {
+	g := newSyncGraph()
+
+	bulkSyncs, err := client.BulkSync.List(ctx, &polytomic.BulkSyncListRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("listing bulk syncs: %w", err)
+	}
+	for _, bulk := range bulkSyncs.Data {
+		g.addNode(syncGraphNode{
+			ID:     pointer.GetString(bulk.Id),
+			Name:   pointer.GetString(bulk.Name),
+			Type:   syncGraphNodeBulkSync,
+			Active: pointer.GetBool(bulk.Active),
+		})
+	}
+
+	syncs, err := client.ModelSync.List(ctx, &polytomic.ModelSyncListRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("listing syncs: %w", err)
+	}
+	for _, sync := range syncs.Data {
+		id := pointer.GetString(sync.Id)
+		g.addNode(syncGraphNode{
+			ID:     id,
+			Name:   pointer.GetString(sync.Name),
+			Type:   syncGraphNodeSync,
+			Active: pointer.GetBool(sync.Active),
+		})
+		if sync.Schedule != nil && sync.Schedule.RunAfter != nil {
+			var upstreams []string
+			upstreams = append(upstreams, sync.Schedule.RunAfter.SyncIds...)
+			upstreams = append(upstreams, sync.Schedule.RunAfter.BulkSyncIds...)
+			g.setUpstreams(id, upstreams)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *syncGraph) addNode(n syncGraphNode) {
+	g.nodes[n.ID] = n
+}
+
+// setUpstreams replaces the run-after dependencies of id.
+func (g *syncGraph) setUpstreams(id string, upstreams []string) {
+	if len(upstreams) == 0 {
+		delete(g.upstreams, id)
+		return
+	}
+	deduped := make([]string, 0, len(upstreams))
+	seen := map[string]bool{}
+	for _, u := range upstreams {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		deduped = append(deduped, u)
+	}
+	sort.Strings(deduped)
+	g.upstreams[id] = deduped
+}
+
+// sortedNodes returns the graph's nodes ordered by ID.
+func (g *syncGraph) sortedNodes() []syncGraphNode {
+	nodes := make([]syncGraphNode, 0, len(g.nodes))
+	for _, id := range slices.Sorted(maps.Keys(g.nodes)) {
+		nodes = append(nodes, g.nodes[id])
+	}
+	return nodes
+}
+
+// edges returns every upstream -> downstream edge, ordered by upstream then
+// downstream ID.
+func (g *syncGraph) edges() []syncGraphEdge {
+	var edges []syncGraphEdge
+	for to, ups := range g.upstreams {
+		for _, from := range ups {
+			edges = append(edges, syncGraphEdge{From: from, To: to})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// downstreams returns the IDs of syncs configured to run after id.
+func (g *syncGraph) downstreams(id string) []string {
+	var result []string
+	for to, ups := range g.upstreams {
+		for _, from := range ups {
+			if from == id {
+				result = append(result, to)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// roots returns the IDs of syncs that start a run-after chain: they have at
+// least one downstream sync and do not run after anything themselves.
+func (g *syncGraph) roots() []string {
+	hasDownstream := map[string]bool{}
+	for _, e := range g.edges() {
+		hasDownstream[e.From] = true
+	}
+	var roots []string
+	for _, id := range slices.Sorted(maps.Keys(hasDownstream)) {
+		if _, ok := g.nodes[id]; ok && len(g.upstreams[id]) == 0 {
+			roots = append(roots, id)
+		}
+	}
+	return roots
+}
+
+// missing returns referenced upstream IDs which are not nodes in the graph,
+// i.e. syncs which have been deleted.
+func (g *syncGraph) missing() []string {
+	seen := map[string]bool{}
+	for _, e := range g.edges() {
+		if _, ok := g.nodes[e.From]; !ok {
+			seen[e.From] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// cycles returns each set of syncs which transitively run after themselves.
+// Cycles are found as the strongly connected components of the graph (using
+// Tarjan's algorithm); each is returned as a sorted list of IDs.
+func (g *syncGraph) cycles() [][]string {
+	var (
+		index   = 0
+		stack   []string
+		onStack = map[string]bool{}
+		indices = map[string]int{}
+		lowlink = map[string]int{}
+		result  [][]string
+	)
+
+	var connect func(id string)
+	connect = func(id string) {
+		indices[id] = index
+		lowlink[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, up := range g.upstreams[id] {
+			if _, visited := indices[up]; !visited {
+				connect(up)
+				lowlink[id] = min(lowlink[id], lowlink[up])
+			} else if onStack[up] {
+				lowlink[id] = min(lowlink[id], indices[up])
+			}
+		}
+
+		if lowlink[id] != indices[id] {
+			return
+		}
+		var component []string
+		for {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[n] = false
+			component = append(component, n)
+			if n == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.runsAfter(id, id) {
+			sort.Strings(component)
+			result = append(result, component)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(g.upstreams)) {
+		if _, visited := indices[id]; !visited {
+			connect(id)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i][0] < result[j][0]
+	})
+	return result
+}
+
+// cycleContaining returns the cycle id participates in, or nil.
+func (g *syncGraph) cycleContaining(id string) []string {
+	for _, cycle := range g.cycles() {
+		for _, n := range cycle {
+			if n == id {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// runsAfter reports whether id directly runs after upstream.
+func (g *syncGraph) runsAfter(id, upstream string) bool {
+	for _, u := range g.upstreams[id] {
+		if u == upstream {
+			return true
+		}
+	}
+	return false
+}
+
+// describe renders a node for use in diagnostics.
+func (g *syncGraph) describe(id string) string {
+	n, ok := g.nodes[id]
+	if !ok || n.Name == "" {
+		return id
+	}
+	return fmt.Sprintf("%q (%s)", n.Name, id)
+}
+
+// describeAll renders a list of nodes for use in diagnostics.
+func (g *syncGraph) describeAll(ids []string) string {
+	described := make([]string, 0, len(ids))
+	for _, id := range ids {
+		described = append(described, g.describe(id))
+	}
+	return strings.Join(described, ", ")
+}
+
+// activeDownstreams returns the active syncs which run after id.
+func (g *syncGraph) activeDownstreams(id string) []string {
+	var result []string
+	for _, d := range g.downstreams(id) {
+		if n, ok := g.nodes[d]; ok && n.Active {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// knownStrings returns the elements of a string set, or false if the set or
+// any of its elements are not yet known.
+func knownStrings(set types.Set) ([]string, bool) {
+	if set.IsUnknown() {
+		return nil, false
+	}
+	result := make([]string, 0, len(set.Elements()))
+	for _, e := range set.Elements() {
+		s, ok := e.(types.String)
+		if !ok || s.IsUnknown() {
+			return nil, false
+		}
+		result = append(result, s.ValueString())
+	}
+	return result, true
+}
+
+// validateRunAfter checks the planned run_after dependencies for the sync id
+// (empty when the sync is being created). Upstreams must exist and be of the
+// referenced type. Inactive upstreams of an active sync and cycles through id
+// are warnings, since the graph is the organization's current one and the
+// same apply may activate the upstream or remove an edge of the cycle. The
+// graph is updated with the planned dependencies.
+func (g *syncGraph) validateRunAfter(id string, active bool, syncIDs, bulkSyncIDs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	runAfter := path.Root("schedule").AtName("run_after")
+
+	check := func(attr string, expected string, ids []string) {
+		for _, upstream := range ids {
+			node, ok := g.nodes[upstream]
+			switch {
+			case !ok:
+				diags.AddAttributeError(runAfter.AtName(attr), "Invalid run_after dependency",
+					fmt.Sprintf("%s does not exist; it may have been deleted.", upstream))
+			case node.Type != expected:
+				diags.AddAttributeError(runAfter.AtName(attr), "Invalid run_after dependency",
+					fmt.Sprintf("%s is a %s, not a %s.", g.describe(upstream), strings.ReplaceAll(node.Type, "_", " "), strings.ReplaceAll(expected, "_", " ")))
+			case active && !node.Active:
+				diags.AddAttributeWarning(runAfter.AtName(attr), "Inactive run_after dependency",
+					fmt.Sprintf("%s is inactive, so this sync won't be triggered unless it's activated.", g.describe(upstream)))
+			}
+		}
+	}
+	check("sync_ids", syncGraphNodeSync, syncIDs)
+	check("bulk_sync_ids", syncGraphNodeBulkSync, bulkSyncIDs)
+
+	if id == "" {
+		// A sync being created can't be referenced by anything else yet.
+		return diags
+	}
+
+	var upstreams []string
+	upstreams = append(upstreams, syncIDs...)
+	upstreams = append(upstreams, bulkSyncIDs...)
+	g.setUpstreams(id, upstreams)
+	if cycle := g.cycleContaining(id); cycle != nil {
+		diags.AddAttributeWarning(runAfter, "Run-after cycle",
+			fmt.Sprintf("This sync would form a run-after cycle with %s; none of them will be triggered unless "+
+				"one of their run_after dependencies is removed.", g.describeAll(cycle)))
+	}
+
+	return diags
+}
+
+// validateDependents warns when the sync id is deactivated or deleted while
+// active syncs still run after it.
+func (g *syncGraph) validateDependents(id string, action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if dependents := g.activeDownstreams(id); len(dependents) > 0 {
+		diags.AddWarning("Sync has run-after dependents",
+			fmt.Sprintf("%s is being %s, but %s run after it and will no longer be triggered.", g.describe(id), action, g.describeAll(dependents)))
+	}
+	return diags
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSyncGraph(active map[string]bool, upstreams map[string][]string) *syncGraph {
+	g := newSyncGraph()
+	for id, a := range active {
+		typ := syncGraphNodeSync
+		if id[0] == 'b' {
+			typ = syncGraphNodeBulkSync
+		}
+		g.addNode(syncGraphNode{ID: id, Name: id, Type: typ, Active: a})
+	}
+	for id, ups := range upstreams {
+		g.setUpstreams(id, ups)
+	}
+	return g
+}
+
+func TestSyncGraph(t *testing.T) {
+	tests := map[string]struct {
+		active    map[string]bool
+		upstreams map[string][]string
+		roots     []string
+		cycles    [][]string
+		missing   []string
+	}{
+		"no dependencies": {
+			active: map[string]bool{"a": true, "b1": true},
+		},
+		"chain": {
+			active:    map[string]bool{"a": true, "b1": true, "c": true},
+			upstreams: map[string][]string{"a": {"b1"}, "c": {"a"}},
+			roots:     []string{"b1"},
+		},
+		"cycle": {
+			active:    map[string]bool{"a": true, "c": true, "d": true},
+			upstreams: map[string][]string{"a": {"c"}, "c": {"d"}, "d": {"a"}},
+			cycles:    [][]string{{"a", "c", "d"}},
+		},
+		"self reference": {
+			active:    map[string]bool{"a": true},
+			upstreams: map[string][]string{"a": {"a"}},
+			cycles:    [][]string{{"a"}},
+		},
+		"cycle with tail": {
+			active:    map[string]bool{"a": true, "c": true, "d": true, "b1": true},
+			upstreams: map[string][]string{"a": {"b1", "c"}, "c": {"a"}, "d": {"c"}},
+			roots:     []string{"b1"},
+			cycles:    [][]string{{"a", "c"}},
+		},
+		"deleted upstream": {
+			active:    map[string]bool{"a": true},
+			upstreams: map[string][]string{"a": {"gone"}},
+			missing:   []string{"gone"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := testSyncGraph(tc.active, tc.upstreams)
+			assert.Equal(t, tc.roots, g.roots())
+			assert.Equal(t, tc.cycles, g.cycles())
+			assert.Equal(t, tc.missing, g.missing())
+		})
+	}
+}
+
+func TestSyncGraphValidateRunAfter(t *testing.T) {
+	tests := map[string]struct {
+		id          string
+		active      bool
+		syncIDs     []string
+		bulkSyncIDs []string
+		errors      int
+		warnings    int
+	}{
+		"valid": {
+			id: "a", active: true, syncIDs: []string{"c"}, bulkSyncIDs: []string{"b1"},
+		},
+		"create": {
+			active: true, syncIDs: []string{"a"},
+		},
+		"deleted upstream": {
+			id: "a", active: true, syncIDs: []string{"gone"}, errors: 1,
+		},
+		"inactive upstream": {
+			id: "a", active: true, syncIDs: []string{"inactive"}, warnings: 1,
+		},
+		"inactive upstream for inactive sync": {
+			id: "a", active: false, syncIDs: []string{"inactive"},
+		},
+		"bulk sync in sync_ids": {
+			id: "a", active: true, syncIDs: []string{"b1"}, errors: 1,
+		},
+		"sync in bulk_sync_ids": {
+			id: "a", active: true, bulkSyncIDs: []string{"c"}, errors: 1,
+		},
+		"cycle": {
+			id: "c", active: true, syncIDs: []string{"a"}, warnings: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := testSyncGraph(
+				map[string]bool{"a": true, "c": true, "inactive": false, "b1": true},
+				map[string][]string{"a": {"c"}},
+			)
+			diags := g.validateRunAfter(tc.id, tc.active, tc.syncIDs, tc.bulkSyncIDs)
+			assert.Equal(t, tc.errors, diags.ErrorsCount(), diags)
+			assert.Equal(t, tc.warnings, diags.WarningsCount(), diags)
+		})
+	}
+}
+
+func TestSyncGraphValidateDependents(t *testing.T) {
+	g := testSyncGraph(
+		map[string]bool{"a": true, "c": false, "b1": true},
+		map[string][]string{"a": {"b1"}, "c": {"a"}},
+	)
+
+	// a's only dependent is inactive.
+	assert.Empty(t, g.validateDependents("a", "deleted"))
+
+	diags := g.validateDependents("b1", "deactivated")
+	assert.Equal(t, 1, diags.WarningsCount())
+	assert.Contains(t, diags[0].Detail(), `"a" (a)`)
+}
@@ -3,10 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/polytomic/terraform-provider-polytomic/internal/cassette"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -93,19 +95,41 @@ func getSharedPGContainer(t *testing.T) pgContainer {
 // testPostgresConfig returns Postgres connection details for acceptance tests.
 // It first checks for POLYTOMIC_TEST_PG_* environment variables and uses those
 // if present. Otherwise it starts a shared Postgres testcontainer.
+//
+// Replayed tests use the connection details they were recorded with, without
+// a database.
 func testPostgresConfig(t *testing.T) postgresTestConfig {
 	t.Helper()
 
-	if cfg, ok := testPostgresConfigFromEnv(t); ok {
-		return cfg
+	c := TestCassette(t)
+	if !c.Recording() {
+		port, err := strconv.Atoi(c.Value("postgres_port", nil))
+		if err != nil {
+			t.Fatalf("invalid recorded postgres port: %v", err)
+		}
+		return postgresTestConfig{
+			Host:     c.Value("postgres_host", nil),
+			Database: c.Value("postgres_database", nil),
+			Username: c.Value("postgres_username", nil),
+			Password: cassette.Redacted,
+			Port:     port,
+		}
 	}
 
-	ctr := getSharedPGContainer(t)
-	return postgresTestConfig{
-		Host:     ctr.host,
-		Database: testPGDatabase,
-		Username: testPGUser,
-		Password: testPGPassword,
-		Port:     ctr.port,
+	cfg, ok := testPostgresConfigFromEnv(t)
+	if !ok {
+		ctr := getSharedPGContainer(t)
+		cfg = postgresTestConfig{
+			Host:     ctr.host,
+			Database: testPGDatabase,
+			Username: testPGUser,
+			Password: testPGPassword,
+			Port:     ctr.port,
+		}
 	}
+	c.Value("postgres_host", func() string { return cfg.Host })
+	c.Value("postgres_database", func() string { return cfg.Database })
+	c.Value("postgres_username", func() string { return cfg.Username })
+	c.Value("postgres_port", func() string { return strconv.Itoa(cfg.Port) })
+	return cfg
 }
@@ -1,18 +1,25 @@
 package provider
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"html/template"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	_ "github.com/lib/pq"
+	"github.com/polytomic/terraform-provider-polytomic/internal/cassette"
+	"github.com/polytomic/terraform-provider-polytomic/internal/fakeapi"
 	"github.com/polytomic/terraform-provider-polytomic/internal/providerclient"
+	"github.com/polytomic/terraform-provider-polytomic/provider/internal/connections"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,14 +31,31 @@ var TestAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	Name: providerserver.NewProtocol6WithError(New("test")()),
 }
 
-// TestAccPreCheck performs pre-checks for acceptance testing
+// FakeProtoV6ProviderFactories starts a fake Polytomic API and returns provider
+// factories which use it, so resource tests can run with plain go test,
+// without TF_ACC or a live API. The fake is authenticated with its API key;
+// clients created with testClient use it too.
+func FakeProtoV6ProviderFactories(t *testing.T) (map[string]func() (tfprotov6.ProviderServer, error), *fakeapi.Server) {
+	t.Helper()
+
+	server := fakeapi.New(t)
+	t.Setenv(providerclient.PolytomicDeploymentURL, server.URL)
+	t.Setenv(providerclient.PolytomicAPIKey, server.APIKey)
+	t.Setenv(providerclient.PolytomicDeploymentKey, "")
+	t.Setenv(providerclient.PolytomicPartnerKey, "")
+	return TestAccProtoV6ProviderFactories, server
+}
+
+// TestAccPreCheck performs pre-checks for acceptance testing, and records or
+// replays the test's API requests; see TestCassette.
 func TestAccPreCheck(t *testing.T) {
-	if os.Getenv(providerclient.PolytomicAPIKey) == "" && os.Getenv(providerclient.PolytomicDeploymentKey) == "" {
-		t.Fatalf("%s or %s must be set for acceptance testing", providerclient.PolytomicAPIKey, providerclient.PolytomicDeploymentKey)
+	TestCassette(t)
+	if !cassette.Recording() {
+		return
 	}
 
-	if os.Getenv(providerclient.PolytomicDeploymentURL) == "" {
-		t.Fatalf("%s must be set for acceptance testing", providerclient.PolytomicDeploymentURL)
+	if os.Getenv(providerclient.PolytomicAPIKey) == "" && os.Getenv(providerclient.PolytomicDeploymentKey) == "" {
+		t.Fatalf("%s or %s must be set for acceptance testing", providerclient.PolytomicAPIKey, providerclient.PolytomicDeploymentKey)
 	}
 }
 
@@ -43,9 +67,90 @@ func GetTestAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderS
 // APIKey returns true if the test is being run using an API key, rather than a
 // deployment key.
 func APIKey() bool {
+	if !cassette.Recording() {
+		// Replayed tests use an API key unless a deployment key is set.
+		return os.Getenv(providerclient.PolytomicDeploymentKey) == ""
+	}
 	return os.Getenv(providerclient.PolytomicAPIKey) != "" && os.Getenv(providerclient.PolytomicDeploymentKey) == ""
 }
 
+var (
+	testCassettesMu sync.Mutex
+	testCassettes   = map[*testing.T]*cassette.Cassette{}
+)
+
+// TestCassette returns the cassette which records or replays the API requests
+// made by t. The cassette is served at a local URL, which t's providers and
+// clients use as their deployment URL until t completes. Cassettes are
+// replayed unless POLYTOMIC_TEST_RECORD is set; replayed tests are configured
+// with placeholder credentials, so they run without a Polytomic deployment.
+func TestCassette(t *testing.T) *cassette.Cassette {
+	t.Helper()
+
+	testCassettesMu.Lock()
+	defer testCassettesMu.Unlock()
+	if c, ok := testCassettes[t]; ok {
+		return c
+	}
+
+	upstream := os.Getenv(providerclient.PolytomicDeploymentURL)
+	if cassette.Recording() && upstream == "" {
+		t.Fatalf("%s must be set for acceptance testing", providerclient.PolytomicDeploymentURL)
+	}
+	if !cassette.Recording() && APIKey() && os.Getenv(providerclient.PolytomicAPIKey) == "" {
+		t.Setenv(providerclient.PolytomicAPIKey, "replay")
+	}
+
+	c := cassette.New(t, t.Name(), cassette.Options{
+		APIKey:    APIKey(),
+		Sensitive: func(field string) bool { return sensitiveFields()[field] },
+		Secrets: []string{
+			os.Getenv(providerclient.PolytomicAPIKey),
+			os.Getenv(providerclient.PolytomicDeploymentKey),
+			os.Getenv(providerclient.PolytomicPartnerKey),
+		},
+	})
+	testCassettes[t] = c
+	t.Setenv(providerclient.PolytomicDeploymentURL, c.Serve(upstream))
+	t.Cleanup(func() {
+		testCassettesMu.Lock()
+		defer testCassettesMu.Unlock()
+		delete(testCassettes, t)
+	})
+	return c
+}
+
+// sensitiveFields returns the fields whose values are redacted from
+// cassettes: credentials, and the sensitive configuration of each connection
+// type.
+var sensitiveFields = sync.OnceValue(func() map[string]bool {
+	fields := map[string]bool{
+		"api_key":            true,
+		"deployment_api_key": true,
+		"partner_key":        true,
+		"password":           true,
+	}
+	for _, r := range connections.Resources {
+		var resp resource.SchemaResponse
+		r().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+		if conf, ok := resp.Schema.Attributes["configuration"].(schema.SingleNestedAttribute); ok {
+			addSensitiveFields(fields, conf.Attributes)
+		}
+	}
+	return fields
+})
+
+func addSensitiveFields(fields map[string]bool, attrs map[string]schema.Attribute) {
+	for name, attr := range attrs {
+		if attr.IsSensitive() {
+			fields[name] = true
+		}
+		if nested, ok := attr.(schema.SingleNestedAttribute); ok {
+			addSensitiveFields(fields, nested.Attributes)
+		}
+	}
+}
+
 type postgresTestConfig struct {
 	Host     string
 	Database string
@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"unicode"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -108,3 +113,29 @@ func attrValueInt(v any) int {
 	}
 	return 0
 }
+
+// planUnmanaged plans an attribute which is configured by separate resources
+// when the manage attribute is false, such as a model's relations. The
+// attribute is then planned as null, so it isn't compared to what those
+// resources configure, and setting it or any of the conflicting attributes is
+// an error; hint says how to configure it instead.
+func planUnmanaged(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, manage, attribute string, null attr.Value, conflicting []string, summary, hint string) {
+	var managed types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(manage), &managed)...)
+	if resp.Diagnostics.HasError() || managed.IsUnknown() || managed.ValueBool() {
+		return
+	}
+
+	for _, name := range append([]string{attribute}, conflicting...) {
+		v, _, err := tftypes.WalkAttributePath(req.Config.Raw, tftypes.NewAttributePath().WithAttributeName(name))
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading configuration", err.Error())
+			return
+		}
+		if configured, ok := v.(tftypes.Value); ok && !configured.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(name), summary,
+				fmt.Sprintf("%s can't be set when %s is false; %s", name, manage, hint))
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), null)...)
+}
@@ -11,58 +11,75 @@ import (
 
 // Test connection round-trip
 func TestAccRoundTrip_Connection(t *testing.T) {
+	connName := provider.ValidName(provider.ToSnakeCase(fmt.Sprintf("test-%s", provider.TestUUID(t, "name"))))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 PreCheck(t),
+		ProtoV6ProviderFactories: provider.GetTestAccProtoV6ProviderFactories(),
+		Steps:                    connectionRoundTripSteps(t, connName, provider.APIKey()),
+	})
+}
+
+// Test connection round-trip against a fake Polytomic API
+func TestAccRoundTrip_ConnectionFakeAPI(t *testing.T) {
 	connName := provider.ValidName(provider.ToSnakeCase(fmt.Sprintf("test-%s", uuid.NewString())))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 FakePreCheck(t),
+		ProtoV6ProviderFactories: FakeProviderFactories(t),
+		Steps:                    connectionRoundTripSteps(t, connName, true),
+	})
+}
+
+// connectionRoundTripSteps creates a connection named connName, and checks
+// that it's exported and imported unchanged. Without an API key, the
+// connection is created in a new organization.
+func connectionRoundTripSteps(t *testing.T, connName string, apiKey bool) []resource.TestStep {
 	var testOrgName string
-	if !provider.APIKey() {
+	if !apiKey {
 		testOrgName = connName
 	}
 	resourceName := fmt.Sprintf("polytomic_csv_connection.%s", connName)
 	tfConfig := provider.TestCaseTfResource(t, connectionResourceTemplate, provider.TestCaseTfArgs{
 		Name:   connName,
-		APIKey: provider.APIKey(),
+		APIKey: apiKey,
 	})
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 PreCheck(t),
-		ProtoV6ProviderFactories: provider.GetTestAccProtoV6ProviderFactories(),
-		Steps: []resource.TestStep{
-			// Step 1: Create the connection
-			{
-				Config: tfConfig,
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "name", connName),
-					resource.TestCheckResourceAttrSet(resourceName, "id"),
-					ImportAndValidate(
-						t.Context(),
-						[]string{resourceName},
-						RoundTripOptions{
-							ValidateSensitive: false,
-							IgnoreFields: []string{
-								"created_at",
-								"updated_at",
-							},
-							OrgName: testOrgName,
+	return []resource.TestStep{
+		// Step 1: Create the connection
+		{
+			Config: tfConfig,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(resourceName, "name", connName),
+				resource.TestCheckResourceAttrSet(resourceName, "id"),
+				ImportAndValidate(
+					t.Context(),
+					[]string{resourceName},
+					RoundTripOptions{
+						ValidateSensitive: false,
+						IgnoreFields: []string{
+							"created_at",
+							"updated_at",
 						},
-					)),
-			},
-			// Step 2: Test refreshing state via reading
-			{
-				RefreshState: true,
+						OrgName: testOrgName,
+					},
+				)),
+		},
+		// Step 2: Test refreshing state via reading
+		{
+			RefreshState: true,
+		},
+		// Step 3: Test importing, if we're using an API key
+		{
+			SkipFunc: func() (bool, error) {
+				return !apiKey, nil
 			},
-			// Step 3: Test importing, if we're using an API key
-			{
-				SkipFunc: func() (bool, error) {
-					return !provider.APIKey(), nil
-				},
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"configuration.url", // May change during test
-				},
-				ResourceName: resourceName,
+			ImportState:       true,
+			ImportStateVerify: true,
+			ImportStateVerifyIgnore: []string{
+				"configuration.url", // May change during test
 			},
+			ResourceName: resourceName,
 		},
-	})
+	}
 }
 
 const connectionResourceTemplate = `
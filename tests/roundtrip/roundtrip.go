@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/polytomic/terraform-provider-polytomic/importer"
@@ -19,15 +20,41 @@ import (
 	"github.com/polytomic/terraform-provider-polytomic/provider"
 )
 
+// deploymentURL is the Polytomic API URL used by the Terraform CLI when
+// importing and planning. It serves the test's cassette, or is the URL of the
+// test's fake API, so the CLI sees the same objects as the provider.
+var deploymentURL string
+
 // PreCheck extends the standard precheck for round-trip testing
 func PreCheck(t *testing.T) func() {
 	return func() {
 		provider.TestAccPreCheck(t) // Reuse existing precheck from provider package
+		deploymentURL = os.Getenv(providerclient.PolytomicDeploymentURL)
+		terraformPreCheck(t)
+	}
+}
 
-		// Additional checks for round-trip testing
-		if _, err := exec.LookPath("terraform"); err != nil {
-			t.Fatal("terraform CLI not found in PATH")
-		}
+// FakePreCheck is the precheck for round-trip tests which use
+// FakeProviderFactories.
+func FakePreCheck(t *testing.T) func() {
+	return func() {
+		terraformPreCheck(t)
+	}
+}
+
+// FakeProviderFactories returns provider factories backed by a fake Polytomic
+// API, which the importer and the Terraform CLI use too.
+func FakeProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	factories, server := provider.FakeProtoV6ProviderFactories(t)
+	deploymentURL = server.URL
+	return factories
+}
+
+// terraformPreCheck checks that the Terraform CLI is available for importing
+// and planning.
+func terraformPreCheck(t *testing.T) {
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Fatal("terraform CLI not found in PATH")
 	}
 }
 
@@ -175,7 +202,11 @@ func runImporter(ctx context.Context, outputDir string, includePermissions bool)
 
 	// Initialize and run importer directly
 	// Note: importer.Init uses log.Fatal on errors, so if we get here it succeeded
-	importer.Init(ctx, client, "", outputDir, true, includePermissions)
+	importer.Init(ctx, client, importer.Options{
+		OutputPath:         outputDir,
+		Replace:            true,
+		IncludePermissions: includePermissions,
+	})
 
 	return nil
 }
@@ -384,7 +415,7 @@ func (ws *TerraformWorkspace) ExecuteImportScript(scriptPath string) error {
 		fmt.Sprintf("TF_CLI_CONFIG_FILE=%s", filepath.Join(ws.Dir, ".tfrc")),
 		fmt.Sprintf("POLYTOMIC_API_KEY=%s", os.Getenv("POLYTOMIC_API_KEY")),
 		fmt.Sprintf("POLYTOMIC_DEPLOYMENT_KEY=%s", os.Getenv("POLYTOMIC_DEPLOYMENT_KEY")),
-		fmt.Sprintf("POLYTOMIC_DEPLOYMENT_URL=%s", os.Getenv("POLYTOMIC_DEPLOYMENT_URL")),
+		fmt.Sprintf("POLYTOMIC_DEPLOYMENT_URL=%s", deploymentURL),
 	)
 
 	output, err := cmd.CombinedOutput()
@@ -403,7 +434,7 @@ func (ws *TerraformWorkspace) CheckForDrift() (bool, string, error) {
 		fmt.Sprintf("TF_CLI_CONFIG_FILE=%s", filepath.Join(ws.Dir, ".tfrc")),
 		fmt.Sprintf("POLYTOMIC_API_KEY=%s", os.Getenv("POLYTOMIC_API_KEY")),
 		fmt.Sprintf("POLYTOMIC_DEPLOYMENT_KEY=%s", os.Getenv("POLYTOMIC_DEPLOYMENT_KEY")),
-		fmt.Sprintf("POLYTOMIC_DEPLOYMENT_URL=%s", os.Getenv("POLYTOMIC_DEPLOYMENT_URL")),
+		fmt.Sprintf("POLYTOMIC_DEPLOYMENT_URL=%s", deploymentURL),
 	)
 
 	output, err := cmd.CombinedOutput()